@@ -1,10 +1,11 @@
 package api
 
-import "github.com/gin-gonic/gin"
+// BasePath is path prefix that api controllers are registered under.
+const BasePath = "/api/"
 
 // Controller is interface about api Controller.
 type Controller interface {
-	RegisterRoutes(router gin.IRouter)
+	RegisterRoutes(router *Router)
 }
 
 // IController is instance of Container
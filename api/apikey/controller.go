@@ -0,0 +1,132 @@
+package apikey
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/middleware"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+)
+
+// APIPath is path prefix
+const APIPath = "/apikeys/"
+
+// Controller is api key controller
+type Controller struct {
+	service Service
+}
+
+// NewController return new api key controller instance.
+func NewController(service Service) *Controller {
+	return &Controller{
+		service: service,
+	}
+}
+
+// RegisterRoutes register handler routes.
+// Api keys can not manage api keys, so leaked key can not mint new ones.
+func (controller *Controller) RegisterRoutes(router *api.Router) {
+	authorized := router.Group(APIPath,
+		middleware.AuthRequired(),
+		middleware.RateLimit(service.RateLimitUser),
+		middleware.RequireFullAccess())
+	{
+		authorized.Handle("POST", "/", controller.createAPIKey)
+		authorized.Handle("GET", "/", controller.getAPIKeys)
+		authorized.Handle("DELETE", "/:id", controller.revokeAPIKey)
+	}
+}
+
+// @Description Create new api key of current user that is shown only once
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param payload body apikey.CreateAPIKeyRequest true "api key payload"
+// @Success 201 {object} apikey.CreatedAPIKeyResponse "ok"
+// @Failure 400 {object} common.ErrorResponse "Invalid api key payload"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Tags API Key API
+// @Router /apikeys [post]
+func (controller *Controller) createAPIKey(ctx *gin.Context) {
+	var reqPayload CreateAPIKeyRequest
+
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	createdKey, key, err := controller.service.CreateAPIKey(ctx.Request.Context(),
+		ctx.GetInt64(middleware.UserIDKey),
+		reqPayload.Name,
+		reqPayload.Scopes,
+		time.Duration(reqPayload.ExpiresInSec)*time.Second,
+	)
+
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	res := CreatedAPIKeyResponse{
+		APIKeyResponse: createdKey.Response(),
+		Key:            key,
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// @Description Get api keys of current user
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {array} apikey.APIKeyResponse "ok"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Tags API Key API
+// @Router /apikeys [get]
+func (controller *Controller) getAPIKeys(ctx *gin.Context) {
+	keys, err := controller.service.GetAPIKeysByUserID(ctx.Request.Context(), ctx.GetInt64(middleware.UserIDKey))
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	res := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		res[i] = key.Response()
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Description Revoke api key of current user
+// @Security ApiKeyAuth
+// @Param id path int true "API Key ID"
+// @Success 204
+// @Failure 400 {object} common.ErrorResponse "Invalid api key id"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Failure 404 {object} common.ErrorResponse "Not found entity"
+// @Tags API Key API
+// @Router /apikeys/{id} [delete]
+func (controller *Controller) revokeAPIKey(ctx *gin.Context) {
+	keyID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.ErrParsingFailed)
+		return
+	}
+
+	if err := controller.service.RevokeAPIKey(ctx.Request.Context(),
+		ctx.GetInt64(middleware.UserIDKey),
+		keyID,
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,187 @@
+package apikey_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/api/apikey"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/internal/testutil"
+	"github.com/gghcode/go-gin-starterkit/middleware"
+	"github.com/gghcode/go-gin-starterkit/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type controllerIntegration struct {
+	suite.Suite
+
+	ginEngine *gin.Engine
+	dbConn    *db.Conn
+
+	testKeys []apikey.APIKey
+}
+
+func TestAPIKeyControllerIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	suite.Run(t, new(controllerIntegration))
+}
+
+func (suite *controllerIntegration) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	conf, err := config.NewBuilder().
+		BindEnvs("TEST").
+		Build()
+
+	dbConn, err := db.NewConn(conf)
+	require.NoError(suite.T(), err)
+
+	testutil.MigrateUp(suite.T(), dbConn)
+
+	suite.ginEngine = gin.New()
+	suite.ginEngine.Use(func(ctx *gin.Context) {
+		var innerHandler gin.HandlerFunc = func(ctx *gin.Context) {
+			ctx.Set(middleware.UserIDKey, int64(TestUserID))
+		}
+
+		ctx.Set(middleware.VerifyHandlerKey, innerHandler)
+		ctx.Next()
+	})
+
+	suite.dbConn = dbConn
+
+	apiKeyRepo := apikey.NewRepository(dbConn)
+	apiKeyService := apikey.NewService(apiKeyRepo, user.NewRepository(dbConn))
+	apiKeyController := apikey.NewController(apiKeyService)
+	apiKeyController.RegisterRoutes(api.NewRouter(&suite.ginEngine.RouterGroup))
+
+	suite.testKeys, err = pushTestDataToDB(apiKeyRepo)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *controllerIntegration) TearDownSuite() {
+	suite.dbConn.Close()
+}
+
+func (suite *controllerIntegration) TestCreateAPIKey() {
+	testCases := []struct {
+		description    string
+		reqBody        io.Reader
+		expectedStatus int
+	}{
+		{
+			description: "ShouldCreateAPIKey",
+			reqBody: testutil.ReqBodyFromInterface(suite.T(), apikey.CreateAPIKeyRequest{
+				Name:         "ci",
+				Scopes:       []string{service.ScopeTodosRead},
+				ExpiresInSec: 3600,
+			}),
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			description: "ShouldReturnBadRequestErr_WhenNoScopes",
+			reqBody: testutil.ReqBodyFromInterface(suite.T(), apikey.CreateAPIKeyRequest{
+				Name: "ci",
+			}),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "ShouldReturnBadRequestErr_WhenInvalidScope",
+			reqBody: testutil.ReqBodyFromInterface(suite.T(), apikey.CreateAPIKeyRequest{
+				Name:   "ci",
+				Scopes: []string{"unknown:scope"},
+			}),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualRes := testutil.ActualResponse(
+				suite.T(),
+				suite.ginEngine,
+				"POST",
+				apikey.APIPath,
+				tc.reqBody,
+			)
+
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var createdKey apikey.CreatedAPIKeyResponse
+				suite.NoError(json.NewDecoder(actualRes.Body).Decode(&createdKey))
+
+				suite.Contains(createdKey.Key, createdKey.Prefix)
+				suite.NotNil(createdKey.ExpiresAt)
+			}
+		})
+	}
+}
+
+func (suite *controllerIntegration) TestGetAPIKeys() {
+	actualRes := testutil.ActualResponse(
+		suite.T(),
+		suite.ginEngine,
+		"GET",
+		apikey.APIPath,
+		nil)
+
+	suite.Equal(http.StatusOK, actualRes.StatusCode)
+
+	var keys []apikey.APIKeyResponse
+	suite.NoError(json.NewDecoder(actualRes.Body).Decode(&keys))
+
+	suite.NotEmpty(keys)
+	for _, key := range keys {
+		suite.NotEqual(suite.testKeys[OtherUserKeyIdx].ID, key.ID)
+	}
+}
+
+func (suite *controllerIntegration) TestRevokeAPIKey() {
+	testCases := []struct {
+		description    string
+		argsKeyID      string
+		expectedStatus int
+	}{
+		{
+			description:    "ShouldRevokeAPIKey",
+			argsKeyID:      strconv.FormatInt(suite.testKeys[WillRemovedKeyIdx].ID, 10),
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			description:    "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsKeyID:      strconv.FormatInt(suite.testKeys[OtherUserKeyIdx].ID, 10),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description:    "ShouldReturnBadRequestErr_WhenInvalidID",
+			argsKeyID:      "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualRes := testutil.ActualResponse(
+				suite.T(),
+				suite.ginEngine,
+				"DELETE",
+				apikey.APIPath+tc.argsKeyID,
+				nil)
+
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package apikey
+
+import "time"
+
+// CreateAPIKeyRequest is request model for creating api key.
+// Key never expires when ExpiresInSec is omitted.
+type CreateAPIKeyRequest struct {
+	Name         string   `json:"name" example:"<key name>" binding:"required,max=100"`
+	Scopes       []string `json:"scopes" example:"todos:read" binding:"required,min=1"`
+	ExpiresInSec int64    `json:"expires_in_sec" example:"2592000" binding:"omitempty,min=60"`
+}
+
+// APIKeyResponse is api key response model.
+type APIKeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"create_at"`
+}
+
+// CreatedAPIKeyResponse is response model of created api key.
+// Key is responded only once since it is stored hashed.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+
+	Key string `json:"key"`
+}
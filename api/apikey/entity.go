@@ -0,0 +1,52 @@
+package apikey
+
+import (
+	"time"
+
+	pg "github.com/lib/pq"
+)
+
+// EmptyAPIKey is empty api key model
+var EmptyAPIKey = APIKey{}
+
+// APIKey is personal api key data model.
+// Only hash of key is stored, and Prefix is kept plain to look key up.
+// ExpiresAt and LastUsedAt are zero when key never expires or was never used.
+type APIKey struct {
+	ID         int64          `gorm:"primary_key;"`
+	UserID     int64          `gorm:"not null;index;"`
+	Name       string         `gorm:"not null;"`
+	Prefix     string         `gorm:"unique;not null;"`
+	KeyHash    string         `gorm:"not null;"`
+	Scopes     pg.StringArray `gorm:"type:text[];not null;"`
+	ExpiresAt  int64          `gorm:"not null;"`
+	LastUsedAt int64          `gorm:"not null;"`
+	CreatedAt  int64          `gorm:"not null;"`
+}
+
+// IsExpired return true when key expired at t.
+func (key APIKey) IsExpired(t time.Time) bool {
+	return key.ExpiresAt != 0 && key.ExpiresAt <= t.Unix()
+}
+
+// Response return new api key response from api key entity.
+func (key APIKey) Response() APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  unixTimeOrNil(key.ExpiresAt),
+		LastUsedAt: unixTimeOrNil(key.LastUsedAt),
+		CreatedAt:  time.Unix(key.CreatedAt, 0),
+	}
+}
+
+func unixTimeOrNil(sec int64) *time.Time {
+	if sec == 0 {
+		return nil
+	}
+
+	t := time.Unix(sec, 0)
+	return &t
+}
@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidScope is occurred when requested scope is unknown.
+var ErrInvalidScope = errors.New("Scope is invalid")
+
+// ErrorCodes map errors of api key api into status and code of response.
+var ErrorCodes = common.ErrorCodes{
+	common.ErrEntityNotFound: {Status: http.StatusNotFound, Code: "apikey.not_found"},
+	ErrInvalidScope:          {Status: http.StatusBadRequest, Code: "apikey.invalid_scope"},
+}
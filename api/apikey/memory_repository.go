@@ -0,0 +1,103 @@
+package apikey
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+)
+
+type memoryRepository struct {
+	mutex  sync.RWMutex
+	keys   map[int64]APIKey
+	lastID int64
+}
+
+// NewMemoryRepository return new in-memory repository
+// that behaves like postgres repository.
+func NewMemoryRepository() Repository {
+	return newTracedRepository(&memoryRepository{
+		keys: map[int64]APIKey{},
+	})
+}
+
+func (repo *memoryRepository) CreateAPIKey(ctx context.Context, key APIKey) (APIKey, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, k := range repo.keys {
+		if k.Prefix == key.Prefix {
+			return EmptyAPIKey, common.ErrAlreadyExistsEntity
+		}
+	}
+
+	repo.lastID++
+
+	key.ID = repo.lastID
+	key.CreatedAt = time.Now().Unix()
+	repo.keys[key.ID] = key
+
+	return key, nil
+}
+
+func (repo *memoryRepository) GetAPIKeysByUserID(ctx context.Context, userID int64) ([]APIKey, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	keys := []APIKey{}
+	for _, key := range repo.keys {
+		if key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys, nil
+}
+
+func (repo *memoryRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, key := range repo.keys {
+		if key.Prefix == prefix {
+			return key, nil
+		}
+	}
+
+	return EmptyAPIKey, common.ErrEntityNotFound
+}
+
+func (repo *memoryRepository) RemoveAPIKeyByID(ctx context.Context, userID int64, keyID int64) (APIKey, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	key, ok := repo.keys[keyID]
+	if !ok || key.UserID != userID {
+		return EmptyAPIKey, common.ErrEntityNotFound
+	}
+
+	delete(repo.keys, keyID)
+
+	return key, nil
+}
+
+func (repo *memoryRepository) UpdateLastUsedAtByID(ctx context.Context, keyID int64, lastUsedAt int64) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	key, ok := repo.keys[keyID]
+	if !ok {
+		return common.ErrEntityNotFound
+	}
+
+	key.LastUsedAt = lastUsedAt
+	repo.keys[keyID] = key
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/jinzhu/gorm"
+	pg "github.com/lib/pq"
+)
+
+// Repository communications with db connection.
+type Repository interface {
+	CreateAPIKey(ctx context.Context, key APIKey) (APIKey, error)
+
+	GetAPIKeysByUserID(ctx context.Context, userID int64) ([]APIKey, error)
+
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error)
+
+	RemoveAPIKeyByID(ctx context.Context, userID int64, keyID int64) (APIKey, error)
+
+	UpdateLastUsedAtByID(ctx context.Context, keyID int64, lastUsedAt int64) error
+}
+
+type repository struct {
+	dbConn *db.Conn
+}
+
+// NewRepository return new instance.
+func NewRepository(dbConn *db.Conn) Repository {
+	return newTracedRepository(&repository{
+		dbConn: dbConn,
+	})
+}
+
+func (repo *repository) CreateAPIKey(ctx context.Context, key APIKey) (APIKey, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	key.CreatedAt = time.Now().Unix()
+
+	err := repo.dbConn.WithContext(ctx).
+		Create(&key).
+		Error
+
+	if pgErr, ok := err.(*pg.Error); ok && pgErr.Code == "23505" {
+		// handle duplicate prefix
+		return EmptyAPIKey, common.ErrAlreadyExistsEntity
+	} else if err != nil {
+		return EmptyAPIKey, err
+	}
+
+	return key, nil
+}
+
+func (repo *repository) GetAPIKeysByUserID(ctx context.Context, userID int64) ([]APIKey, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	keys := []APIKey{}
+
+	err := repo.dbConn.WithContext(ctx).
+		Where("user_id=?", userID).
+		Order("id").
+		Find(&keys).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (repo *repository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	var key APIKey
+
+	err := repo.dbConn.WithContext(ctx).
+		Where("prefix=?", prefix).
+		First(&key).
+		Error
+
+	if err == gorm.ErrRecordNotFound {
+		return EmptyAPIKey, common.ErrEntityNotFound
+	} else if err != nil {
+		return EmptyAPIKey, err
+	}
+
+	return key, nil
+}
+
+func (repo *repository) RemoveAPIKeyByID(ctx context.Context, userID int64, keyID int64) (APIKey, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	var key APIKey
+
+	err := repo.dbConn.WithContext(ctx).
+		Where("id=? AND user_id=?", keyID, userID).
+		First(&key).
+		Error
+
+	if err == gorm.ErrRecordNotFound {
+		return EmptyAPIKey, common.ErrEntityNotFound
+	} else if err != nil {
+		return EmptyAPIKey, err
+	}
+
+	err = repo.dbConn.WithContext(ctx).
+		Delete(&key).
+		Error
+
+	if err != nil {
+		return EmptyAPIKey, err
+	}
+
+	return key, nil
+}
+
+func (repo *repository) UpdateLastUsedAtByID(ctx context.Context, keyID int64, lastUsedAt int64) error {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	result := repo.dbConn.WithContext(ctx).
+		Model(&APIKey{}).
+		Where("id=?", keyID).
+		Update("last_used_at", lastUsedAt)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrEntityNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package apikey_test
+
+import (
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/apikey"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/internal/testutil"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type repoIntegration struct {
+	repoSuite
+
+	gormDB *gorm.DB
+	dbConn *db.Conn
+}
+
+func TestAPIKeyRepoIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	suite.Run(t, new(repoIntegration))
+}
+
+func (suite *repoIntegration) SetupSuite() {
+	conf, err := config.NewBuilder().
+		BindEnvs("TEST").
+		Build()
+
+	dbConn, err := db.NewConn(conf)
+	require.NoError(suite.T(), err)
+
+	testutil.MigrateUp(suite.T(), dbConn)
+
+	suite.dbConn = dbConn
+	suite.repo = apikey.NewRepository(suite.dbConn)
+
+	suite.repoSuite.SetupSuite()
+}
+
+func (suite *repoIntegration) TearDownSuite() {
+	suite.dbConn.Close()
+}
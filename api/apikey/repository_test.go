@@ -0,0 +1,206 @@
+package apikey_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/apikey"
+	"github.com/gghcode/go-gin-starterkit/api/common"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	WillFetchedKeyIdx = 0
+	WillRemovedKeyIdx = 1
+	OtherUserKeyIdx   = 2
+
+	TestUserID  = 1
+	OtherUserID = 2
+)
+
+// repoSuite runs api key cases on either repository,
+// so prefix stays unique in memory as in postgres.
+type repoSuite struct {
+	suite.Suite
+
+	repo apikey.Repository
+
+	testKeys []apikey.APIKey
+}
+
+func TestAPIKeyMemoryRepoUnit(t *testing.T) {
+	suite.Run(t, &repoSuite{repo: apikey.NewMemoryRepository()})
+}
+
+// SetupSuite seed repo with test api keys.
+func (suite *repoSuite) SetupSuite() {
+	testKeys, err := pushTestDataToDB(suite.repo)
+	require.NoError(suite.T(), err)
+
+	suite.testKeys = testKeys
+}
+
+func pushTestDataToDB(repo apikey.Repository) ([]apikey.APIKey, error) {
+	keys := []apikey.APIKey{
+		apikey.APIKey{UserID: TestUserID, Name: "will fetched key", Scopes: []string{"todos:read"}},
+		apikey.APIKey{UserID: TestUserID, Name: "will removed key", Scopes: []string{"todos:write"}},
+		apikey.APIKey{UserID: OtherUserID, Name: "other user key", Scopes: []string{"todos:read"}},
+	}
+
+	var result []apikey.APIKey
+
+	for _, key := range keys {
+		key.Prefix = uniquePrefix()
+		key.KeyHash = "hash of " + key.Prefix
+
+		insertedKey, err := repo.CreateAPIKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, insertedKey)
+	}
+
+	return result, nil
+}
+
+// uniquePrefix return prefix that does not collide across test runs on same database.
+func uniquePrefix() string {
+	return apikey.KeyPrefix + uuid.NewV4().String()
+}
+
+func (suite *repoSuite) TestCreateAPIKey() {
+	prefix := uniquePrefix()
+
+	testCases := []struct {
+		description   string
+		argsKey       apikey.APIKey
+		expectedKeyFn func(apikey.APIKey) apikey.APIKey
+		expectedErr   error
+	}{
+		{
+			description: "ShouldCreateAPIKey",
+			argsKey:     apikey.APIKey{UserID: TestUserID, Name: "new key", Prefix: prefix, Scopes: []string{"todos:read"}},
+			expectedKeyFn: func(actualKey apikey.APIKey) apikey.APIKey {
+				key := apikey.APIKey{UserID: TestUserID, Name: "new key", Prefix: prefix, Scopes: []string{"todos:read"}}
+				key.ID = actualKey.ID
+				key.CreatedAt = actualKey.CreatedAt
+
+				return key
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnAlreadyExistsErr_WhenDuplicatePrefix",
+			argsKey:     apikey.APIKey{UserID: TestUserID, Name: "duplicate key", Prefix: prefix, Scopes: []string{"todos:read"}},
+			expectedKeyFn: func(apikey.APIKey) apikey.APIKey {
+				return apikey.EmptyAPIKey
+			},
+			expectedErr: common.ErrAlreadyExistsEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualKey, actualErr := suite.repo.CreateAPIKey(context.Background(), tc.argsKey)
+
+			suite.Equal(tc.expectedKeyFn(actualKey), actualKey)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestGetAPIKeysByUserID() {
+	actualKeys, err := suite.repo.GetAPIKeysByUserID(context.Background(), OtherUserID)
+
+	suite.NoError(err)
+	suite.Equal([]apikey.APIKey{suite.testKeys[OtherUserKeyIdx]}, actualKeys)
+}
+
+func (suite *repoSuite) TestGetAPIKeyByPrefix() {
+	testCases := []struct {
+		description string
+		argsPrefix  string
+		expectedKey apikey.APIKey
+		expectedErr error
+	}{
+		{
+			description: "ShouldFetchAPIKey",
+			argsPrefix:  suite.testKeys[WillFetchedKeyIdx].Prefix,
+			expectedKey: suite.testKeys[WillFetchedKeyIdx],
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnNotFoundErr",
+			argsPrefix:  "NOT_EXISTS_PREFIX",
+			expectedKey: apikey.EmptyAPIKey,
+			expectedErr: common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualKey, actualErr := suite.repo.GetAPIKeyByPrefix(context.Background(), tc.argsPrefix)
+
+			suite.Equal(tc.expectedKey, actualKey)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestRemoveAPIKeyByID() {
+	testCases := []struct {
+		description string
+		argsUserID  int64
+		argsKeyID   int64
+		expectedKey apikey.APIKey
+		expectedErr error
+	}{
+		{
+			description: "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsUserID:  TestUserID,
+			argsKeyID:   suite.testKeys[OtherUserKeyIdx].ID,
+			expectedKey: apikey.EmptyAPIKey,
+			expectedErr: common.ErrEntityNotFound,
+		},
+		{
+			description: "ShouldRemoveAPIKey",
+			argsUserID:  TestUserID,
+			argsKeyID:   suite.testKeys[WillRemovedKeyIdx].ID,
+			expectedKey: suite.testKeys[WillRemovedKeyIdx],
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnNotFoundErr_WhenRemoved",
+			argsUserID:  TestUserID,
+			argsKeyID:   suite.testKeys[WillRemovedKeyIdx].ID,
+			expectedKey: apikey.EmptyAPIKey,
+			expectedErr: common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualKey, actualErr := suite.repo.RemoveAPIKeyByID(context.Background(), tc.argsUserID, tc.argsKeyID)
+
+			suite.Equal(tc.expectedKey, actualKey)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestUpdateLastUsedAtByID() {
+	key := suite.testKeys[WillFetchedKeyIdx]
+
+	suite.NoError(suite.repo.UpdateLastUsedAtByID(context.Background(), key.ID, 1000))
+
+	actualKey, err := suite.repo.GetAPIKeyByPrefix(context.Background(), key.Prefix)
+	suite.NoError(err)
+	suite.Equal(int64(1000), actualKey.LastUsedAt)
+
+	err = suite.repo.UpdateLastUsedAtByID(context.Background(), -1, 1000)
+	suite.Equal(common.ErrEntityNotFound, err)
+}
@@ -0,0 +1,175 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/service"
+)
+
+const (
+	// KeyPrefix is prefix of every api key, so leaked keys are easy to detect.
+	KeyPrefix = "sk_"
+
+	prefixBytes = 6
+	secretBytes = 32
+
+	// lastUsedPrecision is how stale last used time may get
+	// to avoid writing it on every request.
+	lastUsedPrecision = time.Minute
+)
+
+// Service is api key service.
+type Service interface {
+	service.APIKeyAuthenticator
+
+	CreateAPIKey(ctx context.Context, userID int64, name string, scopes []string, expiresIn time.Duration) (APIKey, string, error)
+	GetAPIKeysByUserID(ctx context.Context, userID int64) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID int64, keyID int64) error
+}
+
+type apiKeyService struct {
+	repo     Repository
+	userRepo user.Repository
+}
+
+// NewService return new api key service instance.
+func NewService(repo Repository, userRepo user.Repository) Service {
+	return &apiKeyService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateAPIKey create api key of user and return it with plain key.
+// Key never expires when expiresIn is zero.
+func (apiKeyService *apiKeyService) CreateAPIKey(ctx context.Context, userID int64, name string, scopes []string, expiresIn time.Duration) (APIKey, string, error) {
+	for _, scope := range scopes {
+		if !service.IsValidScope(scope) {
+			return EmptyAPIKey, "", ErrInvalidScope
+		}
+	}
+
+	prefix, key, err := newKey()
+	if err != nil {
+		return EmptyAPIKey, "", err
+	}
+
+	apiKey := APIKey{
+		UserID:  userID,
+		Name:    name,
+		Prefix:  prefix,
+		KeyHash: hashKey(key),
+		Scopes:  scopes,
+	}
+
+	if expiresIn > 0 {
+		apiKey.ExpiresAt = time.Now().Add(expiresIn).Unix()
+	}
+
+	createdKey, err := apiKeyService.repo.CreateAPIKey(ctx, apiKey)
+	if err != nil {
+		return EmptyAPIKey, "", err
+	}
+
+	return createdKey, key, nil
+}
+
+func (apiKeyService *apiKeyService) GetAPIKeysByUserID(ctx context.Context, userID int64) ([]APIKey, error) {
+	return apiKeyService.repo.GetAPIKeysByUserID(ctx, userID)
+}
+
+func (apiKeyService *apiKeyService) RevokeAPIKey(ctx context.Context, userID int64, keyID int64) error {
+	_, err := apiKeyService.repo.RemoveAPIKeyByID(ctx, userID, keyID)
+	return err
+}
+
+// AuthenticateAPIKey look key up by its prefix and verify its hash,
+// then record when it was used.
+func (apiKeyService *apiKeyService) AuthenticateAPIKey(ctx context.Context, key string) (service.APIKeyIdentity, error) {
+	prefix, ok := parseKey(key)
+	if !ok {
+		return service.APIKeyIdentity{}, service.ErrInvalidAPIKey
+	}
+
+	apiKey, err := apiKeyService.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if err == common.ErrEntityNotFound {
+		return service.APIKeyIdentity{}, service.ErrInvalidAPIKey
+	} else if err != nil {
+		return service.APIKeyIdentity{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashKey(key))) != 1 {
+		return service.APIKeyIdentity{}, service.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if apiKey.IsExpired(now) {
+		return service.APIKeyIdentity{}, service.ErrAPIKeyExpired
+	}
+
+	keyUser, err := apiKeyService.userRepo.GetUserByUserID(ctx, apiKey.UserID)
+	if err == common.ErrEntityNotFound {
+		return service.APIKeyIdentity{}, service.ErrInvalidAPIKey
+	} else if err != nil {
+		return service.APIKeyIdentity{}, err
+	}
+
+	if now.Sub(time.Unix(apiKey.LastUsedAt, 0)) >= lastUsedPrecision {
+		if err := apiKeyService.repo.UpdateLastUsedAtByID(ctx, apiKey.ID, now.Unix()); err != nil {
+			return service.APIKeyIdentity{}, err
+		}
+	}
+
+	return service.APIKeyIdentity{
+		KeyID:  apiKey.ID,
+		UserID: keyUser.ID,
+		Role:   keyUser.Role,
+		Scopes: apiKey.Scopes,
+	}, nil
+}
+
+// newKey return new key formatted as sk_<prefix>_<secret> and its lookup prefix.
+func newKey() (string, string, error) {
+	prefix := make([]byte, prefixBytes)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", "", err
+	}
+
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	lookupPrefix := KeyPrefix + hex.EncodeToString(prefix)
+
+	return lookupPrefix, lookupPrefix + "_" + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// parseKey return lookup prefix of key.
+// Secret may contain underscores, while prefix is hex encoded.
+func parseKey(key string) (string, bool) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, KeyPrefix), "_", 2)
+	if len(parts) != 2 || len(parts[0]) != hex.EncodedLen(prefixBytes) || parts[1] == "" {
+		return "", false
+	}
+
+	return KeyPrefix + parts[0], true
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
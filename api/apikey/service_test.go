@@ -0,0 +1,159 @@
+package apikey_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/apikey"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type serviceUnit struct {
+	suite.Suite
+
+	repo           apikey.Repository
+	apiKeyService  apikey.Service
+	testUser       user.User
+	testKey        apikey.APIKey
+	testPlainKey   string
+	removedUserKey string
+}
+
+func TestAPIKeyServiceUnit(t *testing.T) {
+	suite.Run(t, new(serviceUnit))
+}
+
+func (suite *serviceUnit) SetupTest() {
+	ctx := context.Background()
+
+	userRepo := user.NewMemoryRepository()
+	suite.repo = apikey.NewMemoryRepository()
+	suite.apiKeyService = apikey.NewService(suite.repo, userRepo)
+
+	testUser, err := userRepo.CreateUser(ctx, user.User{UserName: "username", Role: service.RoleAdmin})
+	suite.Require().NoError(err)
+
+	removedUser, err := userRepo.CreateUser(ctx, user.User{UserName: "removed"})
+	suite.Require().NoError(err)
+
+	suite.testUser = testUser
+	suite.testKey, suite.testPlainKey, err = suite.apiKeyService.CreateAPIKey(ctx,
+		testUser.ID,
+		"ci",
+		[]string{service.ScopeTodosRead},
+		0,
+	)
+	suite.Require().NoError(err)
+
+	_, suite.removedUserKey, err = suite.apiKeyService.CreateAPIKey(ctx, removedUser.ID, "ci", nil, 0)
+	suite.Require().NoError(err)
+
+	_, err = userRepo.RemoveUserByUserID(ctx, removedUser.ID)
+	suite.Require().NoError(err)
+}
+
+func (suite *serviceUnit) TestCreateAPIKey() {
+	suite.Contains(suite.testPlainKey, suite.testKey.Prefix+"_")
+	suite.NotContains(suite.testKey.KeyHash, suite.testPlainKey)
+	suite.Zero(suite.testKey.ExpiresAt)
+
+	_, _, err := suite.apiKeyService.CreateAPIKey(context.Background(),
+		suite.testUser.ID,
+		"invalid",
+		[]string{"unknown:scope"},
+		0,
+	)
+
+	suite.Equal(apikey.ErrInvalidScope, err)
+}
+
+func (suite *serviceUnit) TestAuthenticateAPIKey() {
+	expiredPlainKey := apikey.KeyPrefix + "000000000000_expired"
+	expiredKeyHash := sha256.Sum256([]byte(expiredPlainKey))
+
+	_, err := suite.repo.CreateAPIKey(context.Background(), apikey.APIKey{
+		UserID:    suite.testUser.ID,
+		Prefix:    apikey.KeyPrefix + "000000000000",
+		KeyHash:   hex.EncodeToString(expiredKeyHash[:]),
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	})
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		description      string
+		key              string
+		expectedIdentity service.APIKeyIdentity
+		expectedErr      error
+	}{
+		{
+			description: "ShouldBeSuccess",
+			key:         suite.testPlainKey,
+			expectedIdentity: service.APIKeyIdentity{
+				KeyID:  suite.testKey.ID,
+				UserID: suite.testUser.ID,
+				Role:   service.RoleAdmin,
+				Scopes: []string{service.ScopeTodosRead},
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnInvalidAPIKeyErr_WhenWrongSecret",
+			key:         suite.testKey.Prefix + "_wrong",
+			expectedErr: service.ErrInvalidAPIKey,
+		},
+		{
+			description: "ShouldReturnInvalidAPIKeyErr_WhenUnknownPrefix",
+			key:         apikey.KeyPrefix + "ffffffffffff_secret",
+			expectedErr: service.ErrInvalidAPIKey,
+		},
+		{
+			description: "ShouldReturnInvalidAPIKeyErr_WhenMalformed",
+			key:         "malformed",
+			expectedErr: service.ErrInvalidAPIKey,
+		},
+		{
+			description: "ShouldReturnInvalidAPIKeyErr_WhenUserRemoved",
+			key:         suite.removedUserKey,
+			expectedErr: service.ErrInvalidAPIKey,
+		},
+		{
+			description: "ShouldReturnAPIKeyExpiredErr",
+			key:         expiredPlainKey,
+			expectedErr: service.ErrAPIKeyExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualIdentity, actualErr := suite.apiKeyService.AuthenticateAPIKey(context.Background(), tc.key)
+
+			suite.Equal(tc.expectedErr, actualErr)
+			suite.Equal(tc.expectedIdentity, actualIdentity)
+		})
+	}
+}
+
+func (suite *serviceUnit) TestAuthenticateAPIKey_ShouldRecordLastUsedAt() {
+	_, err := suite.apiKeyService.AuthenticateAPIKey(context.Background(), suite.testPlainKey)
+	suite.Require().NoError(err)
+
+	keys, err := suite.apiKeyService.GetAPIKeysByUserID(context.Background(), suite.testUser.ID)
+	suite.Require().NoError(err)
+
+	suite.Require().Len(keys, 1)
+	suite.NotZero(keys[0].LastUsedAt)
+}
+
+func (suite *serviceUnit) TestRevokeAPIKey() {
+	ctx := context.Background()
+
+	suite.NoError(suite.apiKeyService.RevokeAPIKey(ctx, suite.testUser.ID, suite.testKey.ID))
+
+	_, err := suite.apiKeyService.AuthenticateAPIKey(ctx, suite.testPlainKey)
+	suite.Equal(service.ErrInvalidAPIKey, err)
+}
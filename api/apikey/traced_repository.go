@@ -0,0 +1,63 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/gghcode/go-gin-starterkit/tracing"
+)
+
+// tracedRepository record span of each api key query,
+// including last used update made per authenticated request.
+type tracedRepository struct {
+	next Repository
+}
+
+func newTracedRepository(next Repository) Repository {
+	return &tracedRepository{next: next}
+}
+
+func (repo *tracedRepository) CreateAPIKey(ctx context.Context, key APIKey) (APIKey, error) {
+	var result APIKey
+	err := tracing.Trace(ctx, "apikey.Repository/CreateAPIKey", func(ctx context.Context) (err error) {
+		result, err = repo.next.CreateAPIKey(ctx, key)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetAPIKeysByUserID(ctx context.Context, userID int64) ([]APIKey, error) {
+	var result []APIKey
+	err := tracing.Trace(ctx, "apikey.Repository/GetAPIKeysByUserID", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetAPIKeysByUserID(ctx, userID)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error) {
+	var result APIKey
+	err := tracing.Trace(ctx, "apikey.Repository/GetAPIKeyByPrefix", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetAPIKeyByPrefix(ctx, prefix)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) RemoveAPIKeyByID(ctx context.Context, userID int64, keyID int64) (APIKey, error) {
+	var result APIKey
+	err := tracing.Trace(ctx, "apikey.Repository/RemoveAPIKeyByID", func(ctx context.Context) (err error) {
+		result, err = repo.next.RemoveAPIKeyByID(ctx, userID, keyID)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) UpdateLastUsedAtByID(ctx context.Context, keyID int64, lastUsedAt int64) error {
+	return tracing.Trace(ctx, "apikey.Repository/UpdateLastUsedAtByID", func(ctx context.Context) error {
+		return repo.next.UpdateLastUsedAtByID(ctx, keyID, lastUsedAt)
+	})
+}
@@ -2,10 +2,12 @@ package auth
 
 import (
 	"net/http"
-	"strconv"
 
+	"github.com/gghcode/go-gin-starterkit/api"
 	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/middleware"
+	"github.com/gghcode/go-gin-starterkit/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,6 +17,7 @@ const APIPath = "/auth/"
 // Controller is auth controller
 type Controller struct {
 	conf    config.JwtConfig
+	mfaConf config.MFAConfig
 	service Service
 }
 
@@ -22,111 +25,415 @@ type Controller struct {
 func NewController(conf config.Configuration, service Service) *Controller {
 	return &Controller{
 		conf:    conf.Jwt,
+		mfaConf: conf.MFA,
 		service: service,
 	}
 }
 
 // RegisterRoutes register handler routes.
-func (controller *Controller) RegisterRoutes(router gin.IRouter) {
-	router.Handle("POST", APIPath+"/token", controller.issueToken)
-	router.Handle("POST", APIPath+"/refresh", controller.refreshToken)
+func (controller *Controller) RegisterRoutes(router *api.Router) {
+	router.Handle("POST", APIPath+"/token",
+		middleware.RateLimit(service.RateLimitToken),
+		controller.issueToken)
+	router.Handle("POST", APIPath+"/mfa/verify",
+		middleware.RateLimit(service.RateLimitMFA),
+		controller.verifyMFA)
+	router.Handle("POST", APIPath+"/refresh",
+		middleware.RateLimit(service.RateLimitRefresh),
+		controller.refreshToken)
+	router.Handle("POST", APIPath+"/password/forgot",
+		middleware.RateLimit(service.RateLimitPasswordReset),
+		controller.forgotPassword)
+	router.Handle("POST", APIPath+"/password/reset",
+		middleware.RateLimit(service.RateLimitPasswordReset),
+		controller.resetPassword)
+
+	authorized := router.Group(APIPath,
+		middleware.AuthRequired(),
+		middleware.RateLimit(service.RateLimitUser))
+	{
+		authorized.Handle("POST", "/logout",
+			middleware.RequireFullAccess(),
+			controller.logout)
+		authorized.Handle("POST", "/logout/all",
+			middleware.RequireFullAccess(),
+			controller.logoutAll)
+		authorized.Handle("DELETE", "/lockouts/:username",
+			middleware.RequireScope(service.ScopeUsersWrite),
+			middleware.RequirePermission(service.PermissionManageUsers),
+			controller.unlockAccount)
+		authorized.Handle("POST", "/mfa/totp",
+			middleware.RequireFullAccess(),
+			controller.enrollTOTP)
+		authorized.Handle("POST", "/mfa/totp/confirm",
+			middleware.RequireFullAccess(),
+			controller.confirmTOTP)
+		authorized.Handle("DELETE", "/mfa/totp",
+			middleware.RequireFullAccess(),
+			controller.disableTOTP)
+	}
 }
 
 // @Description Get new access token
 // @Accept json
 // @Produce json
 // @Param payload body auth.CreateAccessTokenRequest true "payload"
-// @Success 200 {object} auth.TokenResponse "ok"
+// @Success 200 {object} auth.TokenResponse "ok, or auth.MFAChallengeResponse when two factor authentication is enabled"
 // @Failure 400 {object} common.ErrorResponse "Invalid payload"
 // @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 423 {object} common.ErrorResponse "Account locked"
+// @Failure 429 {object} common.ErrorResponse "Too many requests"
 // @Tags Auth API
 // @Router /auth/token [post]
 func (controller *Controller) issueToken(ctx *gin.Context) {
 	var reqPayload CreateAccessTokenRequest
 
 	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
-		ctx.JSON(
-			http.StatusBadRequest,
-			common.NewErrResp(common.ErrInvalidRequestPayload),
-		)
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
 		return
 	}
 
-	loginUser, err := controller.service.VerifyAuthentication(
+	loginUser, err := controller.service.VerifyAuthentication(ctx.Request.Context(),
 		reqPayload.UserName,
 		reqPayload.Password,
+		middleware.GetClientIP(ctx),
+	)
+
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	if loginUser.TOTPEnabled {
+		mfaToken, err := controller.service.IssueMFAChallenge(ctx.Request.Context(), loginUser.ID)
+		if err != nil {
+			common.WriteErrResp(ctx, ErrorCodes, err)
+			return
+		}
+
+		res := MFAChallengeResponse{
+			Type:      "mfa",
+			MFAToken:  mfaToken,
+			ExpiresIn: controller.mfaConf.ChallengeExpiresSec,
+		}
+
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	controller.writeTokenResp(ctx, loginUser.ID)
+}
+
+// @Description Get new access token by mfa challenge token and TOTP or recovery code
+// @Accept json
+// @Produce json
+// @Param payload body auth.VerifyMFARequest true "payload"
+// @Success 200 {object} auth.TokenResponse "ok"
+// @Failure 400 {object} common.ErrorResponse "Invalid payload"
+// @Failure 401 {object} common.ErrorResponse "Invalid mfa token or code"
+// @Failure 423 {object} common.ErrorResponse "Account locked"
+// @Failure 429 {object} common.ErrorResponse "Too many requests"
+// @Tags Auth API
+// @Router /auth/mfa/verify [post]
+func (controller *Controller) verifyMFA(ctx *gin.Context) {
+	var reqPayload VerifyMFARequest
+
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	loginUser, err := controller.service.VerifyMFAChallenge(ctx.Request.Context(),
+		reqPayload.MFAToken,
+		reqPayload.Code,
+		reqPayload.RecoveryCode,
+		middleware.GetClientIP(ctx),
 	)
 
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
-	accessToken, err := controller.service.GenerateAccessToken(loginUser.ID)
+	controller.writeTokenResp(ctx, loginUser.ID)
+}
+
+func (controller *Controller) writeTokenResp(ctx *gin.Context, userID int64) {
+	refreshToken, err := controller.service.IssueRefreshToken(ctx.Request.Context(), userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
-	refreshToken, err := controller.service.IssueRefreshToken(loginUser.ID)
+	accessToken, err := controller.service.GenerateAccessToken(ctx.Request.Context(),
+		userID,
+		refreshToken.SessionID,
+	)
+
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
 	res := TokenResponse{
 		Type:         "Bearer",
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: refreshToken.Token,
 		ExpiresIn:    controller.conf.AccessExpiresInSec,
 	}
 
 	ctx.JSON(http.StatusOK, res)
 }
 
-// @Description Get new access token by refreshtoken
+// @Description Get new access token and rotated refresh token by refreshtoken
 // @Accept json
 // @Produce json
 // @Param payload body auth.AccessTokenByRefreshRequest true "payload"
 // @Success 200 {object} auth.TokenResponse "ok"
 // @Failure 400 {object} common.ErrorResponse "Invalid payload"
 // @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 429 {object} common.ErrorResponse "Too many requests"
 // @Tags Auth API
 // @Router /auth/refresh [post]
 func (controller *Controller) refreshToken(ctx *gin.Context) {
 	var reqPayload AccessTokenByRefreshRequest
 
 	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
-		ctx.JSON(
-			http.StatusBadRequest,
-			common.NewErrResp(common.ErrInvalidRequestPayload),
-		)
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
 		return
 	}
 
-	tokenClaims, err := controller.service.ExtractTokenClaims(reqPayload.Token)
+	refreshToken, err := controller.service.RotateRefreshToken(ctx.Request.Context(), reqPayload.Token)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	accessToken, err := controller.service.GenerateAccessToken(ctx.Request.Context(),
+		refreshToken.UserID,
+		refreshToken.SessionID,
+	)
+
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	res := TokenResponse{
+		Type:         "Bearer",
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken.Token,
+		ExpiresIn:    controller.conf.AccessExpiresInSec,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Description Logout current session
+// @Security ApiKeyAuth
+// @Success 204
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Tags Auth API
+// @Router /auth/logout [post]
+func (controller *Controller) logout(ctx *gin.Context) {
+	if err := controller.service.RevokeAccessToken(ctx.Request.Context(),
+		ctx.GetString(middleware.TokenIDKey),
+		ctx.GetTime(middleware.TokenExpiresAtKey),
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	if sessionID := ctx.GetString(middleware.SessionIDKey); sessionID != "" {
+		if err := controller.service.RevokeSession(ctx.Request.Context(),
+			ctx.GetInt64(middleware.UserIDKey),
+			sessionID,
+		); err != nil {
+			common.WriteErrResp(ctx, ErrorCodes, err)
+			return
+		}
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Description Logout all sessions of current user
+// @Security ApiKeyAuth
+// @Success 204
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Tags Auth API
+// @Router /auth/logout/all [post]
+func (controller *Controller) logoutAll(ctx *gin.Context) {
+	if err := controller.service.RevokeAccessToken(ctx.Request.Context(),
+		ctx.GetString(middleware.TokenIDKey),
+		ctx.GetTime(middleware.TokenExpiresAtKey),
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	if err := controller.service.RevokeAllSessions(ctx.Request.Context(),
+		ctx.GetInt64(middleware.UserIDKey),
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Description Unlock account locked by failed logins
+// @Security ApiKeyAuth
+// @Param username path string true "User Name"
+// @Param ip query string false "Client ip locked by failed logins"
+// @Success 204
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Permission denied"
+// @Tags Auth API
+// @Router /auth/lockouts/{username} [delete]
+func (controller *Controller) unlockAccount(ctx *gin.Context) {
+	if err := controller.service.UnlockAccount(ctx.Request.Context(),
+		ctx.Param("username"),
+		ctx.Query("ip"),
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Description Issue password reset token that is delivered to user by notifier
+// @Accept json
+// @Param payload body auth.ForgotPasswordRequest true "payload"
+// @Success 202
+// @Failure 400 {object} common.ErrorResponse "Invalid payload"
+// @Failure 429 {object} common.ErrorResponse "Too many requests"
+// @Tags Auth API
+// @Router /auth/password/forgot [post]
+func (controller *Controller) forgotPassword(ctx *gin.Context) {
+	var reqPayload ForgotPasswordRequest
+
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
 		return
 	}
 
-	userID, _ := strconv.ParseInt(tokenClaims["sub"].(string), 10, 64)
-	if valid := controller.service.VerifyRefreshToken(userID, reqPayload.Token); !valid {
-		ctx.JSON(http.StatusUnauthorized, common.NewErrResp(ErrInvalidRefreshToken))
+	if err := controller.service.RequestPasswordReset(ctx.Request.Context(), reqPayload.UserName); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
-	accessToken, err := controller.service.GenerateAccessToken(userID)
+	ctx.Status(http.StatusAccepted)
+}
+
+// @Description Reset password by reset token and logout all sessions of user
+// @Accept json
+// @Param payload body auth.ResetPasswordRequest true "payload"
+// @Success 204
+// @Failure 400 {object} common.ErrorResponse "Invalid payload or reset token"
+// @Failure 429 {object} common.ErrorResponse "Too many requests"
+// @Tags Auth API
+// @Router /auth/password/reset [post]
+func (controller *Controller) resetPassword(ctx *gin.Context) {
+	var reqPayload ResetPasswordRequest
+
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	if err := controller.service.ResetPassword(ctx.Request.Context(),
+		reqPayload.Token,
+		reqPayload.Password,
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Description Start TOTP enrollment of current user
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth.TOTPEnrollmentResponse "ok"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 409 {object} common.ErrorResponse "Already enabled"
+// @Tags Auth API
+// @Router /auth/mfa/totp [post]
+func (controller *Controller) enrollTOTP(ctx *gin.Context) {
+	enrollment, err := controller.service.EnrollTOTP(ctx.Request.Context(), ctx.GetInt64(middleware.UserIDKey))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
-	res := TokenResponse{
-		Type:        "Bearer",
-		AccessToken: accessToken,
-		ExpiresIn:   controller.conf.AccessExpiresInSec,
+	res := TOTPEnrollmentResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
 	}
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+// @Description Enable TOTP of current user by code and get recovery codes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param payload body auth.ConfirmTOTPRequest true "payload"
+// @Success 200 {object} auth.RecoveryCodesResponse "ok"
+// @Failure 400 {object} common.ErrorResponse "Invalid payload or not enrolled"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential or code"
+// @Failure 409 {object} common.ErrorResponse "Already enabled"
+// @Tags Auth API
+// @Router /auth/mfa/totp/confirm [post]
+func (controller *Controller) confirmTOTP(ctx *gin.Context) {
+	var reqPayload ConfirmTOTPRequest
+
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	recoveryCodes, err := controller.service.ConfirmTOTP(ctx.Request.Context(),
+		ctx.GetInt64(middleware.UserIDKey),
+		reqPayload.Code,
+	)
+
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// @Description Disable TOTP of current user by code or recovery code
+// @Security ApiKeyAuth
+// @Accept json
+// @Param payload body auth.DisableTOTPRequest true "payload"
+// @Success 204
+// @Failure 400 {object} common.ErrorResponse "Invalid payload or not enrolled"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential or code"
+// @Tags Auth API
+// @Router /auth/mfa/totp [delete]
+func (controller *Controller) disableTOTP(ctx *gin.Context) {
+	var reqPayload DisableTOTPRequest
+
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	if err := controller.service.DisableTOTP(ctx.Request.Context(),
+		ctx.GetInt64(middleware.UserIDKey),
+		reqPayload.Code,
+		reqPayload.RecoveryCode,
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
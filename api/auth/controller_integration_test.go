@@ -1,15 +1,20 @@
 package auth_test
 
 import (
+	"context"
+	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
+	"github.com/gghcode/go-gin-starterkit/api"
 	"github.com/gghcode/go-gin-starterkit/api/auth"
 	"github.com/gghcode/go-gin-starterkit/api/user"
 	"github.com/gghcode/go-gin-starterkit/config"
 	"github.com/gghcode/go-gin-starterkit/db"
 	"github.com/gghcode/go-gin-starterkit/internal/testutil"
+	"github.com/gghcode/go-gin-starterkit/middleware"
 	"github.com/gghcode/go-gin-starterkit/service"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -44,25 +49,35 @@ func (suite *controllerIntegration) SetupSuite() {
 	dbConn, err := db.NewConn(conf)
 	require.NoError(suite.T(), err)
 
+	testutil.MigrateUp(suite.T(), dbConn)
+
+	redisConn := db.NewRedisConn(conf)
+	denylist := service.NewTokenDenylist(redisConn)
+
+	keys, err := service.NewKeyProvider(conf)
+	require.NoError(suite.T(), err)
+
 	suite.ginEngine = gin.New()
+	suite.ginEngine.Use(middleware.AddAuthHandler(keys, denylist, nil))
 	suite.dbConn = dbConn
 
 	userRepo := user.NewRepository(dbConn)
 	passport := service.NewPassport()
-	suite.service = auth.NewService(conf, userRepo, passport, db.NewRedisConn(conf))
+	suite.service = auth.NewService(conf, userRepo, passport, redisConn, denylist, keys,
+		service.NewWriterNotifier(ioutil.Discard))
 
 	authController := auth.NewController(
 		conf,
 		suite.service,
 	)
-	authController.RegisterRoutes(suite.ginEngine)
+	authController.RegisterRoutes(api.NewRouter(&suite.ginEngine.RouterGroup))
 
 	testUser := user.User{
 		UserName: "username",
 	}
 	testUser.PasswordHash, _ = passport.HashPassword("password")
 
-	suite.testUser, err = userRepo.CreateUser(testUser)
+	suite.testUser, err = userRepo.CreateUser(context.Background(), testUser)
 	suite.NoError(err)
 }
 
@@ -130,16 +145,31 @@ func (suite *controllerIntegration) TestGetTokenByRefreshToken() {
 		{
 			description: "ShouldGenerateToken",
 			reqBodyFn: func() io.Reader {
-				refreshToken, err := suite.service.IssueRefreshToken(10)
+				refreshToken, err := suite.service.IssueRefreshToken(context.Background(), suite.testUser.ID)
 				suite.NoError(err)
 
 				return testutil.ReqBodyFromInterface(suite.T(), auth.AccessTokenByRefreshRequest{
-					Token: refreshToken,
+					Token: refreshToken.Token,
 				})
 			},
 			expectedStatus: http.StatusOK,
 			expectedJSON:   testutil.JSONStringFromInterface(suite.T(), auth.TokenResponse{}),
 		},
+		{
+			description: "ShouldReturnUnauthorizedErr_WhenReusedToken",
+			reqBodyFn: func() io.Reader {
+				refreshToken, err := suite.service.IssueRefreshToken(context.Background(), suite.testUser.ID)
+				suite.NoError(err)
+
+				_, err = suite.service.RotateRefreshToken(context.Background(), refreshToken.Token)
+				suite.NoError(err)
+
+				return testutil.ReqBodyFromInterface(suite.T(), auth.AccessTokenByRefreshRequest{
+					Token: refreshToken.Token,
+				})
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
 		{
 			description: "ShouldReturnUnauthorizedErr_WhenInvalidToken",
 			reqBodyFn: func() io.Reader {
@@ -165,3 +195,81 @@ func (suite *controllerIntegration) TestGetTokenByRefreshToken() {
 		})
 	}
 }
+
+func (suite *controllerIntegration) TestLogout() {
+	testCases := []struct {
+		description         string
+		path                string
+		expectedStatus      int
+		expectedAfterStatus int
+	}{
+		{
+			description:         "ShouldLogoutCurrentSession",
+			path:                auth.APIPath + "logout",
+			expectedStatus:      http.StatusNoContent,
+			expectedAfterStatus: http.StatusUnauthorized,
+		},
+		{
+			description:         "ShouldLogoutAllSessions",
+			path:                auth.APIPath + "logout/all",
+			expectedStatus:      http.StatusNoContent,
+			expectedAfterStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			tokenRes := suite.login()
+			otherTokenRes := suite.login()
+
+			header := http.Header{}
+			header.Set("Authorization", "Bearer "+tokenRes.AccessToken)
+
+			actualRes := testutil.ActualResponseWithHeader(
+				suite.T(), suite.ginEngine, "POST", tc.path, nil, header,
+			)
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+
+			actualRes = testutil.ActualResponseWithHeader(
+				suite.T(), suite.ginEngine, "POST", tc.path, nil, header,
+			)
+			suite.Equal(tc.expectedAfterStatus, actualRes.StatusCode)
+
+			actualRes = testutil.ActualResponse(
+				suite.T(),
+				suite.ginEngine,
+				"POST",
+				auth.APIPath+"refresh",
+				testutil.ReqBodyFromInterface(suite.T(), auth.AccessTokenByRefreshRequest{
+					Token: otherTokenRes.RefreshToken,
+				}),
+			)
+
+			if tc.path == auth.APIPath+"logout/all" {
+				suite.Equal(http.StatusUnauthorized, actualRes.StatusCode)
+			} else {
+				suite.Equal(http.StatusOK, actualRes.StatusCode)
+			}
+		})
+	}
+}
+
+func (suite *controllerIntegration) login() auth.TokenResponse {
+	actualRes := testutil.ActualResponse(
+		suite.T(),
+		suite.ginEngine,
+		"POST",
+		auth.APIPath+"token",
+		testutil.ReqBodyFromInterface(suite.T(), auth.CreateAccessTokenRequest{
+			UserName: suite.testUser.UserName,
+			Password: "password",
+		}),
+	)
+	require.Equal(suite.T(), http.StatusOK, actualRes.StatusCode)
+
+	var tokenRes auth.TokenResponse
+	err := json.NewDecoder(actualRes.Body).Decode(&tokenRes)
+	require.NoError(suite.T(), err)
+
+	return tokenRes
+}
@@ -11,6 +11,54 @@ type AccessTokenByRefreshRequest struct {
 	Token string `json:"token" example:"<refresh token>" binding:"required"`
 }
 
+// ForgotPasswordRequest is request model for issuing password reset token
+type ForgotPasswordRequest struct {
+	UserName string `json:"username" example:"<username>" binding:"required"`
+}
+
+// ResetPasswordRequest is request model for resetting password by reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" example:"<reset token>" binding:"required"`
+	Password string `json:"password" example:"<new password>" binding:"required,min=8,max=50"`
+}
+
+// VerifyMFARequest is request model for exchanging mfa challenge token
+// with TOTP code or recovery code
+type VerifyMFARequest struct {
+	MFAToken     string `json:"mfa_token" example:"<mfa token>" binding:"required"`
+	Code         string `json:"code" example:"123456"`
+	RecoveryCode string `json:"recovery_code" example:"<recovery code>"`
+}
+
+// ConfirmTOTPRequest is request model for enabling TOTP by code
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" example:"123456" binding:"required"`
+}
+
+// DisableTOTPRequest is request model for disabling TOTP by code or recovery code
+type DisableTOTPRequest struct {
+	Code         string `json:"code" example:"123456"`
+	RecoveryCode string `json:"recovery_code" example:"<recovery code>"`
+}
+
+// MFAChallengeResponse is response model of first step of two step login
+type MFAChallengeResponse struct {
+	Type      string `json:"type"`
+	MFAToken  string `json:"mfa_token"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+// TOTPEnrollmentResponse is response model of pending TOTP enrollment
+type TOTPEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// RecoveryCodesResponse is response model of recovery codes
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 // TokenResponse is token model
 type TokenResponse struct {
 	Type         string `json:"type"`
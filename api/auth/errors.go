@@ -2,6 +2,9 @@ package auth
 
 import (
 	"errors"
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
 )
 
 var (
@@ -10,4 +13,40 @@ var (
 
 	// ErrInvalidRefreshToken is occurred when invalid refresh token
 	ErrInvalidRefreshToken = errors.New("Invalid refresh token")
+
+	// ErrRefreshTokenReused is occurred when already rotated refresh token is presented
+	ErrRefreshTokenReused = errors.New("Refresh token was already used")
+
+	// ErrAccountLocked is occurred when login is locked by too many failed attempts
+	ErrAccountLocked = errors.New("Account is temporarily locked")
+
+	// ErrInvalidResetToken is occurred when password reset token is invalid, expired or used
+	ErrInvalidResetToken = errors.New("Invalid password reset token")
+
+	// ErrInvalidMFACode is occurred when TOTP or recovery code is invalid or already used
+	ErrInvalidMFACode = errors.New("Invalid two factor authentication code")
+
+	// ErrInvalidMFAToken is occurred when mfa challenge token is invalid, expired or used
+	ErrInvalidMFAToken = errors.New("Invalid mfa challenge token")
+
+	// ErrMFAAlreadyEnabled is occurred when enrolling user whose two factor authentication is enabled
+	ErrMFAAlreadyEnabled = errors.New("Two factor authentication is already enabled")
+
+	// ErrMFANotEnrolled is occurred when confirming or disabling without enrollment
+	ErrMFANotEnrolled = errors.New("Two factor authentication is not enrolled")
 )
+
+// ErrorCodes map errors of auth api into status and code of response.
+// Unknown user is responded same as wrong password not to reveal user names.
+var ErrorCodes = common.ErrorCodes{
+	common.ErrEntityNotFound: {Status: http.StatusUnauthorized, Code: "auth.invalid_credentials"},
+	ErrInvalidPassword:       {Status: http.StatusUnauthorized, Code: "auth.invalid_credentials"},
+	ErrInvalidRefreshToken:   {Status: http.StatusUnauthorized, Code: "auth.invalid_refresh_token"},
+	ErrRefreshTokenReused:    {Status: http.StatusUnauthorized, Code: "auth.refresh_token_reused"},
+	ErrAccountLocked:         {Status: http.StatusLocked, Code: "auth.account_locked"},
+	ErrInvalidResetToken:     {Status: http.StatusBadRequest, Code: "auth.invalid_reset_token"},
+	ErrInvalidMFACode:        {Status: http.StatusUnauthorized, Code: "auth.invalid_mfa_code"},
+	ErrInvalidMFAToken:       {Status: http.StatusUnauthorized, Code: "auth.invalid_mfa_token"},
+	ErrMFAAlreadyEnabled:     {Status: http.StatusConflict, Code: "auth.mfa_already_enabled"},
+	ErrMFANotEnrolled:        {Status: http.StatusBadRequest, Code: "auth.mfa_not_enrolled"},
+}
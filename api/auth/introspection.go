@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
+)
+
+const (
+	// TokenTypeBearer is introspected type of access tokens.
+	TokenTypeBearer = "Bearer"
+
+	// TokenTypeRefresh is introspected type of refresh tokens.
+	TokenTypeRefresh = "Refresh"
+)
+
+// TokenIntrospection is state of token described in RFC 7662.
+// Fields other than Active are zero when token is not active.
+type TokenIntrospection struct {
+	Active    bool
+	TokenType string
+	TokenID   string
+	Subject   string
+	SessionID string
+	ClientID  string
+	Scopes    []string
+	ExpiresAt int64
+	IssuedAt  int64
+}
+
+type introspectionClaims struct {
+	jwt.StandardClaims
+
+	SessionID string `json:"sid,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// IntrospectToken return whether access or refresh token is active.
+// Refresh token is active only while it is the latest one of its session family,
+// and access token while neither it nor its session was revoked.
+func (authService *authService) IntrospectToken(ctx context.Context, token string) (TokenIntrospection, error) {
+	claims := introspectionClaims{}
+
+	_, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		authService.keys.Keyfunc,
+	)
+
+	if err != nil || claims.Id == "" {
+		return TokenIntrospection{}, nil
+	}
+
+	var tokenType string
+
+	switch claims.Type {
+	case service.AccessTokenType:
+		tokenType = TokenTypeBearer
+
+		revoked, err := authService.denylist.IsRevoked(ctx, claims.Id, claims.SessionID)
+		if err != nil {
+			return TokenIntrospection{}, err
+		} else if revoked {
+			return TokenIntrospection{}, nil
+		}
+	case refreshTokenType:
+		tokenType = TokenTypeRefresh
+
+		latestTokenID, err := authService.redis.Get(ctx, RefreshTokenFamilyRedisStorageKey(claims.SessionID))
+		if err == db.ErrNil || (err == nil && latestTokenID != claims.Id) {
+			return TokenIntrospection{}, nil
+		} else if err != nil {
+			return TokenIntrospection{}, err
+		}
+	default:
+		// tokens of other types such as id tokens are not introspected.
+		return TokenIntrospection{}, nil
+	}
+
+	return TokenIntrospection{
+		Active:    true,
+		TokenType: tokenType,
+		TokenID:   claims.Id,
+		Subject:   claims.Subject,
+		SessionID: claims.SessionID,
+		ClientID:  claims.ClientID,
+		Scopes:    strings.Fields(claims.Scope),
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+	}, nil
+}
+
+// RevokeToken revoke access token, or session of refresh token with its access tokens.
+// Token that is not active is ignored as described in RFC 7009.
+func (authService *authService) RevokeToken(ctx context.Context, token string) error {
+	introspection, err := authService.IntrospectToken(ctx, token)
+	if err != nil || !introspection.Active {
+		return err
+	}
+
+	if introspection.TokenType == TokenTypeRefresh {
+		userID, err := strconv.ParseInt(introspection.Subject, 10, 64)
+		if err != nil {
+			return nil
+		}
+
+		return authService.RevokeSession(ctx, userID, introspection.SessionID)
+	}
+
+	return authService.RevokeAccessToken(ctx, introspection.TokenID, time.Unix(introspection.ExpiresAt, 0))
+}
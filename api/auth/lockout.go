@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+)
+
+const (
+	prefixLoginFailures = "login_failures"
+	prefixLoginLock     = "login_lock"
+
+	lockoutSubjectUser = "user"
+	lockoutSubjectIP   = "ip"
+)
+
+// lockout count failed logins per username and ip
+// and lock them out with exponential backoff.
+type lockout struct {
+	redis db.RedisConn
+	conf  config.LockoutConfig
+}
+
+type lockoutSubject struct {
+	kind      string
+	id        string
+	threshold int64
+}
+
+func (l lockout) subjects(username, clientIP string) []lockoutSubject {
+	return []lockoutSubject{
+		{kind: lockoutSubjectUser, id: username, threshold: l.conf.UserThreshold},
+		{kind: lockoutSubjectIP, id: clientIP, threshold: l.conf.IPThreshold},
+	}
+}
+
+// check return ErrAccountLocked when username or ip is locked.
+func (l lockout) check(ctx context.Context, username, clientIP string) error {
+	var keys []string
+	for _, subject := range l.subjects(username, clientIP) {
+		if subject.threshold > 0 && subject.id != "" {
+			keys = append(keys, LoginLockRedisStorageKey(subject.kind, subject.id))
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	count, err := l.redis.Exists(ctx, keys...)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return ErrAccountLocked
+	}
+
+	return nil
+}
+
+// recordFailure count failed login and lock username or ip that reached threshold.
+func (l lockout) recordFailure(ctx context.Context, username, clientIP string) error {
+	for _, subject := range l.subjects(username, clientIP) {
+		if subject.threshold <= 0 || subject.id == "" {
+			continue
+		}
+
+		failuresKey := LoginFailuresRedisStorageKey(subject.kind, subject.id)
+
+		failures, err := l.redis.Incr(ctx, failuresKey)
+		if err != nil {
+			return err
+		}
+
+		if err := l.redis.Expire(ctx, failuresKey, time.Duration(l.conf.WindowSec)*time.Second); err != nil {
+			return err
+		}
+
+		// zero lock would never expire, so failures are only counted.
+		if failures < subject.threshold || l.conf.LockSec <= 0 {
+			continue
+		}
+
+		lockKey := LoginLockRedisStorageKey(subject.kind, subject.id)
+		if err := l.redis.Set(ctx, lockKey, "1", l.lockDuration(failures-subject.threshold)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockDuration return lock that doubles on each failure over threshold.
+func (l lockout) lockDuration(overThreshold int64) time.Duration {
+	maxLock := time.Duration(l.conf.MaxLockSec) * time.Second
+	lock := time.Duration(l.conf.LockSec) * time.Second
+
+	for i := int64(0); i < overThreshold; i++ {
+		if (maxLock > 0 && lock >= maxLock) || lock > math.MaxInt64/2 {
+			break
+		}
+
+		lock *= 2
+	}
+
+	if maxLock > 0 && lock > maxLock {
+		return maxLock
+	}
+
+	return lock
+}
+
+// reset forget failed logins of username after successful login.
+// Failures of ip are kept, so one valid account does not hide guessing of others.
+func (l lockout) reset(ctx context.Context, username string) error {
+	return l.redis.Del(ctx, LoginFailuresRedisStorageKey(lockoutSubjectUser, username))
+}
+
+// unlock release lock and failed logins of username and ip.
+// Empty ip is skipped, so only username is unlocked.
+func (l lockout) unlock(ctx context.Context, username, clientIP string) error {
+	var keys []string
+	for _, subject := range l.subjects(username, clientIP) {
+		if subject.id != "" {
+			keys = append(keys,
+				LoginFailuresRedisStorageKey(subject.kind, subject.id),
+				LoginLockRedisStorageKey(subject.kind, subject.id),
+			)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return l.redis.Del(ctx, keys...)
+}
+
+// LoginFailuresRedisStorageKey return key of failed login count of username or ip.
+func LoginFailuresRedisStorageKey(kind, id string) string {
+	return fmt.Sprintf("%s_%s_%s", prefixLoginFailures, kind, id)
+}
+
+// LoginLockRedisStorageKey return key of lock of username or ip.
+func LoginLockRedisStorageKey(kind, id string) string {
+	return fmt.Sprintf("%s_%s_%s", prefixLoginLock, kind, id)
+}
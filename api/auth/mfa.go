@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
+)
+
+const (
+	prefixMFAChallenge = "mfa_challenge"
+	prefixTOTPUsedStep = "totp_used_step"
+
+	mfaChallengeTokenBytes = 32
+
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPEnrollment is secret of pending TOTP enrollment.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// EnrollTOTP start TOTP enrollment of user with new secret.
+// Two factor authentication is not enabled until ConfirmTOTP.
+func (authService *authService) EnrollTOTP(ctx context.Context, userID int64) (TOTPEnrollment, error) {
+	enrollUser, err := authService.userRepo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	if enrollUser.TOTPEnabled {
+		return TOTPEnrollment{}, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := service.NewTOTPSecret()
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	if _, err := authService.userRepo.UpdateTOTPByUserID(ctx, userID, secret, false, nil); err != nil {
+		return TOTPEnrollment{}, err
+	}
+
+	return TOTPEnrollment{
+		Secret: secret,
+		URI:    service.TOTPURI(authService.mfaIssuer, enrollUser.UserName, secret),
+	}, nil
+}
+
+// ConfirmTOTP enable two factor authentication of user by code of pending secret
+// and return recovery codes that are stored hashed, so they are shown only once.
+func (authService *authService) ConfirmTOTP(ctx context.Context, userID int64, code string) ([]string, error) {
+	confirmUser, err := authService.userRepo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmUser.TOTPEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	} else if confirmUser.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+
+	if err := authService.verifyTOTP(ctx, confirmUser, code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authService.userRepo.UpdateTOTPByUserID(ctx,
+		userID,
+		confirmUser.TOTPSecret,
+		true,
+		recoveryCodeHashes,
+	); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// DisableTOTP disable two factor authentication of user by TOTP or recovery code.
+func (authService *authService) DisableTOTP(ctx context.Context, userID int64, code, recoveryCode string) error {
+	disableUser, err := authService.userRepo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !disableUser.TOTPEnabled {
+		return ErrMFANotEnrolled
+	}
+
+	if err := authService.verifySecondFactor(ctx, disableUser, code, recoveryCode); err != nil {
+		return err
+	}
+
+	_, err = authService.userRepo.UpdateTOTPByUserID(ctx, userID, "", false, nil)
+	return err
+}
+
+// IssueMFAChallenge issue short lived challenge token of user
+// whose password was verified, to be exchanged by VerifyMFAChallenge.
+func (authService *authService) IssueMFAChallenge(ctx context.Context, userID int64) (string, error) {
+	token := make([]byte, mfaChallengeTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	challengeToken := hex.EncodeToString(token)
+
+	if err := authService.redis.Set(ctx,
+		MFAChallengeRedisStorageKey(challengeToken),
+		strconv.FormatInt(userID, 10),
+		authService.mfaChallengeExpiresInSec*time.Second,
+	); err != nil {
+		return "", err
+	}
+
+	return challengeToken, nil
+}
+
+// VerifyMFAChallenge verify TOTP or recovery code of challenge and consume it.
+// Failed codes are counted by lockout same as failed passwords.
+func (authService *authService) VerifyMFAChallenge(ctx context.Context, challengeToken, code, recoveryCode, clientIP string) (user.User, error) {
+	challengeKey := MFAChallengeRedisStorageKey(challengeToken)
+
+	userIDString, err := authService.redis.Get(ctx, challengeKey)
+	if err == db.ErrNil {
+		return user.EmptyUser, ErrInvalidMFAToken
+	} else if err != nil {
+		return user.EmptyUser, err
+	}
+
+	userID, err := strconv.ParseInt(userIDString, 10, 64)
+	if err != nil {
+		return user.EmptyUser, ErrInvalidMFAToken
+	}
+
+	loginUser, err := authService.userRepo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return user.EmptyUser, err
+	}
+
+	if err := authService.lockout.check(ctx, loginUser.UserName, clientIP); err != nil {
+		return user.EmptyUser, err
+	}
+
+	err = authService.verifySecondFactor(ctx, loginUser, code, recoveryCode)
+	if err == ErrInvalidMFACode {
+		if err := authService.lockout.recordFailure(ctx, loginUser.UserName, clientIP); err != nil {
+			return user.EmptyUser, err
+		}
+
+		return user.EmptyUser, err
+	} else if err != nil {
+		return user.EmptyUser, err
+	}
+
+	if err := authService.redis.Del(ctx, challengeKey); err != nil {
+		return user.EmptyUser, err
+	}
+
+	if err := authService.lockout.reset(ctx, loginUser.UserName); err != nil {
+		return user.EmptyUser, err
+	}
+
+	return loginUser, nil
+}
+
+func (authService *authService) verifySecondFactor(ctx context.Context, secondFactorUser user.User, code, recoveryCode string) error {
+	if recoveryCode == "" {
+		return authService.verifyTOTP(ctx, secondFactorUser, code)
+	}
+
+	err := authService.userRepo.RemoveRecoveryCodeByUserID(ctx,
+		secondFactorUser.ID,
+		hashRecoveryCode(recoveryCode),
+	)
+
+	if err == common.ErrEntityNotFound {
+		return ErrInvalidMFACode
+	}
+
+	return err
+}
+
+// verifyTOTP verify code by secret of user.
+// Each step is accepted once, so observed code can not be replayed.
+func (authService *authService) verifyTOTP(ctx context.Context, totpUser user.User, code string) error {
+	step, ok := service.ValidateTOTP(totpUser.TOTPSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	usedKey := TOTPUsedStepRedisStorageKey(totpUser.ID, step)
+
+	used, err := authService.redis.Incr(ctx, usedKey)
+	if err != nil {
+		return err
+	}
+
+	// step is accepted until skew periods after it passed.
+	if err := authService.redis.Expire(ctx, usedKey, 3*service.TOTPPeriod); err != nil {
+		return err
+	}
+
+	if used > 1 {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range codes {
+		code := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(code); err != nil {
+			return nil, nil, err
+		}
+
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(code))
+
+		codes[i] = encoded[:8] + "-" + encoded[8:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode return hash of recovery code ignoring case and separators.
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// MFAChallengeRedisStorageKey return key of user id that challenge was issued to.
+func MFAChallengeRedisStorageKey(challengeToken string) string {
+	return fmt.Sprintf("%s_%s", prefixMFAChallenge, challengeToken)
+}
+
+// TOTPUsedStepRedisStorageKey return key of step that TOTP code of user was accepted at.
+func TOTPUsedStepRedisStorageKey(userID int64, step int64) string {
+	return fmt.Sprintf("%s_%d_%d", prefixTOTPUsedStep, userID, step)
+}
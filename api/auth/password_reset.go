@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
+)
+
+const (
+	prefixPasswordResetToken = "password_reset_token"
+
+	passwordResetTokenBytes = 32
+)
+
+// RequestPasswordReset issue single use reset token of username
+// and deliver it by notifier. Unknown username is ignored
+// so that response does not reveal user names.
+func (authService *authService) RequestPasswordReset(ctx context.Context, username string) error {
+	resetUser, err := authService.userRepo.GetUserByUserName(ctx, username)
+	if err == common.ErrEntityNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	token, err := newPasswordResetToken()
+	if err != nil {
+		return err
+	}
+
+	// only hash of token is stored, so leaked storage does not leak tokens.
+	if err := authService.redis.Set(ctx,
+		PasswordResetTokenRedisStorageKey(token),
+		strconv.FormatInt(resetUser.ID, 10),
+		authService.passwordResetExpiresInSec*time.Second,
+	); err != nil {
+		return err
+	}
+
+	return authService.notifier.Notify(ctx, service.Notification{
+		UserID:   resetUser.ID,
+		UserName: resetUser.UserName,
+		Subject:  "Password reset",
+		Body:     token,
+	})
+}
+
+// ResetPassword change password of user that reset token was issued to
+// and revoke every session of the user. The token is consumed even if it fails later.
+func (authService *authService) ResetPassword(ctx context.Context, token, password string) error {
+	tokenKey := PasswordResetTokenRedisStorageKey(token)
+
+	// GetSet consumes token atomically, so concurrent resets can not reuse it.
+	userIDString, err := authService.redis.GetSet(ctx, tokenKey, "")
+	if err == db.ErrNil {
+		authService.redis.Del(ctx, tokenKey)
+		return ErrInvalidResetToken
+	} else if err != nil {
+		return err
+	}
+
+	if err := authService.redis.Del(ctx, tokenKey); err != nil {
+		return err
+	}
+
+	userID, err := strconv.ParseInt(userIDString, 10, 64)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	passwordHash, err := authService.passport.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if _, err := authService.userRepo.UpdateUserByUserID(ctx, userID, user.User{
+		PasswordHash: passwordHash,
+	}); err != nil {
+		return err
+	}
+
+	return authService.RevokeAllSessions(ctx, userID)
+}
+
+func newPasswordResetToken() (string, error) {
+	token := make([]byte, passwordResetTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// PasswordResetTokenRedisStorageKey return key of user id that reset token was issued to.
+func PasswordResetTokenRedisStorageKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("%s_%s", prefixPasswordResetToken, hex.EncodeToString(hash[:]))
+}
@@ -1,26 +1,86 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/api/user"
 	"github.com/gghcode/go-gin-starterkit/config"
 	"github.com/gghcode/go-gin-starterkit/db"
 	"github.com/gghcode/go-gin-starterkit/service"
+	uuid "github.com/satori/go.uuid"
 )
 
-const prefixRefreshToken = "refresh_token"
+const (
+	prefixRefreshTokenFamily   = "refresh_token_family"
+	prefixRefreshTokenSessions = "refresh_token_sessions"
+
+	// refreshTokenType is typ claim that tells refresh tokens from access tokens.
+	refreshTokenType = "refresh"
+)
 
 // Service is auth authService.
 type Service interface {
-	VerifyAuthentication(username, password string) (user.User, error)
-	GenerateAccessToken(userID int64) (string, error)
-	IssueRefreshToken(userID int64) (string, error)
-	VerifyRefreshToken(userID int64, refreshToken string) bool
+	VerifyAuthentication(ctx context.Context, username, password, clientIP string) (user.User, error)
+	UnlockAccount(ctx context.Context, username, clientIP string) error
+	RequestPasswordReset(ctx context.Context, username string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	EnrollTOTP(ctx context.Context, userID int64) (TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID int64, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID int64, code, recoveryCode string) error
+	IssueMFAChallenge(ctx context.Context, userID int64) (string, error)
+	VerifyMFAChallenge(ctx context.Context, challengeToken, code, recoveryCode, clientIP string) (user.User, error)
+	GenerateAccessToken(ctx context.Context, userID int64, sessionID string) (string, error)
+	GenerateClientAccessToken(ctx context.Context, userID int64, sessionID string, grant ClientGrant) (string, error)
+	IssueRefreshToken(ctx context.Context, userID int64) (RefreshToken, error)
+	IssueClientRefreshToken(ctx context.Context, userID int64, grant ClientGrant) (RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, refreshToken string) (RefreshToken, error)
+	RotateClientRefreshToken(ctx context.Context, clientID, refreshToken string) (RefreshToken, error)
+	RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	RevokeSession(ctx context.Context, userID int64, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID int64) error
 	ExtractTokenClaims(token string) (jwt.MapClaims, error)
+	IntrospectToken(ctx context.Context, token string) (TokenIntrospection, error)
+	RevokeToken(ctx context.Context, token string) error
+}
+
+// RefreshToken is issued refresh token of session.
+// Grant is zero when session is not of third party client.
+type RefreshToken struct {
+	UserID    int64
+	SessionID string
+	Token     string
+	Grant     ClientGrant
+}
+
+// ClientGrant is client and scopes that tokens issued to third party client are restricted to.
+type ClientGrant struct {
+	ClientID string
+	Scopes   []string
+}
+
+type accessTokenClaims struct {
+	jwt.StandardClaims
+
+	SessionID string `json:"sid,omitempty"`
+	Role      string `json:"role,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	Type      string `json:"typ"`
+}
+
+type refreshTokenClaims struct {
+	jwt.StandardClaims
+
+	SessionID string `json:"sid"`
+	ClientID  string `json:"client_id,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	Type      string `json:"typ"`
 }
 
 // NewService return new auth authService instance.
@@ -28,90 +88,272 @@ func NewService(
 	conf config.Configuration,
 	userRepo user.Repository,
 	passport service.Passport,
-	redisConn db.RedisConn) Service {
+	redisConn db.RedisConn,
+	denylist service.TokenDenylist,
+	keys service.KeyProvider,
+	notifier service.Notifier) Service {
 
 	return &authService{
-		secretKeyBytes:      []byte(conf.Jwt.SecretKey),
-		accessExpiresInSec:  time.Duration(conf.Jwt.AccessExpiresInSec),
-		refreshExpiresInSec: time.Duration(conf.Jwt.RefreshExpiresInSec),
-		userRepo:            userRepo,
-		passport:            passport,
-		redis:               redisConn,
+		keys:                      keys,
+		accessExpiresInSec:        time.Duration(conf.Jwt.AccessExpiresInSec),
+		refreshExpiresInSec:       time.Duration(conf.Jwt.RefreshExpiresInSec),
+		passwordResetExpiresInSec: time.Duration(conf.PasswordReset.ExpiresSec),
+		mfaIssuer:                 conf.MFA.Issuer,
+		mfaChallengeExpiresInSec:  time.Duration(conf.MFA.ChallengeExpiresSec),
+		userRepo:                  userRepo,
+		passport:                  passport,
+		redis:                     redisConn,
+		denylist:                  denylist,
+		notifier:                  notifier,
+		lockout: lockout{
+			redis: redisConn,
+			conf:  conf.Lockout,
+		},
 	}
 }
 
 type authService struct {
-	secretKeyBytes      []byte
-	accessExpiresInSec  time.Duration
-	refreshExpiresInSec time.Duration
+	keys                      service.KeyProvider
+	accessExpiresInSec        time.Duration
+	refreshExpiresInSec       time.Duration
+	passwordResetExpiresInSec time.Duration
+	mfaIssuer                 string
+	mfaChallengeExpiresInSec  time.Duration
 
 	userRepo user.Repository
 	passport service.Passport
 	redis    db.RedisConn
+	denylist service.TokenDenylist
+	notifier service.Notifier
+	lockout  lockout
 }
 
-func (authService *authService) VerifyAuthentication(username, password string) (user.User, error) {
-	loginUser, err := authService.userRepo.GetUserByUserName(username)
-	if err != nil {
+// VerifyAuthentication verify password of username.
+// Failed attempts are counted per username and client ip,
+// and ErrAccountLocked is returned while either of them is locked.
+func (authService *authService) VerifyAuthentication(ctx context.Context, username, password, clientIP string) (user.User, error) {
+	if err := authService.lockout.check(ctx, username, clientIP); err != nil {
+		return user.EmptyUser, err
+	}
+
+	loginUser, err := authService.userRepo.GetUserByUserName(ctx, username)
+	if err == nil && !authService.passport.IsValidPassword(password, loginUser.PasswordHash) {
+		err = ErrInvalidPassword
+	}
+
+	if err == common.ErrEntityNotFound || err == ErrInvalidPassword {
+		if err := authService.lockout.recordFailure(ctx, username, clientIP); err != nil {
+			return user.EmptyUser, err
+		}
+
+		return user.EmptyUser, err
+	} else if err != nil {
 		return user.EmptyUser, err
 	}
 
-	if !authService.passport.IsValidPassword(password, loginUser.PasswordHash) {
-		return user.EmptyUser, ErrInvalidPassword
+	if err := authService.lockout.reset(ctx, username); err != nil {
+		return user.EmptyUser, err
 	}
 
 	return loginUser, nil
 }
 
-func (authService *authService) GenerateAccessToken(userID int64) (string, error) {
-	claims := &jwt.StandardClaims{
-		ExpiresAt: time.Now().Add(authService.accessExpiresInSec * time.Second).Unix(),
-		IssuedAt:  time.Now().Unix(),
-		Subject:   strconv.FormatInt(userID, 10),
-	}
+// UnlockAccount release lock and failed attempts of username and client ip.
+func (authService *authService) UnlockAccount(ctx context.Context, username, clientIP string) error {
+	return authService.lockout.unlock(ctx, username, clientIP)
+}
+
+func (authService *authService) GenerateAccessToken(ctx context.Context, userID int64, sessionID string) (string, error) {
+	return authService.generateAccessToken(ctx, userID, sessionID, ClientGrant{})
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(authService.secretKeyBytes)
+// GenerateClientAccessToken generate access token of user that is restricted to grant of client.
+// Session id is empty when client acts without user session.
+func (authService *authService) GenerateClientAccessToken(ctx context.Context, userID int64, sessionID string, grant ClientGrant) (string, error) {
+	return authService.generateAccessToken(ctx, userID, sessionID, grant)
+}
+
+func (authService *authService) generateAccessToken(ctx context.Context, userID int64, sessionID string, grant ClientGrant) (string, error) {
+	tokenUser, err := authService.userRepo.GetUserByUserID(ctx, userID)
 	if err != nil {
 		return "", err
 	}
 
-	return tokenString, nil
+	claims := &accessTokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(authService.accessExpiresInSec * time.Second).Unix(),
+			IssuedAt:  time.Now().Unix(),
+			Id:        uuid.NewV4().String(),
+			Subject:   strconv.FormatInt(userID, 10),
+		},
+		SessionID: sessionID,
+		Role:      tokenUser.Role,
+		ClientID:  grant.ClientID,
+		Scope:     strings.Join(grant.Scopes, " "),
+		Type:      service.AccessTokenType,
+	}
+
+	return authService.keys.Sign(claims)
+}
+
+// IssueRefreshToken issue refresh token that starts new session family.
+func (authService *authService) IssueRefreshToken(ctx context.Context, userID int64) (RefreshToken, error) {
+	return authService.issueRefreshToken(ctx, userID, ClientGrant{})
+}
+
+// IssueClientRefreshToken issue refresh token that starts new session family of user
+// that is restricted to grant of client.
+func (authService *authService) IssueClientRefreshToken(ctx context.Context, userID int64, grant ClientGrant) (RefreshToken, error) {
+	return authService.issueRefreshToken(ctx, userID, grant)
+}
+
+func (authService *authService) issueRefreshToken(ctx context.Context, userID int64, grant ClientGrant) (RefreshToken, error) {
+	sessionID := uuid.NewV4().String()
+
+	tokenString, tokenID, err := authService.signRefreshToken(userID, sessionID, grant)
+	if err != nil {
+		return RefreshToken{}, err
+	}
+
+	expiration := authService.refreshExpiresInSec * time.Second
+
+	if err := authService.redis.Set(ctx,
+		RefreshTokenFamilyRedisStorageKey(sessionID),
+		tokenID,
+		expiration,
+	); err != nil {
+		return RefreshToken{}, err
+	}
+
+	sessionsKey := RefreshTokenSessionsRedisStorageKey(userID)
+	if err := authService.redis.SAdd(ctx, sessionsKey, sessionID); err != nil {
+		return RefreshToken{}, err
+	}
+
+	if err := authService.redis.Expire(ctx, sessionsKey, expiration); err != nil {
+		return RefreshToken{}, err
+	}
+
+	return RefreshToken{
+		UserID:    userID,
+		SessionID: sessionID,
+		Token:     tokenString,
+		Grant:     grant,
+	}, nil
+}
+
+// RotateRefreshToken exchange refresh token to new one of same family.
+// The whole family is revoked when already rotated token is presented.
+// Refresh tokens of third party clients are not accepted.
+func (authService *authService) RotateRefreshToken(ctx context.Context, refreshToken string) (RefreshToken, error) {
+	return authService.rotateRefreshToken(ctx, "", refreshToken)
 }
 
-func (authService *authService) IssueRefreshToken(userID int64) (string, error) {
-	claims := &jwt.StandardClaims{
-		ExpiresAt: time.Now().Add(authService.refreshExpiresInSec * time.Second).Unix(),
-		IssuedAt:  time.Now().Unix(),
-		Subject:   strconv.FormatInt(userID, 10),
+// RotateClientRefreshToken exchange refresh token issued to client to new one of same family.
+func (authService *authService) RotateClientRefreshToken(ctx context.Context, clientID, refreshToken string) (RefreshToken, error) {
+	return authService.rotateRefreshToken(ctx, clientID, refreshToken)
+}
+
+// rotateRefreshToken rotate refresh token only when it was issued to clientID,
+// so tokens can not be exchanged by other clients.
+func (authService *authService) rotateRefreshToken(ctx context.Context, clientID, refreshToken string) (RefreshToken, error) {
+	claims := refreshTokenClaims{}
+
+	_, err := jwt.ParseWithClaims(
+		refreshToken,
+		&claims,
+		authService.keys.Keyfunc,
+	)
+
+	// access tokens would fail family comparison and revoke session as reused token.
+	if err != nil || claims.Type != refreshTokenType ||
+		claims.SessionID == "" || claims.Id == "" || claims.ClientID != clientID {
+		return RefreshToken{}, ErrInvalidRefreshToken
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(authService.secretKeyBytes)
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
 	if err != nil {
-		return "", err
+		return RefreshToken{}, ErrInvalidRefreshToken
 	}
 
-	if err := authService.redis.Client().Set(
-		RefreshTokenRedisStorageKey(userID),
-		tokenString,
-		authService.refreshExpiresInSec*time.Second,
-	).Err(); err != nil {
-		return "", err
+	grant := ClientGrant{
+		ClientID: claims.ClientID,
+		Scopes:   strings.Fields(claims.Scope),
+	}
+
+	tokenString, tokenID, err := authService.signRefreshToken(userID, claims.SessionID, grant)
+	if err != nil {
+		return RefreshToken{}, err
+	}
+
+	familyKey := RefreshTokenFamilyRedisStorageKey(claims.SessionID)
+
+	prevTokenID, err := authService.redis.GetSet(ctx, familyKey, tokenID)
+	if err == db.ErrNil {
+		authService.redis.Del(ctx, familyKey)
+		return RefreshToken{}, ErrInvalidRefreshToken
+	} else if err != nil {
+		return RefreshToken{}, err
+	}
+
+	if prevTokenID != claims.Id {
+		if err := authService.RevokeSession(ctx, userID, claims.SessionID); err != nil {
+			return RefreshToken{}, err
+		}
+
+		return RefreshToken{}, ErrRefreshTokenReused
+	}
+
+	expiration := authService.refreshExpiresInSec * time.Second
+	if err := authService.redis.Expire(ctx, familyKey, expiration); err != nil {
+		return RefreshToken{}, err
 	}
 
-	return tokenString, nil
+	sessionsKey := RefreshTokenSessionsRedisStorageKey(userID)
+	if err := authService.redis.Expire(ctx, sessionsKey, expiration); err != nil {
+		return RefreshToken{}, err
+	}
+
+	return RefreshToken{
+		UserID:    userID,
+		SessionID: claims.SessionID,
+		Token:     tokenString,
+		Grant:     grant,
+	}, nil
+}
+
+func (authService *authService) RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return authService.denylist.Revoke(ctx, tokenID, time.Until(expiresAt))
 }
 
-func (authService *authService) VerifyRefreshToken(userID int64, refreshToken string) bool {
-	storageKey := RefreshTokenRedisStorageKey(userID)
+// RevokeSession revoke refresh token family and access tokens of session.
+func (authService *authService) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	if err := authService.redis.Del(ctx, RefreshTokenFamilyRedisStorageKey(sessionID)); err != nil {
+		return err
+	}
+
+	if err := authService.redis.SRem(ctx, RefreshTokenSessionsRedisStorageKey(userID), sessionID); err != nil {
+		return err
+	}
+
+	// session is denied as long as refresh tokens of it live, so none of them authenticates again.
+	return authService.denylist.Revoke(ctx, sessionID, authService.refreshExpiresInSec*time.Second)
+}
 
-	token, err := authService.redis.Client().Get(storageKey).Result()
+// RevokeAllSessions revoke every session of user.
+func (authService *authService) RevokeAllSessions(ctx context.Context, userID int64) error {
+	sessionIDs, err := authService.redis.SMembers(ctx, RefreshTokenSessionsRedisStorageKey(userID))
 	if err != nil {
-		return false
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if err := authService.RevokeSession(ctx, userID, sessionID); err != nil {
+			return err
+		}
 	}
 
-	return token == refreshToken
+	return nil
 }
 
 func (authService *authService) ExtractTokenClaims(token string) (jwt.MapClaims, error) {
@@ -120,9 +362,7 @@ func (authService *authService) ExtractTokenClaims(token string) (jwt.MapClaims,
 	_, err := jwt.ParseWithClaims(
 		token,
 		&claims,
-		func(token *jwt.Token) (interface{}, error) {
-			return authService.secretKeyBytes, nil
-		},
+		authService.keys.Keyfunc,
 	)
 
 	if err != nil {
@@ -137,7 +377,36 @@ func (authService *authService) ExtractTokenClaims(token string) (jwt.MapClaims,
 	return claims, nil
 }
 
-// RefreshTokenRedisStorageKey return key that stored on redis
-func RefreshTokenRedisStorageKey(userID int64) string {
-	return fmt.Sprintf("%s_%d", prefixRefreshToken, userID)
+func (authService *authService) signRefreshToken(userID int64, sessionID string, grant ClientGrant) (string, string, error) {
+	tokenID := uuid.NewV4().String()
+
+	claims := &refreshTokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(authService.refreshExpiresInSec * time.Second).Unix(),
+			IssuedAt:  time.Now().Unix(),
+			Id:        tokenID,
+			Subject:   strconv.FormatInt(userID, 10),
+		},
+		SessionID: sessionID,
+		ClientID:  grant.ClientID,
+		Scope:     strings.Join(grant.Scopes, " "),
+		Type:      refreshTokenType,
+	}
+
+	tokenString, err := authService.keys.Sign(claims)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tokenString, tokenID, nil
+}
+
+// RefreshTokenFamilyRedisStorageKey return key of latest token id in session family.
+func RefreshTokenFamilyRedisStorageKey(sessionID string) string {
+	return fmt.Sprintf("%s_%s", prefixRefreshTokenFamily, sessionID)
+}
+
+// RefreshTokenSessionsRedisStorageKey return key of session families of user.
+func RefreshTokenSessionsRedisStorageKey(userID int64) string {
+	return fmt.Sprintf("%s_%d", prefixRefreshTokenSessions, userID)
 }
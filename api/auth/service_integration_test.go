@@ -1,120 +1,174 @@
-package auth_test
-
-import (
-	"testing"
-	"time"
-
-	"github.com/gghcode/go-gin-starterkit/api/auth"
-	"github.com/gghcode/go-gin-starterkit/api/user"
-	"github.com/gghcode/go-gin-starterkit/config"
-	"github.com/gghcode/go-gin-starterkit/db"
-	"github.com/gghcode/go-gin-starterkit/service"
-	"github.com/stretchr/testify/require"
-	"github.com/stretchr/testify/suite"
-)
-
-const existUserID = 10
-
-type serviceIntegration struct {
-	suite.Suite
-
-	authService auth.Service
-}
-
-func (suite *serviceIntegration) SetupSuite() {
-	conf, err := config.NewBuilder().
-		BindEnvs("TEST").
-		Build()
-
-	dbConn, err := db.NewConn(conf)
-	require.NoError(suite.T(), err)
-
-	redisConn := db.NewRedisConn(conf)
-	redisConn.Client().Set(
-		auth.RefreshTokenRedisStorageKey(existUserID),
-		"exist_refreshtoken",
-		30*time.Second,
-	)
-
-	suite.authService = auth.NewService(
-		conf,
-		user.NewRepository(dbConn),
-		service.NewPassport(),
-		redisConn,
-	)
-}
-
-func TestAuthServiceIntegration(t *testing.T) {
-	if testing.Short() {
-		t.Skip("skipping integration test")
-	}
-
-	suite.Run(t, new(serviceIntegration))
-}
-
-func (suite *serviceIntegration) TestIssueRefreshToken() {
-	testCases := []struct {
-		description string
-		argsUserID  int64
-		expected    bool
-	}{
-		{
-			description: "ShouldIssueRefreshToken",
-			argsUserID:  1,
-			expected:    true,
-		},
-	}
-
-	for _, tc := range testCases {
-		suite.Run(tc.description, func() {
-			refreshToken, _ := suite.authService.IssueRefreshToken(tc.argsUserID)
-
-			actual := suite.authService.VerifyRefreshToken(
-				tc.argsUserID,
-				refreshToken,
-			)
-
-			suite.Equal(tc.expected, actual)
-		})
-	}
-}
-
-func (suite *serviceIntegration) TestVerifyRefreshToken() {
-	testCases := []struct {
-		description      string
-		argsUserID       int64
-		argsRefreshToken string
-		stub             func(authService auth.Service) (string, error)
-		expected         bool
-	}{
-		{
-			description:      "ShouldBeValid",
-			argsUserID:       existUserID,
-			argsRefreshToken: "exist_refreshtoken",
-			expected:         true,
-		},
-		{
-			description:      "ShouldBeInvalid_WhenInvalidRefreshToken",
-			argsUserID:       existUserID,
-			argsRefreshToken: "NOT_EXIST_REFRESH_TOKEN",
-			expected:         false,
-		},
-		{
-			description:      "ShouldBeInvalid_WhenNotExistsUserID",
-			argsUserID:       -1,
-			argsRefreshToken: "exist_refreshtoken",
-			expected:         false,
-		},
-	}
-
-	for _, tc := range testCases {
-		suite.Run(tc.description, func() {
-			actual := suite.authService.VerifyRefreshToken(
-				tc.argsUserID,
-				tc.argsRefreshToken,
-			)
-
-			suite.Equal(tc.expected, actual)
-		})
-	}
-}
+package auth_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/auth"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/internal/testutil"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const existUserID = 10
+
+type serviceIntegration struct {
+	suite.Suite
+
+	authService auth.Service
+}
+
+func (suite *serviceIntegration) SetupSuite() {
+	conf, err := config.NewBuilder().
+		BindEnvs("TEST").
+		Build()
+
+	dbConn, err := db.NewConn(conf)
+	require.NoError(suite.T(), err)
+
+	testutil.MigrateUp(suite.T(), dbConn)
+
+	redisConn := db.NewRedisConn(conf)
+
+	keys, err := service.NewKeyProvider(conf)
+	require.NoError(suite.T(), err)
+
+	suite.authService = auth.NewService(
+		conf,
+		user.NewRepository(dbConn),
+		service.NewPassport(),
+		redisConn,
+		service.NewTokenDenylist(redisConn),
+		keys,
+		service.NewWriterNotifier(ioutil.Discard),
+	)
+}
+
+func TestAuthServiceIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	suite.Run(t, new(serviceIntegration))
+}
+
+func (suite *serviceIntegration) TestIssueRefreshToken() {
+	testCases := []struct {
+		description string
+		argsUserID  int64
+		expectedErr error
+	}{
+		{
+			description: "ShouldIssueRefreshToken",
+			argsUserID:  existUserID,
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			refreshToken, err := suite.authService.IssueRefreshToken(context.Background(), tc.argsUserID)
+			suite.NoError(err)
+
+			rotatedToken, actualErr := suite.authService.RotateRefreshToken(context.Background(), refreshToken.Token)
+
+			suite.Equal(tc.argsUserID, rotatedToken.UserID)
+			suite.Equal(refreshToken.SessionID, rotatedToken.SessionID)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *serviceIntegration) TestRotateRefreshToken() {
+	testCases := []struct {
+		description     string
+		refreshTokenFn  func() string
+		expectedErr     error
+		expectedRotated bool
+	}{
+		{
+			description: "ShouldRotateRefreshToken",
+			refreshTokenFn: func() string {
+				refreshToken, _ := suite.authService.IssueRefreshToken(context.Background(), existUserID)
+				return refreshToken.Token
+			},
+			expectedErr:     nil,
+			expectedRotated: true,
+		},
+		{
+			description: "ShouldReturnReusedErr_WhenAlreadyRotatedToken",
+			refreshTokenFn: func() string {
+				refreshToken, _ := suite.authService.IssueRefreshToken(context.Background(), existUserID)
+				suite.authService.RotateRefreshToken(context.Background(), refreshToken.Token)
+
+				return refreshToken.Token
+			},
+			expectedErr: auth.ErrRefreshTokenReused,
+		},
+		{
+			description: "ShouldReturnInvalidErr_WhenFamilyRevoked",
+			refreshTokenFn: func() string {
+				refreshToken, _ := suite.authService.IssueRefreshToken(context.Background(), existUserID)
+				rotatedToken, _ := suite.authService.RotateRefreshToken(context.Background(), refreshToken.Token)
+				suite.authService.RotateRefreshToken(context.Background(), refreshToken.Token)
+
+				return rotatedToken.Token
+			},
+			expectedErr: auth.ErrInvalidRefreshToken,
+		},
+		{
+			description: "ShouldRotateRefreshToken_WhenOtherSessionRotated",
+			refreshTokenFn: func() string {
+				refreshToken, _ := suite.authService.IssueRefreshToken(context.Background(), existUserID)
+				otherToken, _ := suite.authService.IssueRefreshToken(context.Background(), existUserID)
+				suite.authService.RotateRefreshToken(context.Background(), otherToken.Token)
+
+				return refreshToken.Token
+			},
+			expectedErr:     nil,
+			expectedRotated: true,
+		},
+		{
+			description: "ShouldReturnInvalidErr_WhenSessionRevoked",
+			refreshTokenFn: func() string {
+				refreshToken, _ := suite.authService.IssueRefreshToken(context.Background(), existUserID)
+				suite.authService.RevokeSession(context.Background(), existUserID, refreshToken.SessionID)
+
+				return refreshToken.Token
+			},
+			expectedErr: auth.ErrInvalidRefreshToken,
+		},
+		{
+			description: "ShouldReturnInvalidErr_WhenAllSessionsRevoked",
+			refreshTokenFn: func() string {
+				refreshToken, _ := suite.authService.IssueRefreshToken(context.Background(), existUserID)
+				suite.authService.RevokeAllSessions(context.Background(), existUserID)
+
+				return refreshToken.Token
+			},
+			expectedErr: auth.ErrInvalidRefreshToken,
+		},
+		{
+			description:    "ShouldReturnInvalidErr_WhenInvalidToken",
+			refreshTokenFn: func() string { return "INVALID_REFRESH_TOKEN" },
+			expectedErr:    auth.ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			refreshToken := tc.refreshTokenFn()
+
+			rotatedToken, actualErr := suite.authService.RotateRefreshToken(context.Background(), refreshToken)
+
+			suite.Equal(tc.expectedErr, actualErr)
+			suite.Equal(tc.expectedRotated,
+				rotatedToken.Token != "" && rotatedToken.Token != refreshToken)
+		})
+	}
+}
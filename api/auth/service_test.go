@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"strconv"
 	"testing"
 	"time"
@@ -9,7 +10,9 @@ import (
 	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/api/user"
 	"github.com/gghcode/go-gin-starterkit/config"
-	"github.com/go-redis/redis"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -18,31 +21,41 @@ type fakeUserRepo struct {
 	mock.Mock
 }
 
-func (r *fakeUserRepo) CreateUser(usr user.User) (user.User, error) {
+func (r *fakeUserRepo) CreateUser(ctx context.Context, usr user.User) (user.User, error) {
 	args := r.Called(usr)
 	return args.Get(0).(user.User), args.Error(1)
 }
 
-func (r *fakeUserRepo) GetUserByUserName(userName string) (user.User, error) {
+func (r *fakeUserRepo) GetUserByUserName(ctx context.Context, userName string) (user.User, error) {
 	args := r.Called(userName)
 	return args.Get(0).(user.User), args.Error(1)
 }
 
-func (r *fakeUserRepo) GetUserByUserID(userID int64) (user.User, error) {
+func (r *fakeUserRepo) GetUserByUserID(ctx context.Context, userID int64) (user.User, error) {
 	args := r.Called(userID)
 	return args.Get(0).(user.User), args.Error(1)
 }
 
-func (r *fakeUserRepo) UpdateUserByUserID(userID int64, usr user.User) (user.User, error) {
+func (r *fakeUserRepo) UpdateUserByUserID(ctx context.Context, userID int64, usr user.User) (user.User, error) {
 	args := r.Called(userID, usr)
 	return args.Get(0).(user.User), args.Error(1)
 }
 
-func (r *fakeUserRepo) RemoveUserByUserID(userID int64) (user.User, error) {
+func (r *fakeUserRepo) RemoveUserByUserID(ctx context.Context, userID int64) (user.User, error) {
 	args := r.Called(userID)
 	return args.Get(0).(user.User), args.Error(1)
 }
 
+func (r *fakeUserRepo) UpdateTOTPByUserID(ctx context.Context, userID int64, secret string, enabled bool, recoveryCodeHashes []string) (user.User, error) {
+	args := r.Called(userID, secret, enabled, recoveryCodeHashes)
+	return args.Get(0).(user.User), args.Error(1)
+}
+
+func (r *fakeUserRepo) RemoveRecoveryCodeByUserID(ctx context.Context, userID int64, codeHash string) error {
+	args := r.Called(userID, codeHash)
+	return args.Error(0)
+}
+
 type fakePassport struct {
 	mock.Mock
 }
@@ -57,10 +70,26 @@ func (p *fakePassport) IsValidPassword(password string, hash []byte) bool {
 	return args.Bool(0)
 }
 
-type fakeRedisConn struct {
+type fakeDenylist struct {
+	mock.Mock
+}
+
+func (d *fakeDenylist) Revoke(ctx context.Context, id string, ttl time.Duration) error {
+	args := d.Called(id, ttl)
+	return args.Error(0)
 }
 
-func (r *fakeRedisConn) Client() *redis.Client {
+func (d *fakeDenylist) IsRevoked(ctx context.Context, ids ...string) (bool, error) {
+	args := d.Called(ids)
+	return args.Bool(0), args.Error(1)
+}
+
+type fakeNotifier struct {
+	notifications []service.Notification
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, notification service.Notification) error {
+	n.notifications = append(n.notifications, notification)
 	return nil
 }
 
@@ -71,6 +100,8 @@ type serviceUnit struct {
 	authService   Service
 	userRepo      fakeUserRepo
 	passport      fakePassport
+	denylist      fakeDenylist
+	notifier      fakeNotifier
 }
 
 func (suite *serviceUnit) SetupTest() {
@@ -80,15 +111,34 @@ func (suite *serviceUnit) SetupTest() {
 			AccessExpiresInSec:  300,
 			RefreshExpiresInSec: 3000,
 		},
+		PasswordReset: config.PasswordResetConfig{
+			ExpiresSec: 60,
+		},
+		Lockout: config.LockoutConfig{
+			UserThreshold: 2,
+			IPThreshold:   3,
+			WindowSec:     60,
+			LockSec:       60,
+			MaxLockSec:    600,
+		},
 	}
 
 	suite.userRepo = fakeUserRepo{}
 	suite.passport = fakePassport{}
+	suite.denylist = fakeDenylist{}
+	suite.notifier = fakeNotifier{}
+
+	keys, err := service.NewKeyProvider(suite.configuration)
+	suite.Require().NoError(err)
+
 	suite.authService = NewService(
 		suite.configuration,
 		&suite.userRepo,
 		&suite.passport,
-		&fakeRedisConn{},
+		db.NewMemoryRedisConn(),
+		&suite.denylist,
+		keys,
+		&suite.notifier,
 	)
 }
 
@@ -148,9 +198,10 @@ func (suite *serviceUnit) TestVerifyAuthentication() {
 				On("IsValidPassword", tc.inputPassword, tc.stubUser.PasswordHash).
 				Return(tc.stubPasswordValid)
 
-			actualUser, actualErr := suite.authService.VerifyAuthentication(
+			actualUser, actualErr := suite.authService.VerifyAuthentication(context.Background(),
 				tc.inputUserName,
 				tc.inputPassword,
+				"10.0.0.1",
 			)
 
 			suite.Equal(tc.expectedUser, actualUser)
@@ -160,10 +211,329 @@ func (suite *serviceUnit) TestVerifyAuthentication() {
 
 }
 
+func (suite *serviceUnit) TestVerifyAuthentication_ShouldLockUser_WhenFailuresReachThreshold() {
+	loginUser := user.User{ID: 10, UserName: "username", PasswordHash: []byte("hash")}
+
+	suite.userRepo.On("GetUserByUserName", "username").Return(loginUser, nil)
+	suite.passport.On("IsValidPassword", "invalidPassword", loginUser.PasswordHash).Return(false)
+	suite.passport.On("IsValidPassword", "password", loginUser.PasswordHash).Return(true)
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.authService.VerifyAuthentication(ctx, "username", "invalidPassword", "10.0.0.1")
+		suite.Equal(ErrInvalidPassword, err)
+	}
+
+	_, err := suite.authService.VerifyAuthentication(ctx, "username", "password", "10.0.0.2")
+	suite.Equal(ErrAccountLocked, err)
+
+	suite.NoError(suite.authService.UnlockAccount(ctx, "username", ""))
+
+	actualUser, err := suite.authService.VerifyAuthentication(ctx, "username", "password", "10.0.0.2")
+	suite.NoError(err)
+	suite.Equal(loginUser, actualUser)
+}
+
+func (suite *serviceUnit) TestVerifyAuthentication_ShouldLockIP_WhenFailuresReachThreshold() {
+	suite.userRepo.On("GetUserByUserName", mock.Anything).Return(user.EmptyUser, common.ErrEntityNotFound)
+
+	ctx := context.Background()
+
+	for _, username := range []string{"first", "second", "third"} {
+		_, err := suite.authService.VerifyAuthentication(ctx, username, "password", "10.0.0.1")
+		suite.Equal(common.ErrEntityNotFound, err)
+	}
+
+	_, err := suite.authService.VerifyAuthentication(ctx, "fourth", "password", "10.0.0.1")
+	suite.Equal(ErrAccountLocked, err)
+
+	_, err = suite.authService.VerifyAuthentication(ctx, "fourth", "password", "10.0.0.2")
+	suite.Equal(common.ErrEntityNotFound, err)
+}
+
+func (suite *serviceUnit) TestUnlockAccount_ShouldUnlockIP_WhenIPGiven() {
+	loginUser := user.User{ID: 10, UserName: "username", PasswordHash: []byte("hash")}
+
+	suite.userRepo.On("GetUserByUserName", "username").Return(loginUser, nil)
+	suite.userRepo.On("GetUserByUserName", "other").Return(user.EmptyUser, common.ErrEntityNotFound)
+	suite.passport.On("IsValidPassword", "invalidPassword", loginUser.PasswordHash).Return(false)
+	suite.passport.On("IsValidPassword", "password", loginUser.PasswordHash).Return(true)
+
+	ctx := context.Background()
+
+	for _, username := range []string{"username", "username", "other"} {
+		_, err := suite.authService.VerifyAuthentication(ctx, username, "invalidPassword", "10.0.0.1")
+		suite.NotEqual(ErrAccountLocked, err)
+	}
+
+	suite.NoError(suite.authService.UnlockAccount(ctx, "username", ""))
+
+	_, err := suite.authService.VerifyAuthentication(ctx, "username", "password", "10.0.0.1")
+	suite.Equal(ErrAccountLocked, err)
+
+	suite.NoError(suite.authService.UnlockAccount(ctx, "username", "10.0.0.1"))
+
+	actualUser, err := suite.authService.VerifyAuthentication(ctx, "username", "password", "10.0.0.1")
+	suite.NoError(err)
+	suite.Equal(loginUser, actualUser)
+}
+
+func (suite *serviceUnit) TestVerifyAuthentication_ShouldResetUserFailures_WhenSucceeded() {
+	loginUser := user.User{ID: 10, UserName: "username", PasswordHash: []byte("hash")}
+
+	suite.userRepo.On("GetUserByUserName", "username").Return(loginUser, nil)
+	suite.passport.On("IsValidPassword", "invalidPassword", loginUser.PasswordHash).Return(false)
+	suite.passport.On("IsValidPassword", "password", loginUser.PasswordHash).Return(true)
+
+	ctx := context.Background()
+
+	attempts := []string{"invalidPassword", "password", "invalidPassword", "password"}
+	for _, password := range attempts {
+		_, err := suite.authService.VerifyAuthentication(ctx, "username", password, "")
+		suite.NotEqual(ErrAccountLocked, err)
+	}
+}
+
+func TestLockDuration(t *testing.T) {
+	l := lockout{conf: config.LockoutConfig{LockSec: 60, MaxLockSec: 600}}
+
+	testCases := []struct {
+		description   string
+		overThreshold int64
+		expected      time.Duration
+	}{
+		{
+			description:   "ShouldReturnLock_WhenThresholdReached",
+			overThreshold: 0,
+			expected:      time.Minute,
+		},
+		{
+			description:   "ShouldDoubleLock_WhenFailedAgain",
+			overThreshold: 2,
+			expected:      4 * time.Minute,
+		},
+		{
+			description:   "ShouldReturnMaxLock_WhenDoubledLockExceeds",
+			overThreshold: 100,
+			expected:      10 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, l.lockDuration(tc.overThreshold))
+		})
+	}
+}
+
+func (suite *serviceUnit) TestResetPassword() {
+	resetUser := user.User{ID: 10, UserName: "username"}
+	newHash := []byte("new hash")
+
+	suite.userRepo.On("GetUserByUserName", "username").Return(resetUser, nil)
+	suite.passport.On("HashPassword", "new password").Return(newHash, nil)
+	suite.userRepo.
+		On("UpdateUserByUserID", resetUser.ID, user.User{PasswordHash: newHash}).
+		Return(resetUser, nil)
+
+	ctx := context.Background()
+
+	suite.NoError(suite.authService.RequestPasswordReset(ctx, "username"))
+	suite.Require().Len(suite.notifier.notifications, 1)
+
+	notification := suite.notifier.notifications[0]
+	suite.Equal(resetUser.ID, notification.UserID)
+	suite.NotEmpty(notification.Body)
+
+	testCases := []struct {
+		description string
+		token       string
+		expectedErr error
+	}{
+		{
+			description: "ShouldReturnInvalidResetTokenErr_WhenUnknownToken",
+			token:       "UNKNOWN_TOKEN",
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			description: "ShouldBeSuccess",
+			token:       notification.Body,
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnInvalidResetTokenErr_WhenTokenUsed",
+			token:       notification.Body,
+			expectedErr: ErrInvalidResetToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualErr := suite.authService.ResetPassword(ctx, tc.token, "new password")
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+
+	suite.userRepo.AssertNumberOfCalls(suite.T(), "UpdateUserByUserID", 1)
+}
+
+func (suite *serviceUnit) TestRequestPasswordReset_ShouldIgnore_WhenUnknownUser() {
+	suite.userRepo.
+		On("GetUserByUserName", "NOT_EXISTS_USER").
+		Return(user.EmptyUser, common.ErrEntityNotFound)
+
+	err := suite.authService.RequestPasswordReset(context.Background(), "NOT_EXISTS_USER")
+
+	suite.NoError(err)
+	suite.Empty(suite.notifier.notifications)
+}
+
+func (suite *serviceUnit) TestEnrollTOTP_ShouldReturnAlreadyEnabledErr_WhenEnabled() {
+	suite.userRepo.On("GetUserByUserID", int64(10)).Return(user.User{ID: 10, TOTPEnabled: true}, nil)
+
+	_, err := suite.authService.EnrollTOTP(context.Background(), 10)
+
+	suite.Equal(ErrMFAAlreadyEnabled, err)
+	suite.userRepo.AssertNotCalled(suite.T(), "UpdateTOTPByUserID")
+}
+
+func (suite *serviceUnit) TestConfirmTOTP() {
+	secret, err := service.NewTOTPSecret()
+	suite.Require().NoError(err)
+
+	confirmUser := user.User{ID: 10, TOTPSecret: secret}
+	code, err := service.TOTPCode(secret, time.Now())
+	suite.Require().NoError(err)
+
+	var storedHashes []string
+
+	suite.userRepo.On("GetUserByUserID", confirmUser.ID).Return(confirmUser, nil)
+	suite.userRepo.
+		On("UpdateTOTPByUserID", confirmUser.ID, secret, true, mock.Anything).
+		Run(func(args mock.Arguments) { storedHashes = args.Get(3).([]string) }).
+		Return(confirmUser, nil)
+
+	recoveryCodes, err := suite.authService.ConfirmTOTP(context.Background(), confirmUser.ID, code)
+
+	suite.NoError(err)
+	suite.Len(recoveryCodes, recoveryCodeCount)
+
+	for i, recoveryCode := range recoveryCodes {
+		suite.NotEqual(recoveryCode, storedHashes[i])
+		suite.Equal(hashRecoveryCode(recoveryCode), storedHashes[i])
+	}
+}
+
+func (suite *serviceUnit) TestVerifyMFAChallenge() {
+	secret, err := service.NewTOTPSecret()
+	suite.Require().NoError(err)
+
+	loginUser := user.User{ID: 10, UserName: "username", TOTPSecret: secret, TOTPEnabled: true}
+	code, err := service.TOTPCode(secret, time.Now())
+	suite.Require().NoError(err)
+
+	suite.userRepo.On("GetUserByUserID", loginUser.ID).Return(loginUser, nil)
+
+	ctx := context.Background()
+
+	challengeToken, err := suite.authService.IssueMFAChallenge(ctx, loginUser.ID)
+	suite.Require().NoError(err)
+
+	replayChallengeToken, err := suite.authService.IssueMFAChallenge(ctx, loginUser.ID)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		description    string
+		challengeToken string
+		code           string
+		expectedUser   user.User
+		expectedErr    error
+	}{
+		{
+			description:    "ShouldReturnInvalidMFATokenErr_WhenUnknownToken",
+			challengeToken: "UNKNOWN_TOKEN",
+			code:           code,
+			expectedUser:   user.EmptyUser,
+			expectedErr:    ErrInvalidMFAToken,
+		},
+		{
+			description:    "ShouldReturnInvalidMFACodeErr_WhenInvalidCode",
+			challengeToken: challengeToken,
+			code:           "abcdef",
+			expectedUser:   user.EmptyUser,
+			expectedErr:    ErrInvalidMFACode,
+		},
+		{
+			description:    "ShouldBeSuccess",
+			challengeToken: challengeToken,
+			code:           code,
+			expectedUser:   loginUser,
+			expectedErr:    nil,
+		},
+		{
+			description:    "ShouldReturnInvalidMFATokenErr_WhenTokenUsed",
+			challengeToken: challengeToken,
+			code:           code,
+			expectedUser:   user.EmptyUser,
+			expectedErr:    ErrInvalidMFAToken,
+		},
+		{
+			description:    "ShouldReturnInvalidMFACodeErr_WhenCodeReplayed",
+			challengeToken: replayChallengeToken,
+			code:           code,
+			expectedUser:   user.EmptyUser,
+			expectedErr:    ErrInvalidMFACode,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualUser, actualErr := suite.authService.VerifyMFAChallenge(ctx,
+				tc.challengeToken,
+				tc.code,
+				"",
+				"127.0.0.1",
+			)
+
+			suite.Equal(tc.expectedErr, actualErr)
+			suite.Equal(tc.expectedUser, actualUser)
+		})
+	}
+}
+
+func (suite *serviceUnit) TestVerifyMFAChallenge_ShouldConsumeRecoveryCode() {
+	loginUser := user.User{ID: 10, UserName: "username", TOTPEnabled: true}
+
+	suite.userRepo.On("GetUserByUserID", loginUser.ID).Return(loginUser, nil)
+	suite.userRepo.
+		On("RemoveRecoveryCodeByUserID", loginUser.ID, hashRecoveryCode("abcde-fghij")).
+		Return(nil).
+		Once()
+	suite.userRepo.
+		On("RemoveRecoveryCodeByUserID", loginUser.ID, hashRecoveryCode("abcde-fghij")).
+		Return(common.ErrEntityNotFound)
+
+	ctx := context.Background()
+
+	for _, expectedErr := range []error{nil, ErrInvalidMFACode} {
+		challengeToken, err := suite.authService.IssueMFAChallenge(ctx, loginUser.ID)
+		suite.Require().NoError(err)
+
+		_, err = suite.authService.VerifyMFAChallenge(ctx, challengeToken, "", "ABCDE FGHIJ", "127.0.0.1")
+		suite.Equal(expectedErr, err)
+	}
+}
+
 func (suite *serviceUnit) TestGenerateAccessToken() {
 	userID := int64(1)
+	sessionID := "session"
 
-	accessToken, err := suite.authService.GenerateAccessToken(userID)
+	suite.userRepo.
+		On("GetUserByUserID", userID).
+		Return(user.User{ID: userID, Role: service.RoleAdmin}, nil)
+
+	accessToken, err := suite.authService.GenerateAccessToken(context.Background(), userID, sessionID)
 	suite.NoError(err)
 
 	suite.T().Log(accessToken)
@@ -181,6 +551,10 @@ func (suite *serviceUnit) TestGenerateAccessToken() {
 
 	suite.True(ok)
 	suite.Equal(strconv.FormatInt(userID, 10), claims["sub"])
+	suite.Equal(sessionID, claims["sid"])
+	suite.Equal(service.RoleAdmin, claims["role"])
+	suite.NotEmpty(claims["jti"])
+	suite.Equal(service.AccessTokenType, claims["typ"])
 
 	expectedExpiresInSec := suite.configuration.Jwt.AccessExpiresInSec
 	actualExpiresInSec := ActualExpiresInSec(suite.T(), claims)
@@ -188,6 +562,267 @@ func (suite *serviceUnit) TestGenerateAccessToken() {
 	suite.Equal(expectedExpiresInSec, actualExpiresInSec)
 }
 
+func (suite *serviceUnit) TestGenerateClientAccessToken() {
+	userID := int64(1)
+	grant := ClientGrant{
+		ClientID: "client",
+		Scopes:   []string{service.ScopeTodosRead, service.ScopeTodosWrite},
+	}
+
+	suite.userRepo.
+		On("GetUserByUserID", userID).
+		Return(user.User{ID: userID, Role: service.RoleUser}, nil)
+
+	accessToken, err := suite.authService.GenerateClientAccessToken(context.Background(), userID, "", grant)
+	suite.Require().NoError(err)
+
+	claims, err := suite.authService.ExtractTokenClaims(accessToken)
+	suite.Require().NoError(err)
+
+	suite.Equal("client", claims["client_id"])
+	suite.Equal(service.ScopeTodosRead+" "+service.ScopeTodosWrite, claims["scope"])
+	suite.Nil(claims["sid"])
+}
+
+func (suite *serviceUnit) TestRotateClientRefreshToken() {
+	ctx := context.Background()
+	grant := ClientGrant{
+		ClientID: "client",
+		Scopes:   []string{service.ScopeTodosRead},
+	}
+
+	refreshToken, err := suite.authService.IssueClientRefreshToken(ctx, 1, grant)
+	suite.Require().NoError(err)
+
+	_, err = suite.authService.RotateRefreshToken(ctx, refreshToken.Token)
+	suite.Equal(ErrInvalidRefreshToken, err)
+
+	_, err = suite.authService.RotateClientRefreshToken(ctx, "other", refreshToken.Token)
+	suite.Equal(ErrInvalidRefreshToken, err)
+
+	rotatedToken, err := suite.authService.RotateClientRefreshToken(ctx, "client", refreshToken.Token)
+	suite.Require().NoError(err)
+
+	suite.Equal(refreshToken.SessionID, rotatedToken.SessionID)
+	suite.Equal(grant, rotatedToken.Grant)
+
+	sessionToken, err := suite.authService.IssueRefreshToken(ctx, 1)
+	suite.Require().NoError(err)
+
+	_, err = suite.authService.RotateClientRefreshToken(ctx, "client", sessionToken.Token)
+	suite.Equal(ErrInvalidRefreshToken, err)
+}
+
+func (suite *serviceUnit) TestRotateRefreshToken_ShouldReturnInvalidErr_WhenAccessToken() {
+	ctx := context.Background()
+	userID := int64(1)
+
+	suite.userRepo.
+		On("GetUserByUserID", userID).
+		Return(user.User{ID: userID, Role: service.RoleUser}, nil)
+
+	refreshToken, err := suite.authService.IssueRefreshToken(ctx, userID)
+	suite.Require().NoError(err)
+
+	accessToken, err := suite.authService.GenerateAccessToken(ctx, userID, refreshToken.SessionID)
+	suite.Require().NoError(err)
+
+	_, err = suite.authService.RotateRefreshToken(ctx, accessToken)
+	suite.Equal(ErrInvalidRefreshToken, err)
+
+	// session is not revoked as if refresh token was reused.
+	_, err = suite.authService.RotateRefreshToken(ctx, refreshToken.Token)
+	suite.NoError(err)
+}
+
+func (suite *serviceUnit) TestRevokeAccessToken() {
+	testCases := []struct {
+		description   string
+		argsTokenID   string
+		argsExpiresAt time.Time
+		stubErr       error
+		expectedErr   error
+	}{
+		{
+			description:   "ShouldRevokeAccessToken",
+			argsTokenID:   "token_id",
+			argsExpiresAt: time.Now().Add(300 * time.Second),
+			stubErr:       nil,
+			expectedErr:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			suite.denylist.
+				On("Revoke", tc.argsTokenID, mock.AnythingOfType("time.Duration")).
+				Return(tc.stubErr)
+
+			actualErr := suite.authService.RevokeAccessToken(context.Background(), tc.argsTokenID, tc.argsExpiresAt)
+
+			suite.Equal(tc.expectedErr, actualErr)
+
+			ttl := suite.denylist.Calls[0].Arguments.Get(1).(time.Duration)
+			suite.True(ttl > 0 && ttl <= 300*time.Second)
+		})
+	}
+}
+
+func (suite *serviceUnit) TestIntrospectToken_ShouldBeActive_WhenAccessTokenIsNotRevoked() {
+	userID := int64(1)
+	grant := ClientGrant{
+		ClientID: "client",
+		Scopes:   []string{service.ScopeTodosRead},
+	}
+
+	suite.userRepo.
+		On("GetUserByUserID", userID).
+		Return(user.User{ID: userID, Role: service.RoleUser}, nil)
+	suite.denylist.
+		On("IsRevoked", mock.Anything).
+		Return(false, nil)
+
+	accessToken, err := suite.authService.GenerateClientAccessToken(context.Background(), userID, "session", grant)
+	suite.Require().NoError(err)
+
+	actual, err := suite.authService.IntrospectToken(context.Background(), accessToken)
+	suite.Require().NoError(err)
+
+	suite.True(actual.Active)
+	suite.Equal(TokenTypeBearer, actual.TokenType)
+	suite.Equal("1", actual.Subject)
+	suite.Equal("session", actual.SessionID)
+	suite.Equal(grant.ClientID, actual.ClientID)
+	suite.Equal(grant.Scopes, actual.Scopes)
+	suite.NotEmpty(actual.TokenID)
+	suite.True(actual.ExpiresAt > actual.IssuedAt)
+
+	revokedIDs := suite.denylist.Calls[0].Arguments.Get(0).([]string)
+	suite.Equal([]string{actual.TokenID, "session"}, revokedIDs)
+}
+
+func (suite *serviceUnit) TestIntrospectToken_ShouldBeInactive_WhenAccessTokenIsRevoked() {
+	userID := int64(1)
+
+	suite.userRepo.
+		On("GetUserByUserID", userID).
+		Return(user.User{ID: userID, Role: service.RoleUser}, nil)
+	suite.denylist.
+		On("IsRevoked", mock.Anything).
+		Return(true, nil)
+
+	accessToken, err := suite.authService.GenerateAccessToken(context.Background(), userID, "session")
+	suite.Require().NoError(err)
+
+	actual, err := suite.authService.IntrospectToken(context.Background(), accessToken)
+	suite.Require().NoError(err)
+
+	suite.Equal(TokenIntrospection{}, actual)
+}
+
+func (suite *serviceUnit) TestIntrospectToken_ShouldBeInactive_WhenTokenIsInvalid() {
+	actual, err := suite.authService.IntrospectToken(context.Background(), "invalid_token")
+	suite.Require().NoError(err)
+
+	suite.Equal(TokenIntrospection{}, actual)
+}
+
+func (suite *serviceUnit) TestIntrospectToken_ShouldBeInactive_WhenTokenHasNoType() {
+	claims := &jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(300 * time.Second).Unix(),
+		IssuedAt:  time.Now().Unix(),
+		Id:        "token_id",
+		Subject:   "1",
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).
+		SignedString([]byte(suite.configuration.Jwt.SecretKey))
+	suite.Require().NoError(err)
+
+	actual, err := suite.authService.IntrospectToken(context.Background(), token)
+	suite.Require().NoError(err)
+
+	suite.Equal(TokenIntrospection{}, actual)
+}
+
+func (suite *serviceUnit) TestIntrospectToken_ShouldBeActive_OnlyWhileRefreshTokenIsLatest() {
+	ctx := context.Background()
+
+	refreshToken, err := suite.authService.IssueRefreshToken(ctx, 1)
+	suite.Require().NoError(err)
+
+	actual, err := suite.authService.IntrospectToken(ctx, refreshToken.Token)
+	suite.Require().NoError(err)
+
+	suite.True(actual.Active)
+	suite.Equal(TokenTypeRefresh, actual.TokenType)
+	suite.Equal("1", actual.Subject)
+	suite.Equal(refreshToken.SessionID, actual.SessionID)
+
+	rotatedToken, err := suite.authService.RotateRefreshToken(ctx, refreshToken.Token)
+	suite.Require().NoError(err)
+
+	actual, err = suite.authService.IntrospectToken(ctx, refreshToken.Token)
+	suite.Require().NoError(err)
+	suite.False(actual.Active)
+
+	actual, err = suite.authService.IntrospectToken(ctx, rotatedToken.Token)
+	suite.Require().NoError(err)
+	suite.True(actual.Active)
+}
+
+func (suite *serviceUnit) TestRevokeToken_ShouldRevokeAccessToken() {
+	userID := int64(1)
+
+	suite.userRepo.
+		On("GetUserByUserID", userID).
+		Return(user.User{ID: userID, Role: service.RoleUser}, nil)
+	suite.denylist.
+		On("IsRevoked", mock.Anything).
+		Return(false, nil)
+
+	accessToken, err := suite.authService.GenerateAccessToken(context.Background(), userID, "session")
+	suite.Require().NoError(err)
+
+	introspection, err := suite.authService.IntrospectToken(context.Background(), accessToken)
+	suite.Require().NoError(err)
+
+	suite.denylist.
+		On("Revoke", introspection.TokenID, mock.AnythingOfType("time.Duration")).
+		Return(nil)
+
+	err = suite.authService.RevokeToken(context.Background(), accessToken)
+	suite.Require().NoError(err)
+
+	suite.denylist.AssertCalled(suite.T(), "Revoke", introspection.TokenID, mock.AnythingOfType("time.Duration"))
+}
+
+func (suite *serviceUnit) TestRevokeToken_ShouldRevokeSession_WhenRefreshToken() {
+	ctx := context.Background()
+
+	suite.denylist.
+		On("Revoke", mock.Anything, mock.AnythingOfType("time.Duration")).
+		Return(nil)
+
+	refreshToken, err := suite.authService.IssueRefreshToken(ctx, 1)
+	suite.Require().NoError(err)
+
+	err = suite.authService.RevokeToken(ctx, refreshToken.Token)
+	suite.Require().NoError(err)
+
+	suite.denylist.AssertCalled(suite.T(), "Revoke", refreshToken.SessionID, 3000*time.Second)
+
+	_, err = suite.authService.RotateRefreshToken(ctx, refreshToken.Token)
+	suite.Equal(ErrInvalidRefreshToken, err)
+}
+
+func (suite *serviceUnit) TestRevokeToken_ShouldIgnore_WhenTokenIsInvalid() {
+	err := suite.authService.RevokeToken(context.Background(), "invalid_token")
+
+	suite.NoError(err)
+	suite.denylist.AssertNotCalled(suite.T(), "Revoke", mock.Anything, mock.Anything)
+}
+
 func (suite *serviceUnit) TestTokenExtractClaims() {
 	testCases := []struct {
 		description string
@@ -1,26 +1,66 @@
 package common
 
 import (
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/health"
 	"github.com/gin-gonic/gin"
 )
 
 // Controller is common controller.
-type Controller struct{}
+type Controller struct {
+	healthRegistry health.Registry
+}
 
 // NewController return new controller instance.
-func NewController() *Controller {
-	return &Controller{}
+func NewController(healthRegistry health.Registry) *Controller {
+	return &Controller{
+		healthRegistry: healthRegistry,
+	}
 }
 
 // RegisterRoutes is method that register api routes.
-func (c Controller) RegisterRoutes(router gin.IRouter) {
+func (c Controller) RegisterRoutes(router *api.Router) {
 	router.Handle("GET", "/healthy", c.getHealthy)
+	router.Handle("GET", "/healthz/live", c.getLive)
+	router.Handle("GET", "/healthz/ready", c.getReady)
 }
 
-// @Description Get server healthy
-// @Success 200
+// @Description Deprecated alias of /healthz/ready, use it instead
+// @Produce json
+// @Success 200 {object} health.Report "ok"
+// @Failure 503 {object} health.Report "Dependency is down"
 // @Tags App API
 // @Router /healthy [get]
 func (c *Controller) getHealthy(ctx *gin.Context) {
-	ctx.Status(200)
+	c.getReady(ctx)
+}
+
+// @Description Get whether server process is alive
+// @Produce json
+// @Success 200 {object} health.Report "ok"
+// @Tags App API
+// @Router /healthz/live [get]
+func (c *Controller) getLive(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, health.Report{
+		Status: health.StatusUp,
+		Checks: map[string]health.CheckResult{},
+	})
+}
+
+// @Description Get whether every dependency is ready to serve traffic
+// @Produce json
+// @Success 200 {object} health.Report "ok"
+// @Failure 503 {object} health.Report "Dependency is down"
+// @Tags App API
+// @Router /healthz/ready [get]
+func (c *Controller) getReady(ctx *gin.Context) {
+	report := c.healthRegistry.Check(ctx.Request.Context())
+	if !report.IsUp() {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
 }
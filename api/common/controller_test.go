@@ -1,9 +1,14 @@
 package common
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/health"
 	"github.com/gghcode/go-gin-starterkit/internal/testutil"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
@@ -12,8 +17,9 @@ import (
 type controllerUnit struct {
 	suite.Suite
 
-	ginEngine  *gin.Engine
-	controller *Controller
+	ginEngine      *gin.Engine
+	healthRegistry health.Registry
+	controller     *Controller
 }
 
 func TestCommonControllerUnit(t *testing.T) {
@@ -24,32 +30,131 @@ func (suite *controllerUnit) SetupTest() {
 	gin.SetMode(gin.TestMode)
 
 	suite.ginEngine = gin.New()
-	suite.controller = NewController()
-	suite.controller.RegisterRoutes(suite.ginEngine)
+	suite.healthRegistry = health.NewRegistry()
+	suite.controller = NewController(suite.healthRegistry)
+	suite.controller.RegisterRoutes(api.NewRouter(&suite.ginEngine.RouterGroup))
 }
 
 func (suite *controllerUnit) TestHealthy() {
 	testCases := []struct {
 		description    string
+		url            string
 		expectedStatus int
+	}{
+		{
+			description:    "ShouldReturnOK_WhenLive",
+			url:            "/healthz/live",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualRes := testutil.ActualResponse(
+				suite.T(),
+				suite.ginEngine,
+				"GET",
+				tc.url,
+				nil,
+			)
+
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+		})
+	}
+}
+
+func (suite *controllerUnit) TestReady() {
+	testCases := []struct {
+		description    string
+		url            string
+		redisErr       error
+		expectedStatus int
+		expectedReport health.Report
 	}{
 		{
 			description:    "ShouldReturnOK",
+			url:            "/healthz/ready",
+			redisErr:       nil,
 			expectedStatus: http.StatusOK,
+			expectedReport: health.Report{
+				Status: health.StatusUp,
+				Checks: map[string]health.CheckResult{
+					"postgres": {Status: health.StatusUp},
+					"redis":    {Status: health.StatusUp},
+				},
+			},
+		},
+		{
+			description:    "ShouldReturnServiceUnavailable_WhenRedisDown",
+			url:            "/healthz/ready",
+			redisErr:       errors.New("connection refused"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: health.Report{
+				Status: health.StatusDown,
+				Checks: map[string]health.CheckResult{
+					"postgres": {Status: health.StatusUp},
+					"redis":    {Status: health.StatusDown, Error: "connection refused"},
+				},
+			},
+		},
+		{
+			description:    "ShouldReturnOK_WhenDeprecatedHealthy",
+			url:            "/healthy",
+			redisErr:       nil,
+			expectedStatus: http.StatusOK,
+			expectedReport: health.Report{
+				Status: health.StatusUp,
+				Checks: map[string]health.CheckResult{
+					"postgres": {Status: health.StatusUp},
+					"redis":    {Status: health.StatusUp},
+				},
+			},
+		},
+		{
+			description:    "ShouldReturnServiceUnavailable_WhenDeprecatedHealthyAndRedisDown",
+			url:            "/healthy",
+			redisErr:       errors.New("connection refused"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: health.Report{
+				Status: health.StatusDown,
+				Checks: map[string]health.CheckResult{
+					"postgres": {Status: health.StatusUp},
+					"redis":    {Status: health.StatusDown, Error: "connection refused"},
+				},
+			},
 		},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.description, func() {
+			suite.SetupTest()
+
+			redisErr := tc.redisErr
+			suite.healthRegistry.Register("postgres", 0, func(ctx context.Context) error {
+				return nil
+			})
+			suite.healthRegistry.Register("redis", 0, func(ctx context.Context) error {
+				return redisErr
+			})
+
 			actualRes := testutil.ActualResponse(
 				suite.T(),
 				suite.ginEngine,
 				"GET",
-				"/healthy",
+				tc.url,
 				nil,
 			)
 
+			var actualReport health.Report
+			suite.NoError(json.NewDecoder(actualRes.Body).Decode(&actualReport))
+
+			for name, result := range actualReport.Checks {
+				result.LatencyMs = 0
+				actualReport.Checks[name] = result
+			}
+
 			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+			suite.Equal(tc.expectedReport, actualReport)
 		})
 	}
 }
@@ -1,7 +1,17 @@
 package common
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	validator "gopkg.in/go-playground/validator.v8"
 )
 
 var (
@@ -20,34 +30,217 @@ var (
 
 	// ErrInvalidRequestPayload is occurred when payload is invalid.
 	ErrInvalidRequestPayload = errors.New("Request payload is invalid")
+
+	// ErrRouteNotFound is occurred when request matches no route.
+	ErrRouteNotFound = errors.New("Route was not found")
+
+	// ErrInternalServer is occurred when handler panics or fails unexpectedly.
+	ErrInternalServer = errors.New("Internal server error")
 )
 
+// ErrorCode is status and machine readable code that error is responded with.
+type ErrorCode struct {
+	Status int
+	Code   string
+}
+
+// ErrorCodes map errors of package into status and code of response.
+type ErrorCodes map[error]ErrorCode
+
+// defaultErrorCodes map errors that every package shares.
+var defaultErrorCodes = ErrorCodes{
+	ErrParsingFailed:         {http.StatusBadRequest, "request.invalid_parameter"},
+	ErrInvalidUUID:           {http.StatusBadRequest, "request.invalid_uuid"},
+	ErrInvalidRequestPayload: {http.StatusBadRequest, "request.invalid_payload"},
+	ErrEntityNotFound:        {http.StatusNotFound, "entity.not_found"},
+	ErrAlreadyExistsEntity:   {http.StatusConflict, "entity.already_exists"},
+	ErrRouteNotFound:         {http.StatusNotFound, "route.not_found"},
+	ErrInternalServer:        {http.StatusInternalServerError, "server.internal"},
+	db.ErrTimeout:            {http.StatusGatewayTimeout, "server.timeout"},
+	db.ErrCanceled:           {http.StatusServiceUnavailable, "server.canceled"},
+}
+
+func (codes ErrorCodes) lookup(err error) (ErrorCode, bool) {
+	// errors of uncomparable type such as map would panic as key.
+	if !reflect.TypeOf(err).Comparable() {
+		return ErrorCode{}, false
+	}
+
+	errCode, ok := codes[err]
+	return errCode, ok
+}
+
 // ErrorResponse is app response.
 type ErrorResponse struct {
-	Errors []APIError `json:"errors"`
+	Errors    []APIError `json:"errors"`
+	RequestID string     `json:"request_id,omitempty"`
 }
 
 // AddError add new error at ErrorResponse.
 func (errResponse ErrorResponse) AddError(err error) ErrorResponse {
-	errResponse.Errors = append(errResponse.Errors, APIError{
-		Message: err.Error(),
-	})
+	_, apiErrors := MapErr(nil, err)
+	errResponse.Errors = append(errResponse.Errors, apiErrors...)
 
 	return errResponse
 }
 
 // APIError is http error object.
+// Code is stable identifier of error such as user.already_exists
+// and Field is name of invalid field of request payload or query.
 type APIError struct {
+	Code    string `json:"code"`
 	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+	Detail  string `json:"detail,omitempty"`
 }
 
-// NewErrResp is return new error response.
-func NewErrResp(err error) ErrorResponse {
-	return ErrorResponse{
-		Errors: []APIError{
-			APIError{
-				Message: err.Error(),
+// BindingErr mark err of binding request, so it is responded as bad request
+// with one entry per invalid field.
+func BindingErr(err error) error {
+	return bindingError{err}
+}
+
+type bindingError struct {
+	err error
+}
+
+func (bindingErr bindingError) Error() string {
+	return bindingErr.err.Error()
+}
+
+// MapErr translate err into status and errors of response by codes of package
+// and then by default codes. Unknown err is responded as ErrInternalServer
+// so internal messages are not exposed to clients.
+func MapErr(codes ErrorCodes, err error) (int, []APIError) {
+	if bindingErr, ok := err.(bindingError); ok {
+		return http.StatusBadRequest, bindingAPIErrors(bindingErr.err)
+	}
+
+	errCode, ok := codes.lookup(err)
+	if !ok {
+		errCode, ok = defaultErrorCodes.lookup(err)
+	}
+
+	if !ok {
+		errCode, err = defaultErrorCodes[ErrInternalServer], ErrInternalServer
+	}
+
+	return errCode.Status, []APIError{
+		{
+			Code:    errCode.Code,
+			Message: err.Error(),
+		},
+	}
+}
+
+func bindingAPIErrors(err error) []APIError {
+	switch bindingErr := err.(type) {
+	case validator.ValidationErrors:
+		return validationAPIErrors(bindingErr)
+	case *json.UnmarshalTypeError:
+		return []APIError{
+			{
+				Code:    "validation.type",
+				Message: "Field " + bindingErr.Field + " is invalid type",
+				Field:   bindingErr.Field,
+				Detail:  "expected " + bindingErr.Type.String(),
 			},
+		}
+	}
+
+	detail := err.Error()
+	if err == io.EOF {
+		detail = "Request payload is empty"
+	}
+
+	return []APIError{
+		{
+			Code:    defaultErrorCodes[ErrInvalidRequestPayload].Code,
+			Message: ErrInvalidRequestPayload.Error(),
+			Detail:  detail,
 		},
 	}
 }
+
+func validationAPIErrors(validationErrs validator.ValidationErrors) []APIError {
+	apiErrors := make([]APIError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		detail := fieldErr.Tag
+		if fieldErr.Param != "" {
+			detail += "=" + fieldErr.Param
+		}
+
+		apiErrors = append(apiErrors, APIError{
+			Code:    "validation." + fieldErr.Tag,
+			Message: validationMessage(fieldErr),
+			Field:   fieldErr.Name,
+			Detail:  detail,
+		})
+	}
+
+	// validation errors are map, so order of entries is fixed by field.
+	sort.Slice(apiErrors, func(i, j int) bool {
+		return apiErrors[i].Field < apiErrors[j].Field
+	})
+
+	return apiErrors
+}
+
+func validationMessage(fieldErr *validator.FieldError) string {
+	switch fieldErr.Tag {
+	case "required":
+		return "Field " + fieldErr.Name + " is required"
+	case "min":
+		return "Field " + fieldErr.Name + " must be at least " + quantity(fieldErr)
+	case "max":
+		return "Field " + fieldErr.Name + " must be at most " + quantity(fieldErr)
+	}
+
+	return "Field " + fieldErr.Name + " is invalid"
+}
+
+func quantity(fieldErr *validator.FieldError) string {
+	if fieldErr.Kind != reflect.String {
+		return fieldErr.Param
+	}
+
+	return fieldErr.Param + " characters"
+}
+
+// NewErrResp is return new error response of err mapped by codes.
+func NewErrResp(codes ErrorCodes, err error) ErrorResponse {
+	_, apiErrors := MapErr(codes, err)
+
+	return ErrorResponse{
+		Errors: apiErrors,
+	}
+}
+
+// WriteErrResp write error response of err mapped by codes
+// that contains request id of ctx.
+// Problem details is written instead when client accepts application/problem+json.
+func WriteErrResp(ctx *gin.Context, codes ErrorCodes, err error) {
+	status, errResp := newErrRespWithRequestID(ctx, codes, err)
+	writeErrResp(ctx, status, errResp)
+}
+
+// AbortWithErrResp abort pending handlers and write error response
+// of err mapped by codes that contains request id of ctx.
+func AbortWithErrResp(ctx *gin.Context, codes ErrorCodes, err error) {
+	status, errResp := newErrRespWithRequestID(ctx, codes, err)
+	ctx.Abort()
+	writeErrResp(ctx, status, errResp)
+}
+
+func newErrRespWithRequestID(ctx *gin.Context, codes ErrorCodes, err error) (int, ErrorResponse) {
+	status, apiErrors := MapErr(codes, err)
+	if status >= http.StatusInternalServerError {
+		// original error is hidden from response but kept for access log.
+		ctx.Error(err)
+	}
+
+	return status, ErrorResponse{
+		Errors:    apiErrors,
+		RequestID: logging.RequestID(ctx.Request.Context()),
+	}
+}
@@ -1,10 +1,17 @@
 package common
 
 import (
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAddError(t *testing.T) {
@@ -25,8 +32,175 @@ func TestAddError(t *testing.T) {
 }
 
 func TestNewErrResp(t *testing.T) {
+	errResp := NewErrResp(nil, ErrEntityNotFound)
+
+	assert.Equal(t, []APIError{
+		{
+			Code:    "entity.not_found",
+			Message: ErrEntityNotFound.Error(),
+		},
+	}, errResp.Errors)
+}
+
+func TestMapErr(t *testing.T) {
+	fakeErr := errors.New("fake error")
+	codes := ErrorCodes{
+		ErrEntityNotFound: {Status: http.StatusNotFound, Code: "fake.not_found"},
+		fakeErr:           {Status: http.StatusTeapot, Code: "fake.teapot"},
+	}
+
+	testCases := []struct {
+		description    string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedMsg    string
+	}{
+		{
+			description:    "ShouldMapByCodes",
+			err:            fakeErr,
+			expectedStatus: http.StatusTeapot,
+			expectedCode:   "fake.teapot",
+			expectedMsg:    fakeErr.Error(),
+		},
+		{
+			description:    "ShouldPreferCodesOverDefaultCodes",
+			err:            ErrEntityNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "fake.not_found",
+			expectedMsg:    ErrEntityNotFound.Error(),
+		},
+		{
+			description:    "ShouldMapByDefaultCodes",
+			err:            ErrAlreadyExistsEntity,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "entity.already_exists",
+			expectedMsg:    ErrAlreadyExistsEntity.Error(),
+		},
+		{
+			description:    "ShouldReturnGatewayTimeout_WhenTimeout",
+			err:            db.ErrTimeout,
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   "server.timeout",
+			expectedMsg:    db.ErrTimeout.Error(),
+		},
+		{
+			description:    "ShouldReturnServiceUnavailable_WhenCanceled",
+			err:            db.ErrCanceled,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   "server.canceled",
+			expectedMsg:    db.ErrCanceled.Error(),
+		},
+		{
+			description:    "ShouldHideMessage_WhenUnknownErr",
+			err:            errors.New("pq: connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "server.internal",
+			expectedMsg:    ErrInternalServer.Error(),
+		},
+		{
+			description:    "ShouldReturnBadRequest_WhenBindingErr",
+			err:            BindingErr(io.EOF),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "request.invalid_payload",
+			expectedMsg:    ErrInvalidRequestPayload.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			status, apiErrors := MapErr(codes, tc.err)
+
+			assert.Equal(t, tc.expectedStatus, status)
+			assert.Len(t, apiErrors, 1)
+			assert.Equal(t, tc.expectedCode, apiErrors[0].Code)
+			assert.Equal(t, tc.expectedMsg, apiErrors[0].Message)
+		})
+	}
+}
+
+func TestMapErr_ShouldReturnEntryPerField_WhenValidationFailed(t *testing.T) {
+	payload := struct {
+		UserName string `json:"username" binding:"required,min=4"`
+		Password string `json:"password" binding:"required,min=8"`
+		Nickname string `json:"nickname" binding:"max=4"`
+	}{
+		Password: "short",
+		Nickname: "too long",
+	}
+
+	err := NewStructValidator().ValidateStruct(&payload)
+	assert.Error(t, err)
+
+	status, apiErrors := MapErr(nil, BindingErr(err))
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, []APIError{
+		{
+			Code:    "validation.max",
+			Message: "Field nickname must be at most 4 characters",
+			Field:   "nickname",
+			Detail:  "max=4",
+		},
+		{
+			Code:    "validation.min",
+			Message: "Field password must be at least 8 characters",
+			Field:   "password",
+			Detail:  "min=8",
+		},
+		{
+			Code:    "validation.required",
+			Message: "Field username is required",
+			Field:   "username",
+			Detail:  "required",
+		},
+	}, apiErrors)
+}
+
+func TestMapErr_ShouldNameFieldByFormTag_WhenQueryStruct(t *testing.T) {
+	query := struct {
+		Limit int `form:"limit" binding:"max=100"`
+	}{
+		Limit: 101,
+	}
+
+	err := NewStructValidator().ValidateStruct(&query)
+	_, apiErrors := MapErr(nil, BindingErr(err))
+
+	assert.Equal(t, "limit", apiErrors[0].Field)
+	assert.Equal(t, "Field limit must be at most 100", apiErrors[0].Message)
+}
+
+func TestMapErr_ShouldReturnField_WhenTypeMismatch(t *testing.T) {
+	var payload struct {
+		UserName string `json:"username"`
+	}
+
+	err := json.Unmarshal([]byte(`{"username": 1}`), &payload)
+	_, apiErrors := MapErr(nil, BindingErr(err))
+
+	assert.Equal(t, "validation.type", apiErrors[0].Code)
+	assert.Equal(t, "username", apiErrors[0].Field)
+	assert.Equal(t, "expected string", apiErrors[0].Detail)
+}
+
+func TestWriteErrResp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
 	err := errors.New("fake error")
-	errResp := NewErrResp(err)
 
-	assert.Equal(t, errResp.Errors[0].Message, err.Error())
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request, _ = http.NewRequest("GET", "/", nil)
+	ctx.Request = ctx.Request.WithContext(
+		logging.WithRequestID(ctx.Request.Context(), "request-id"))
+
+	WriteErrResp(ctx, nil, err)
+
+	var errResp ErrorResponse
+	assert.NoError(t, json.NewDecoder(recorder.Body).Decode(&errResp))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, ErrInternalServer.Error(), errResp.Errors[0].Message)
+	assert.Equal(t, "request-id", errResp.RequestID)
+	assert.Equal(t, err, ctx.Errors.Last().Err)
 }
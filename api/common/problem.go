@@ -0,0 +1,67 @@
+package common
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MIMEProblemJSON is media type of RFC 7807 problem details.
+	MIMEProblemJSON = "application/problem+json"
+
+	// ProblemTypePrefix is prefix of problem type that is followed by error code.
+	ProblemTypePrefix = "urn:problem-type:"
+)
+
+// ProblemDetails is RFC 7807 error response
+// that is responded when client accepts application/problem+json.
+// Errors and RequestID are extension members same as ErrorResponse.
+type ProblemDetails struct {
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Status    int        `json:"status"`
+	Detail    string     `json:"detail,omitempty"`
+	Instance  string     `json:"instance,omitempty"`
+	Errors    []APIError `json:"errors"`
+	RequestID string     `json:"request_id,omitempty"`
+}
+
+// NewProblemDetails return new problem details of error response.
+func NewProblemDetails(status int, errResp ErrorResponse, instance string) ProblemDetails {
+	problem := ProblemDetails{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Instance:  instance,
+		Errors:    errResp.Errors,
+		RequestID: errResp.RequestID,
+	}
+
+	if len(errResp.Errors) == 1 {
+		problem.Type = ProblemTypePrefix + errResp.Errors[0].Code
+		problem.Detail = errResp.Errors[0].Message
+	} else if len(errResp.Errors) > 1 {
+		problem.Type = ProblemTypePrefix + "validation"
+		problem.Detail = ErrInvalidRequestPayload.Error()
+	}
+
+	return problem
+}
+
+// AcceptsProblemJSON return whether client prefers application/problem+json
+// to application/json by Accept header.
+func AcceptsProblemJSON(ctx *gin.Context) bool {
+	return ctx.NegotiateFormat(gin.MIMEJSON, MIMEProblemJSON) == MIMEProblemJSON
+}
+
+func writeErrResp(ctx *gin.Context, status int, errResp ErrorResponse) {
+	if !AcceptsProblemJSON(ctx) {
+		ctx.JSON(status, errResp)
+		return
+	}
+
+	// content type is kept by renderer when it is already set.
+	ctx.Header("Content-Type", MIMEProblemJSON)
+	ctx.JSON(status, NewProblemDetails(status, errResp, ctx.Request.URL.RequestURI()))
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteErrResp_ContentNegotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		description         string
+		accept              string
+		expectedContentType string
+	}{
+		{
+			description:         "ShouldWriteErrorResponse_WhenAcceptIsEmpty",
+			accept:              "",
+			expectedContentType: "application/json; charset=utf-8",
+		},
+		{
+			description:         "ShouldWriteErrorResponse_WhenAcceptJSON",
+			accept:              "application/json",
+			expectedContentType: "application/json; charset=utf-8",
+		},
+		{
+			description:         "ShouldWriteErrorResponse_WhenJSONIsPreferred",
+			accept:              "application/json, application/problem+json",
+			expectedContentType: "application/json; charset=utf-8",
+		},
+		{
+			description:         "ShouldWriteProblemDetails_WhenAcceptProblemJSON",
+			accept:              "application/problem+json",
+			expectedContentType: MIMEProblemJSON,
+		},
+		{
+			description:         "ShouldWriteProblemDetails_WhenProblemJSONIsPreferred",
+			accept:              "application/problem+json;q=1.0, application/json;q=0.5",
+			expectedContentType: MIMEProblemJSON,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			_, engine := gin.CreateTestContext(recorder)
+			engine.GET("/todos/:id", func(ctx *gin.Context) {
+				WriteErrResp(ctx, nil, ErrEntityNotFound)
+			})
+
+			req, _ := http.NewRequest("GET", "/todos/1?q=1", nil)
+			req.Header.Set("Accept", tc.accept)
+			engine.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusNotFound, recorder.Code)
+			assert.Equal(t, tc.expectedContentType, recorder.Header().Get("Content-Type"))
+
+			if tc.expectedContentType != MIMEProblemJSON {
+				var errResp ErrorResponse
+				assert.NoError(t, json.NewDecoder(recorder.Body).Decode(&errResp))
+				assert.Equal(t, NewErrResp(nil, ErrEntityNotFound), errResp)
+				return
+			}
+
+			var problem ProblemDetails
+			assert.NoError(t, json.NewDecoder(recorder.Body).Decode(&problem))
+			assert.Equal(t, ProblemDetails{
+				Type:     "urn:problem-type:entity.not_found",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   ErrEntityNotFound.Error(),
+				Instance: "/todos/1?q=1",
+				Errors:   NewErrResp(nil, ErrEntityNotFound).Errors,
+			}, problem)
+		})
+	}
+}
+
+func TestNewProblemDetails_ShouldSummarizeDetail_WhenMultipleErrors(t *testing.T) {
+	errResp := ErrorResponse{
+		Errors: []APIError{
+			{Code: "validation.required", Message: "Field password is required", Field: "password"},
+			{Code: "validation.min", Message: "Field username must be at least 4 characters", Field: "username"},
+		},
+		RequestID: "request-id",
+	}
+
+	problem := NewProblemDetails(http.StatusBadRequest, errResp, "/api/users/")
+
+	assert.Equal(t, "urn:problem-type:validation", problem.Type)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.Equal(t, ErrInvalidRequestPayload.Error(), problem.Detail)
+	assert.Equal(t, errResp.Errors, problem.Errors)
+	assert.Equal(t, "request-id", problem.RequestID)
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	validator "gopkg.in/go-playground/validator.v8"
+)
+
+// structValidator validate binding tags like default validator of gin
+// but name invalid fields by json tag, or form tag for query structs,
+// so error details match request that client sent.
+type structValidator struct {
+	once sync.Once
+	json *validator.Validate
+	form *validator.Validate
+}
+
+// NewStructValidator return new validator for binding.Validator.
+func NewStructValidator() binding.StructValidator {
+	return &structValidator{}
+}
+
+func (v *structValidator) ValidateStruct(obj interface{}) error {
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	v.lazyinit()
+
+	validate := v.json
+	if hasFormTag(typ) {
+		validate = v.form
+	}
+
+	if err := validate.Struct(obj); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *structValidator) Engine() interface{} {
+	v.lazyinit()
+	return v.json
+}
+
+func (v *structValidator) lazyinit() {
+	v.once.Do(func() {
+		v.json = validator.New(&validator.Config{TagName: "binding", FieldNameTag: "json"})
+		v.form = validator.New(&validator.Config{TagName: "binding", FieldNameTag: "form"})
+	})
+}
+
+func hasFormTag(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if _, ok := typ.Field(i).Tag.Lookup("form"); ok {
+			return true
+		}
+	}
+
+	return false
+}
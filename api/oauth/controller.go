@@ -0,0 +1,575 @@
+package oauth
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/api/auth"
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/middleware"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// APIPath is path prefix
+const APIPath = "/oauth/"
+
+// Controller is oauth authorization server controller
+type Controller struct {
+	conf    config.JwtConfig
+	service Service
+}
+
+// NewController return new oauth controller instance.
+func NewController(conf config.Configuration, service Service) *Controller {
+	return &Controller{
+		conf:    conf.Jwt,
+		service: service,
+	}
+}
+
+// RegisterRoutes register handler routes.
+// Tokens of clients can neither authorize nor register other clients.
+func (controller *Controller) RegisterRoutes(router *api.Router) {
+	router.Handle("POST", APIPath+"/token",
+		middleware.RateLimit(service.RateLimitToken),
+		controller.issueToken)
+
+	// introspection and revocation are found beside token issuing of auth api.
+	router.Handle("POST", auth.APIPath+"/introspect", controller.introspectToken)
+	router.Handle("POST", auth.APIPath+"/revoke", controller.revokeToken)
+
+	authorized := router.Group(APIPath,
+		middleware.AuthRequired(),
+		middleware.RateLimit(service.RateLimitUser),
+		middleware.RequireFullAccess())
+	{
+		authorized.Handle("GET", "/authorize", controller.getConsent)
+		authorized.Handle("POST", "/authorize", controller.authorize)
+		authorized.Handle("POST", "/clients", controller.createClient)
+		authorized.Handle("GET", "/clients", controller.getClients)
+		authorized.Handle("DELETE", "/clients/:id", controller.removeClient)
+	}
+
+	// userinfo is called by tokens of clients, so it requires openid scope instead of full access.
+	userInfo := router.Group(APIPath,
+		middleware.AuthRequired(),
+		middleware.RateLimit(service.RateLimitUser),
+		middleware.RequireScope(service.ScopeOpenID))
+	{
+		userInfo.Handle("GET", "/userinfo", controller.getUserInfo)
+		userInfo.Handle("POST", "/userinfo", controller.getUserInfo)
+	}
+}
+
+// @Description Register new oauth client of current user whose secret is shown only once
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param payload body oauth.CreateClientRequest true "client payload"
+// @Success 201 {object} oauth.CreatedClientResponse "ok"
+// @Failure 400 {object} common.ErrorResponse "Invalid client payload"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Tags OAuth API
+// @Router /oauth/clients [post]
+func (controller *Controller) createClient(ctx *gin.Context) {
+	var reqPayload CreateClientRequest
+
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	createdClient, secret, err := controller.service.CreateClient(ctx.Request.Context(),
+		ctx.GetInt64(middleware.UserIDKey),
+		reqPayload.Name,
+		reqPayload.RedirectURIs,
+		reqPayload.Scopes,
+		reqPayload.Confidential,
+	)
+
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	res := CreatedClientResponse{
+		ClientResponse: createdClient.Response(),
+		ClientSecret:   secret,
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// @Description Get oauth clients of current user
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {array} oauth.ClientResponse "ok"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Tags OAuth API
+// @Router /oauth/clients [get]
+func (controller *Controller) getClients(ctx *gin.Context) {
+	clients, err := controller.service.GetClientsByUserID(ctx.Request.Context(), ctx.GetInt64(middleware.UserIDKey))
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	res := make([]ClientResponse, len(clients))
+	for i, client := range clients {
+		res[i] = client.Response()
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Description Remove oauth client of current user
+// @Security ApiKeyAuth
+// @Param id path int true "Client ID"
+// @Success 204
+// @Failure 400 {object} common.ErrorResponse "Invalid client id"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Failure 404 {object} common.ErrorResponse "Not found entity"
+// @Tags OAuth API
+// @Router /oauth/clients/{id} [delete]
+func (controller *Controller) removeClient(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.ErrParsingFailed)
+		return
+	}
+
+	if err := controller.service.RemoveClient(ctx.Request.Context(),
+		ctx.GetInt64(middleware.UserIDKey),
+		id,
+	); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Description Describe authorization request of client that current user is asked to approve
+// @Description by authorization code flow with PKCE. Code is issued only by posting approval.
+// @Description Redirects to redirect uri with error once redirect uri is verified.
+// @Security ApiKeyAuth
+// @Produce json
+// @Param response_type query string true "code"
+// @Param client_id query string true "Client ID"
+// @Param redirect_uri query string false "Registered redirect uri"
+// @Param scope query string false "Space delimited scopes"
+// @Param state query string false "Opaque state of client"
+// @Param code_challenge query string true "PKCE code challenge"
+// @Param code_challenge_method query string true "S256"
+// @Param nonce query string false "Nonce echoed in id token"
+// @Success 200 {object} oauth.ConsentResponse "ok"
+// @Success 302
+// @Failure 400 {object} common.ErrorResponse "Invalid client or redirect uri"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Tags OAuth API
+// @Router /oauth/authorize [get]
+func (controller *Controller) getConsent(ctx *gin.Context) {
+	reqPayload, client, redirectURI, ok := controller.bindAuthorizeRequest(ctx)
+	if !ok {
+		return
+	}
+
+	scopes, err := controller.service.GetRequestedScopes(ctx.Request.Context(),
+		client,
+		newAuthorizationRequest(reqPayload),
+	)
+
+	if err != nil {
+		redirectAuthorization(ctx, redirectURI, reqPayload.State, "", err)
+		return
+	}
+
+	res := ConsentResponse{
+		ClientID:    client.ClientID,
+		ClientName:  client.Name,
+		RedirectURI: redirectURI,
+		Scopes:      scopes,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Description Authorize client on behalf of current user by authorization code flow with PKCE.
+// @Description Redirects to redirect uri with code and state when approved, or with error once redirect uri is verified.
+// @Security ApiKeyAuth
+// @Accept x-www-form-urlencoded
+// @Param response_type formData string true "code"
+// @Param client_id formData string true "Client ID"
+// @Param redirect_uri formData string false "Registered redirect uri"
+// @Param scope formData string false "Space delimited scopes"
+// @Param state formData string false "Opaque state of client"
+// @Param code_challenge formData string true "PKCE code challenge"
+// @Param code_challenge_method formData string true "S256"
+// @Param nonce formData string false "Nonce echoed in id token"
+// @Param approve formData bool true "Consent decision of user"
+// @Success 302
+// @Failure 400 {object} common.ErrorResponse "Invalid client or redirect uri"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Tags OAuth API
+// @Router /oauth/authorize [post]
+func (controller *Controller) authorize(ctx *gin.Context) {
+	reqPayload, client, redirectURI, ok := controller.bindAuthorizeRequest(ctx)
+	if !ok {
+		return
+	}
+
+	code, err := controller.service.Authorize(ctx.Request.Context(),
+		client,
+		ctx.GetInt64(middleware.UserIDKey),
+		newAuthorizationRequest(reqPayload),
+	)
+
+	redirectAuthorization(ctx, redirectURI, reqPayload.State, code, err)
+}
+
+// bindAuthorizeRequest bind authorization request and resolve its client and redirect uri.
+// Errors are responded instead of redirected until redirect uri is verified not to leak them.
+func (controller *Controller) bindAuthorizeRequest(ctx *gin.Context) (AuthorizeRequest, Client, string, bool) {
+	var reqPayload AuthorizeRequest
+
+	if err := ctx.ShouldBindWith(&reqPayload, binding.Form); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return AuthorizeRequest{}, EmptyClient, "", false
+	}
+
+	client, redirectURI, err := controller.service.GetRedirectURI(ctx.Request.Context(),
+		reqPayload.ClientID,
+		reqPayload.RedirectURI,
+	)
+
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return AuthorizeRequest{}, EmptyClient, "", false
+	}
+
+	return reqPayload, client, redirectURI, true
+}
+
+// newAuthorizationRequest return authorization request of query or form
+// that is approved only when user posted approval.
+func newAuthorizationRequest(req AuthorizeRequest) AuthorizationRequest {
+	return AuthorizationRequest{
+		ResponseType:        req.ResponseType,
+		RedirectURI:         req.RedirectURI,
+		Scope:               req.Scope,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Nonce:               req.Nonce,
+		Approved:            req.Approve,
+	}
+}
+
+// redirectAuthorization redirect authorization response of code or error to verified redirect uri.
+func redirectAuthorization(ctx *gin.Context, redirectURI, state, code string, err error) {
+	params := url.Values{}
+	if err != nil {
+		_, errResp := newTokenErrResp(ctx, err)
+
+		params.Set("error", errResp.Error)
+		params.Set("error_description", errResp.ErrorDescription)
+	} else {
+		params.Set("code", code)
+	}
+
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	ctx.Redirect(http.StatusFound, withQuery(redirectURI, params))
+}
+
+// @Description Issue token by authorization_code, refresh_token or client_credentials grant.
+// @Description Client authenticates by basic authorization or client_id and client_secret form values.
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "authorization_code, refresh_token or client_credentials"
+// @Param code formData string false "Authorization code"
+// @Param redirect_uri formData string false "Redirect uri of authorization request"
+// @Param code_verifier formData string false "PKCE code verifier"
+// @Param refresh_token formData string false "Refresh token"
+// @Param scope formData string false "Space delimited scopes"
+// @Param client_id formData string false "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Success 200 {object} oauth.TokenResponse "ok"
+// @Failure 400 {object} oauth.TokenErrorResponse "Invalid request or grant"
+// @Failure 401 {object} oauth.TokenErrorResponse "Invalid client"
+// @Failure 429 {object} common.ErrorResponse "Too many requests"
+// @Tags OAuth API
+// @Router /oauth/token [post]
+func (controller *Controller) issueToken(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+
+	var reqPayload TokenRequest
+
+	if err := ctx.ShouldBindWith(&reqPayload, binding.FormPost); err != nil {
+		controller.writeTokenErrResp(ctx, ErrInvalidRequest)
+		return
+	}
+
+	client, err := controller.authenticateClient(ctx, reqPayload.ClientID, reqPayload.ClientSecret)
+	if err != nil {
+		controller.writeTokenErrResp(ctx, err)
+		return
+	}
+
+	var token Token
+
+	switch reqPayload.GrantType {
+	case GrantTypeAuthorizationCode:
+		token, err = controller.service.ExchangeAuthorizationCode(ctx.Request.Context(),
+			client,
+			reqPayload.Code,
+			reqPayload.RedirectURI,
+			reqPayload.CodeVerifier,
+		)
+	case GrantTypeRefreshToken:
+		token, err = controller.service.RefreshAccessToken(ctx.Request.Context(),
+			client,
+			reqPayload.RefreshToken,
+			reqPayload.Scope,
+		)
+	case GrantTypeClientCredentials:
+		token, err = controller.service.IssueClientCredentialsToken(ctx.Request.Context(),
+			client,
+			reqPayload.Scope,
+		)
+	default:
+		err = ErrUnsupportedGrantType
+	}
+
+	if err != nil {
+		controller.writeTokenErrResp(ctx, err)
+		return
+	}
+
+	res := TokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    controller.conf.AccessExpiresInSec,
+		RefreshToken: token.RefreshToken,
+		IDToken:      token.IDToken,
+		Scope:        strings.Join(token.Scopes, " "),
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Description Introspect access or refresh token as described in RFC 7662.
+// @Description Confidential client authenticates by basic authorization or client_id and client_secret form values.
+// @Description Tokens not issued to client are responded inactive.
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Access or refresh token"
+// @Param token_type_hint formData string false "Ignored type hint of token"
+// @Param client_id formData string false "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Success 200 {object} oauth.IntrospectionResponse "ok"
+// @Failure 400 {object} oauth.TokenErrorResponse "Invalid request or public client"
+// @Failure 401 {object} oauth.TokenErrorResponse "Invalid client"
+// @Tags OAuth API
+// @Router /auth/introspect [post]
+func (controller *Controller) introspectToken(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+
+	var reqPayload IntrospectionRequest
+
+	if err := ctx.ShouldBindWith(&reqPayload, binding.FormPost); err != nil {
+		controller.writeTokenErrResp(ctx, ErrInvalidRequest)
+		return
+	}
+
+	client, err := controller.authenticateClient(ctx, reqPayload.ClientID, reqPayload.ClientSecret)
+	if err != nil {
+		controller.writeTokenErrResp(ctx, err)
+		return
+	}
+
+	introspection, err := controller.service.IntrospectToken(ctx.Request.Context(), client, reqPayload.Token)
+	if err != nil {
+		controller.writeTokenErrResp(ctx, err)
+		return
+	}
+
+	if !introspection.Active {
+		ctx.JSON(http.StatusOK, IntrospectionResponse{})
+		return
+	}
+
+	res := IntrospectionResponse{
+		Active:    true,
+		Scope:     strings.Join(introspection.Scopes, " "),
+		ClientID:  introspection.ClientID,
+		Subject:   introspection.Subject,
+		ExpiresAt: introspection.ExpiresAt,
+		IssuedAt:  introspection.IssuedAt,
+		TokenType: introspection.TokenType,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// @Description Revoke access token, or refresh token with its session as described in RFC 7009.
+// @Description Invalid or already revoked token is responded as success.
+// @Description Tokens not issued to client are rejected.
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Access or refresh token"
+// @Param token_type_hint formData string false "Ignored type hint of token"
+// @Param client_id formData string false "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Success 200
+// @Failure 400 {object} oauth.TokenErrorResponse "Invalid request or token of other client"
+// @Failure 401 {object} oauth.TokenErrorResponse "Invalid client"
+// @Tags OAuth API
+// @Router /auth/revoke [post]
+func (controller *Controller) revokeToken(ctx *gin.Context) {
+	var reqPayload RevocationRequest
+
+	if err := ctx.ShouldBindWith(&reqPayload, binding.FormPost); err != nil {
+		controller.writeTokenErrResp(ctx, ErrInvalidRequest)
+		return
+	}
+
+	client, err := controller.authenticateClient(ctx, reqPayload.ClientID, reqPayload.ClientSecret)
+	if err != nil {
+		controller.writeTokenErrResp(ctx, err)
+		return
+	}
+
+	if err := controller.service.RevokeToken(ctx.Request.Context(), client, reqPayload.Token); err != nil {
+		controller.writeTokenErrResp(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// authenticateClient authenticate client by basic authorization,
+// or by client id and secret of form when it is absent.
+func (controller *Controller) authenticateClient(ctx *gin.Context, formClientID, formClientSecret string) (Client, error) {
+	clientID, clientSecret, ok := clientCredentials(ctx)
+	if !ok {
+		clientID, clientSecret = formClientID, formClientSecret
+	}
+
+	return controller.service.AuthenticateClient(ctx.Request.Context(), clientID, clientSecret)
+}
+
+// @Description Get claims of current user described in OpenID Connect Core.
+// @Description Profile claims are responded when token is granted profile scope.
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} oauth.UserInfoResponse "ok"
+// @Failure 401 {object} common.ErrorResponse "Invalid credential"
+// @Failure 403 {object} common.ErrorResponse "Insufficient scope"
+// @Tags OAuth API
+// @Router /oauth/userinfo [get]
+func (controller *Controller) getUserInfo(ctx *gin.Context) {
+	// tokens of login are not restricted, so every claim is responded.
+	scopes := service.Scopes
+	if granted, restricted := ctx.Get(middleware.ScopesKey); restricted {
+		scopes, _ = granted.([]string)
+	}
+
+	userInfo, err := controller.service.GetUserInfo(ctx.Request.Context(), ctx.GetInt64(middleware.UserIDKey), scopes)
+	if err == common.ErrEntityNotFound {
+		// token outlived its user, so it no longer authenticates anyone.
+		common.WriteErrResp(ctx, middleware.ErrorCodes, middleware.ErrUnauthorizedToken)
+		return
+	} else if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	res := UserInfoResponse{
+		Subject:           userInfo.Subject,
+		PreferredUsername: userInfo.PreferredUsername,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (controller *Controller) writeTokenErrResp(ctx *gin.Context, err error) {
+	status, errResp := newTokenErrResp(ctx, err)
+	if status == http.StatusUnauthorized {
+		ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+
+	ctx.JSON(status, errResp)
+}
+
+// newTokenErrResp return RFC 6749 error response of err.
+// Unexpected errors are kept for access log and responded as server_error.
+func newTokenErrResp(ctx *gin.Context, err error) (int, TokenErrorResponse) {
+	status, apiErrors := common.MapErr(OAuthErrorCodes, err)
+	if status >= http.StatusInternalServerError {
+		ctx.Error(err)
+
+		return status, TokenErrorResponse{
+			Error:            "server_error",
+			ErrorDescription: apiErrors[0].Message,
+		}
+	}
+
+	return status, TokenErrorResponse{
+		Error:            apiErrors[0].Code,
+		ErrorDescription: apiErrors[0].Message,
+	}
+}
+
+// clientCredentials return client id and secret of basic authorization
+// that are form encoded as described in RFC 6749.
+func clientCredentials(ctx *gin.Context) (string, string, bool) {
+	username, password, ok := ctx.Request.BasicAuth()
+	if !ok {
+		return "", "", false
+	}
+
+	clientID, err := url.QueryUnescape(username)
+	if err != nil {
+		return "", "", false
+	}
+
+	clientSecret, err := url.QueryUnescape(password)
+	if err != nil {
+		return "", "", false
+	}
+
+	return clientID, clientSecret, true
+}
+
+// withQuery return uri whose query is added params.
+// uri was verified as registered redirect uri, so it parses.
+func withQuery(uri string, params url.Values) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	query := parsed.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String()
+}
@@ -0,0 +1,418 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/api/auth"
+	"github.com/gghcode/go-gin-starterkit/api/oauth"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/internal/testutil"
+	"github.com/gghcode/go-gin-starterkit/middleware"
+	"github.com/gghcode/go-gin-starterkit/service"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type controllerIntegration struct {
+	suite.Suite
+
+	ginEngine *gin.Engine
+	dbConn    *db.Conn
+
+	authorization string
+	testUser      user.User
+	client        oauth.CreatedClientResponse
+}
+
+func TestOAuthControllerIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	suite.Run(t, new(controllerIntegration))
+}
+
+func (suite *controllerIntegration) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	conf, err := config.NewBuilder().
+		BindEnvs("TEST").
+		Build()
+
+	dbConn, err := db.NewConn(conf)
+	require.NoError(suite.T(), err)
+
+	testutil.MigrateUp(suite.T(), dbConn)
+
+	redisConn := db.NewRedisConn(conf)
+	denylist := service.NewTokenDenylist(redisConn)
+
+	keys, err := service.NewKeyProvider(conf)
+	require.NoError(suite.T(), err)
+
+	suite.ginEngine = gin.New()
+	suite.ginEngine.Use(middleware.AddAuthHandler(keys, denylist, nil))
+	suite.dbConn = dbConn
+
+	userRepo := user.NewRepository(dbConn)
+	authService := auth.NewService(conf, userRepo, service.NewPassport(), redisConn, denylist, keys,
+		service.NewWriterNotifier(ioutil.Discard))
+
+	oauthService := oauth.NewService(conf, oauth.NewRepository(dbConn), userRepo, authService, keys, redisConn)
+	oauthController := oauth.NewController(conf, oauthService)
+	oauthController.RegisterRoutes(api.NewRouter(&suite.ginEngine.RouterGroup))
+
+	suite.testUser, err = userRepo.CreateUser(context.Background(), user.User{UserName: uuid.NewV4().String()})
+	require.NoError(suite.T(), err)
+
+	accessToken, err := authService.GenerateAccessToken(context.Background(), suite.testUser.ID, "")
+	require.NoError(suite.T(), err)
+
+	suite.authorization = "Bearer " + accessToken
+
+	actualRes := testutil.ActualResponseWithHeader(
+		suite.T(),
+		suite.ginEngine,
+		"POST",
+		oauth.APIPath+"clients",
+		testutil.ReqBodyFromInterface(suite.T(), oauth.CreateClientRequest{
+			Name:         "app",
+			RedirectURIs: []string{testRedirectURI},
+			Scopes:       []string{service.ScopeTodosRead},
+			Confidential: true,
+		}),
+		http.Header{"Authorization": {suite.authorization}},
+	)
+
+	require.Equal(suite.T(), http.StatusCreated, actualRes.StatusCode)
+	require.NoError(suite.T(), json.NewDecoder(actualRes.Body).Decode(&suite.client))
+}
+
+func (suite *controllerIntegration) TearDownSuite() {
+	suite.dbConn.Close()
+}
+
+func (suite *controllerIntegration) TestAuthorize() {
+	testCases := []struct {
+		description      string
+		query            url.Values
+		expectedStatus   int
+		expectedRedirect []string
+	}{
+		{
+			description: "ShouldRedirectWithCode",
+			query: url.Values{
+				"response_type":         {oauth.ResponseTypeCode},
+				"client_id":             {suite.client.ClientID},
+				"state":                 {"state"},
+				"code_challenge":        {testCodeChallenge},
+				"code_challenge_method": {oauth.CodeChallengeMethodS256},
+				"approve":               {"true"},
+			},
+			expectedStatus:   http.StatusFound,
+			expectedRedirect: []string{"code", "state"},
+		},
+		{
+			description: "ShouldRedirectWithError_WhenNotApproved",
+			query: url.Values{
+				"response_type":         {oauth.ResponseTypeCode},
+				"client_id":             {suite.client.ClientID},
+				"state":                 {"state"},
+				"code_challenge":        {testCodeChallenge},
+				"code_challenge_method": {oauth.CodeChallengeMethodS256},
+				"approve":               {"false"},
+			},
+			expectedStatus:   http.StatusFound,
+			expectedRedirect: []string{"error", "error_description", "state"},
+		},
+		{
+			description: "ShouldRedirectWithError_WhenNoCodeChallenge",
+			query: url.Values{
+				"response_type": {oauth.ResponseTypeCode},
+				"client_id":     {suite.client.ClientID},
+				"state":         {"state"},
+			},
+			expectedStatus:   http.StatusFound,
+			expectedRedirect: []string{"error", "error_description", "state"},
+		},
+		{
+			description: "ShouldReturnBadRequestErr_WhenNotRegisteredRedirectURI",
+			query: url.Values{
+				"response_type": {oauth.ResponseTypeCode},
+				"client_id":     {suite.client.ClientID},
+				"redirect_uri":  {"https://evil.example.com/cb"},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualRes := testutil.ActualResponseWithHeader(
+				suite.T(),
+				suite.ginEngine,
+				"POST",
+				oauth.APIPath+"authorize",
+				strings.NewReader(tc.query.Encode()),
+				http.Header{
+					"Content-Type":  {"application/x-www-form-urlencoded"},
+					"Authorization": {suite.authorization},
+				},
+			)
+
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+
+			if tc.expectedStatus == http.StatusFound {
+				location, err := url.Parse(actualRes.Header.Get("Location"))
+				suite.Require().NoError(err)
+
+				suite.True(strings.HasPrefix(location.String(), testRedirectURI))
+				for _, param := range tc.expectedRedirect {
+					suite.NotEmpty(location.Query().Get(param))
+				}
+			}
+		})
+	}
+}
+
+func (suite *controllerIntegration) TestGetConsent() {
+	query := url.Values{
+		"response_type":         {oauth.ResponseTypeCode},
+		"client_id":             {suite.client.ClientID},
+		"code_challenge":        {testCodeChallenge},
+		"code_challenge_method": {oauth.CodeChallengeMethodS256},
+		"approve":               {"true"},
+	}
+
+	actualRes := testutil.ActualResponseWithHeader(
+		suite.T(),
+		suite.ginEngine,
+		"GET",
+		oauth.APIPath+"authorize?"+query.Encode(),
+		nil,
+		http.Header{"Authorization": {suite.authorization}},
+	)
+
+	suite.Require().Equal(http.StatusOK, actualRes.StatusCode)
+
+	var consentRes oauth.ConsentResponse
+	suite.Require().NoError(json.NewDecoder(actualRes.Body).Decode(&consentRes))
+
+	expected := oauth.ConsentResponse{
+		ClientID:    suite.client.ClientID,
+		ClientName:  "app",
+		RedirectURI: testRedirectURI,
+		Scopes:      []string{service.ScopeTodosRead},
+	}
+
+	suite.Equal(expected, consentRes)
+}
+
+func (suite *controllerIntegration) TestIssueToken() {
+	testCases := []struct {
+		description    string
+		form           url.Values
+		clientSecret   string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			description: "ShouldIssueToken_WhenClientCredentials",
+			form: url.Values{
+				"grant_type": {oauth.GrantTypeClientCredentials},
+			},
+			clientSecret:   suite.client.ClientSecret,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description: "ShouldReturnInvalidClientErr_WhenWrongSecret",
+			form: url.Values{
+				"grant_type": {oauth.GrantTypeClientCredentials},
+			},
+			clientSecret:   "wrong",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid_client",
+		},
+		{
+			description: "ShouldReturnUnsupportedGrantTypeErr",
+			form: url.Values{
+				"grant_type": {"password"},
+			},
+			clientSecret:   suite.client.ClientSecret,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "unsupported_grant_type",
+		},
+		{
+			description: "ShouldReturnInvalidGrantErr_WhenUnknownCode",
+			form: url.Values{
+				"grant_type":    {oauth.GrantTypeAuthorizationCode},
+				"code":          {"unknown"},
+				"code_verifier": {testCodeVerifier},
+			},
+			clientSecret:   suite.client.ClientSecret,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_grant",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			credentials := suite.client.ClientID + ":" + tc.clientSecret
+
+			actualRes := testutil.ActualResponseWithHeader(
+				suite.T(),
+				suite.ginEngine,
+				"POST",
+				oauth.APIPath+"token",
+				strings.NewReader(tc.form.Encode()),
+				http.Header{
+					"Content-Type":  {"application/x-www-form-urlencoded"},
+					"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))},
+				},
+			)
+
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+			suite.Equal("no-store", actualRes.Header.Get("Cache-Control"))
+
+			if tc.expectedStatus == http.StatusOK {
+				var tokenRes oauth.TokenResponse
+				suite.NoError(json.NewDecoder(actualRes.Body).Decode(&tokenRes))
+
+				suite.NotEmpty(tokenRes.AccessToken)
+				suite.Equal(service.ScopeTodosRead, tokenRes.Scope)
+			} else {
+				var errRes oauth.TokenErrorResponse
+				suite.NoError(json.NewDecoder(actualRes.Body).Decode(&errRes))
+
+				suite.Equal(tc.expectedError, errRes.Error)
+			}
+		})
+	}
+}
+
+func (suite *controllerIntegration) TestIntrospectAndRevokeToken() {
+	tokenRes := suite.actualFormResponse(oauth.APIPath+"token", url.Values{
+		"grant_type": {oauth.GrantTypeClientCredentials},
+	})
+	suite.Require().Equal(http.StatusOK, tokenRes.StatusCode)
+
+	var token oauth.TokenResponse
+	suite.Require().NoError(json.NewDecoder(tokenRes.Body).Decode(&token))
+
+	introspectionRes := suite.actualFormResponse(auth.APIPath+"introspect", url.Values{
+		"token": {token.AccessToken},
+	})
+	suite.Require().Equal(http.StatusOK, introspectionRes.StatusCode)
+
+	var introspection oauth.IntrospectionResponse
+	suite.Require().NoError(json.NewDecoder(introspectionRes.Body).Decode(&introspection))
+
+	suite.True(introspection.Active)
+	suite.Equal(suite.client.ClientID, introspection.ClientID)
+	suite.Equal(service.ScopeTodosRead, introspection.Scope)
+	suite.Equal(auth.TokenTypeBearer, introspection.TokenType)
+
+	revocationRes := suite.actualFormResponse(auth.APIPath+"revoke", url.Values{
+		"token": {token.AccessToken},
+	})
+	suite.Require().Equal(http.StatusOK, revocationRes.StatusCode)
+
+	introspectionRes = suite.actualFormResponse(auth.APIPath+"introspect", url.Values{
+		"token": {token.AccessToken},
+	})
+	suite.Require().Equal(http.StatusOK, introspectionRes.StatusCode)
+
+	introspection = oauth.IntrospectionResponse{}
+	suite.Require().NoError(json.NewDecoder(introspectionRes.Body).Decode(&introspection))
+
+	suite.Equal(oauth.IntrospectionResponse{}, introspection)
+
+	// invalid tokens are responded as revoked.
+	revocationRes = suite.actualFormResponse(auth.APIPath+"revoke", url.Values{
+		"token": {"invalid_token"},
+	})
+	suite.Equal(http.StatusOK, revocationRes.StatusCode)
+}
+
+func (suite *controllerIntegration) TestGetUserInfo() {
+	tokenRes := suite.actualFormResponse(oauth.APIPath+"token", url.Values{
+		"grant_type": {oauth.GrantTypeClientCredentials},
+	})
+	suite.Require().Equal(http.StatusOK, tokenRes.StatusCode)
+
+	var token oauth.TokenResponse
+	suite.Require().NoError(json.NewDecoder(tokenRes.Body).Decode(&token))
+
+	testCases := []struct {
+		description    string
+		authorization  string
+		expectedStatus int
+		expected       oauth.UserInfoResponse
+	}{
+		{
+			description:    "ShouldReturnUserInfo_WhenLoginToken",
+			authorization:  suite.authorization,
+			expectedStatus: http.StatusOK,
+			expected: oauth.UserInfoResponse{
+				Subject:           strconv.FormatInt(suite.testUser.ID, 10),
+				PreferredUsername: suite.testUser.UserName,
+			},
+		},
+		{
+			description:    "ShouldReturnInsufficientScope_WhenNoOpenIDScope",
+			authorization:  "Bearer " + token.AccessToken,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualRes := testutil.ActualResponseWithHeader(
+				suite.T(),
+				suite.ginEngine,
+				"GET",
+				oauth.APIPath+"userinfo",
+				nil,
+				http.Header{"Authorization": {tc.authorization}},
+			)
+
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+
+			if tc.expectedStatus == http.StatusOK {
+				var actual oauth.UserInfoResponse
+				suite.NoError(json.NewDecoder(actualRes.Body).Decode(&actual))
+
+				suite.Equal(tc.expected, actual)
+			}
+		})
+	}
+}
+
+// actualFormResponse post form of client authenticated by client_id and client_secret form values.
+func (suite *controllerIntegration) actualFormResponse(path string, form url.Values) *http.Response {
+	form.Set("client_id", suite.client.ClientID)
+	form.Set("client_secret", suite.client.ClientSecret)
+
+	return testutil.ActualResponseWithHeader(
+		suite.T(),
+		suite.ginEngine,
+		"POST",
+		path,
+		strings.NewReader(form.Encode()),
+		http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+	)
+}
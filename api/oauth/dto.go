@@ -0,0 +1,120 @@
+package oauth
+
+import "time"
+
+// CreateClientRequest is request model for registering oauth client.
+// Client is public and has no secret unless Confidential is set.
+// RedirectURIs may be empty only for confidential client.
+type CreateClientRequest struct {
+	Name         string   `json:"name" example:"<client name>" binding:"required,max=100"`
+	RedirectURIs []string `json:"redirect_uris" example:"https://client.example.com/callback"`
+	Scopes       []string `json:"scopes" example:"todos:read" binding:"required,min=1"`
+	Confidential bool     `json:"confidential" example:"true"`
+}
+
+// ClientResponse is oauth client response model.
+type ClientResponse struct {
+	ID           int64     `json:"id"`
+	ClientID     string    `json:"client_id"`
+	Name         string    `json:"name"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	Scopes       []string  `json:"scopes"`
+	Confidential bool      `json:"confidential"`
+	CreatedAt    time.Time `json:"create_at"`
+}
+
+// CreatedClientResponse is response model of registered client.
+// ClientSecret is responded only once since it is stored hashed.
+type CreatedClientResponse struct {
+	ClientResponse
+
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// AuthorizeRequest is query of authorization request described in RFC 6749 and RFC 7636.
+// Approve is consent decision of user that is posted with same parameters.
+type AuthorizeRequest struct {
+	ResponseType        string `form:"response_type"`
+	ClientID            string `form:"client_id"`
+	RedirectURI         string `form:"redirect_uri"`
+	Scope               string `form:"scope"`
+	State               string `form:"state"`
+	CodeChallenge       string `form:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method"`
+	Nonce               string `form:"nonce"`
+	Approve             bool   `form:"approve"`
+}
+
+// ConsentResponse is authorization request that user is asked to approve.
+type ConsentResponse struct {
+	ClientID    string   `json:"client_id"`
+	ClientName  string   `json:"client_name"`
+	RedirectURI string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+}
+
+// TokenRequest is form of token request.
+// Client authenticates by basic authorization or by ClientID and ClientSecret.
+type TokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// TokenResponse is access token response model described in RFC 6749.
+// IDToken is responded when openid scope is granted as described in OpenID Connect Core.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope"`
+}
+
+// TokenErrorResponse is error response model of token endpoint described in RFC 6749.
+type TokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// IntrospectionRequest is form of token introspection request described in RFC 7662.
+// TokenTypeHint is accepted but ignored since type is told by token itself.
+type IntrospectionRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// IntrospectionResponse is token introspection response model described in RFC 7662.
+// Only Active is responded when token is not active.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+}
+
+// RevocationRequest is form of token revocation request described in RFC 7009.
+// TokenTypeHint is accepted but ignored since type is told by token itself.
+type RevocationRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// UserInfoResponse is userinfo response model described in OpenID Connect Core.
+type UserInfoResponse struct {
+	Subject           string `json:"sub"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+}
@@ -0,0 +1,59 @@
+package oauth
+
+import (
+	"time"
+
+	pg "github.com/lib/pq"
+)
+
+// EmptyClient is empty client model
+var EmptyClient = Client{}
+
+// Client is oauth client data model registered by user.
+// Only hash of secret is stored, and SecretHash is empty when client is public.
+// Scopes are every scope that client may request.
+type Client struct {
+	ID           int64          `gorm:"primary_key;"`
+	UserID       int64          `gorm:"not null;index;"`
+	ClientID     string         `gorm:"unique;not null;"`
+	SecretHash   string         `gorm:"not null;"`
+	Name         string         `gorm:"not null;"`
+	RedirectURIs pg.StringArray `gorm:"type:text[];not null;"`
+	Scopes       pg.StringArray `gorm:"type:text[];not null;"`
+	CreatedAt    int64          `gorm:"not null;"`
+}
+
+// TableName return name of oauth clients table.
+func (Client) TableName() string {
+	return "oauth_clients"
+}
+
+// IsConfidential return true when client authenticates by secret.
+func (client Client) IsConfidential() bool {
+	return client.SecretHash != ""
+}
+
+// HasRedirectURI return true when uri is registered by client.
+// Redirect uris are compared exactly as required by RFC 6749.
+func (client Client) HasRedirectURI(uri string) bool {
+	for _, redirectURI := range client.RedirectURIs {
+		if redirectURI == uri {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Response return new client response from client entity.
+func (client Client) Response() ClientResponse {
+	return ClientResponse{
+		ID:           client.ID,
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectURIs,
+		Scopes:       client.Scopes,
+		Confidential: client.IsConfidential(),
+		CreatedAt:    time.Unix(client.CreatedAt, 0),
+	}
+}
@@ -0,0 +1,66 @@
+package oauth
+
+import (
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/api/auth"
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidClient is occurred when client is unknown or fails to authenticate.
+	ErrInvalidClient = errors.New("Client authentication failed")
+
+	// ErrInvalidRedirectURI is occurred when redirect uri is malformed or not registered by client.
+	ErrInvalidRedirectURI = errors.New("Redirect uri is invalid")
+
+	// ErrInvalidScope is occurred when scope is unknown or exceeds scopes of client or grant.
+	ErrInvalidScope = errors.New("Scope is invalid")
+
+	// ErrInvalidRequest is occurred when request misses required parameter such as code challenge.
+	ErrInvalidRequest = errors.New("Request is invalid")
+
+	// ErrInvalidGrant is occurred when authorization code or refresh token
+	// is invalid, expired, used or issued to other client.
+	ErrInvalidGrant = errors.New("Grant is invalid")
+
+	// ErrUnauthorizedClient is occurred when client is not allowed to use grant type
+	// or to introspect or revoke token.
+	ErrUnauthorizedClient = errors.New("Client is not authorized for request")
+
+	// ErrUnsupportedGrantType is occurred when grant type is unknown.
+	ErrUnsupportedGrantType = errors.New("Grant type is not supported")
+
+	// ErrUnsupportedResponseType is occurred when response type is not code.
+	ErrUnsupportedResponseType = errors.New("Response type is not supported")
+
+	// ErrAccessDenied is occurred when user does not approve authorization request.
+	ErrAccessDenied = errors.New("User denied authorization")
+)
+
+// ErrorCodes map errors of client api into status and code of response.
+// Authorization requests whose client or redirect uri is invalid
+// are responded by them, since they can not be redirected.
+var ErrorCodes = common.ErrorCodes{
+	common.ErrEntityNotFound: {Status: http.StatusNotFound, Code: "oauth.client_not_found"},
+	ErrInvalidClient:         {Status: http.StatusBadRequest, Code: "oauth.invalid_client"},
+	ErrInvalidRedirectURI:    {Status: http.StatusBadRequest, Code: "oauth.invalid_redirect_uri"},
+	ErrInvalidScope:          {Status: http.StatusBadRequest, Code: "oauth.invalid_scope"},
+}
+
+// OAuthErrorCodes map errors of token endpoint and of redirected authorization requests
+// into status and error code defined by RFC 6749.
+var OAuthErrorCodes = common.ErrorCodes{
+	ErrInvalidRequest:           {Status: http.StatusBadRequest, Code: "invalid_request"},
+	ErrInvalidClient:            {Status: http.StatusUnauthorized, Code: "invalid_client"},
+	ErrInvalidGrant:             {Status: http.StatusBadRequest, Code: "invalid_grant"},
+	ErrUnauthorizedClient:       {Status: http.StatusBadRequest, Code: "unauthorized_client"},
+	ErrUnsupportedGrantType:     {Status: http.StatusBadRequest, Code: "unsupported_grant_type"},
+	ErrUnsupportedResponseType:  {Status: http.StatusBadRequest, Code: "unsupported_response_type"},
+	ErrInvalidScope:             {Status: http.StatusBadRequest, Code: "invalid_scope"},
+	ErrAccessDenied:             {Status: http.StatusForbidden, Code: "access_denied"},
+	common.ErrEntityNotFound:    {Status: http.StatusBadRequest, Code: "invalid_grant"},
+	auth.ErrInvalidRefreshToken: {Status: http.StatusBadRequest, Code: "invalid_grant"},
+	auth.ErrRefreshTokenReused:  {Status: http.StatusBadRequest, Code: "invalid_grant"},
+}
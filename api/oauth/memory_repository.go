@@ -0,0 +1,88 @@
+package oauth
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+)
+
+type memoryRepository struct {
+	mutex   sync.RWMutex
+	clients map[int64]Client
+	lastID  int64
+}
+
+// NewMemoryRepository return new in-memory repository
+// that behaves like postgres repository.
+func NewMemoryRepository() Repository {
+	return newTracedRepository(&memoryRepository{
+		clients: map[int64]Client{},
+	})
+}
+
+func (repo *memoryRepository) CreateClient(ctx context.Context, client Client) (Client, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, c := range repo.clients {
+		if c.ClientID == client.ClientID {
+			return EmptyClient, common.ErrAlreadyExistsEntity
+		}
+	}
+
+	repo.lastID++
+
+	client.ID = repo.lastID
+	client.CreatedAt = time.Now().Unix()
+	repo.clients[client.ID] = client
+
+	return client, nil
+}
+
+func (repo *memoryRepository) GetClientsByUserID(ctx context.Context, userID int64) ([]Client, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	clients := []Client{}
+	for _, client := range repo.clients {
+		if client.UserID == userID {
+			clients = append(clients, client)
+		}
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].ID < clients[j].ID
+	})
+
+	return clients, nil
+}
+
+func (repo *memoryRepository) GetClientByClientID(ctx context.Context, clientID string) (Client, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, client := range repo.clients {
+		if client.ClientID == clientID {
+			return client, nil
+		}
+	}
+
+	return EmptyClient, common.ErrEntityNotFound
+}
+
+func (repo *memoryRepository) RemoveClientByID(ctx context.Context, userID int64, id int64) (Client, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	client, ok := repo.clients[id]
+	if !ok || client.UserID != userID {
+		return EmptyClient, common.ErrEntityNotFound
+	}
+
+	delete(repo.clients, id)
+
+	return client, nil
+}
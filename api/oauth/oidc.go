@@ -0,0 +1,77 @@
+package oauth
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/service"
+	uuid "github.com/satori/go.uuid"
+)
+
+// maxNonceLength limits nonce that is kept with authorization code and echoed in id token.
+const maxNonceLength = 255
+
+// UserInfo is claims of user described in OpenID Connect Core.
+// Profile claims are empty unless profile scope is granted.
+type UserInfo struct {
+	Subject           string
+	PreferredUsername string
+}
+
+type idTokenClaims struct {
+	jwt.StandardClaims
+
+	Nonce             string `json:"nonce,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+}
+
+// GetUserInfo return claims of user that scopes allow.
+func (oauthService *oauthService) GetUserInfo(ctx context.Context, userID int64, scopes []string) (UserInfo, error) {
+	infoUser, err := oauthService.userRepo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	return newUserInfo(infoUser, scopes), nil
+}
+
+// generateIDToken return id token of user whose audience is client
+// and that carries nonce of authorization request.
+func (oauthService *oauthService) generateIDToken(ctx context.Context, client Client, userID int64, scopes []string, nonce string) (string, error) {
+	tokenUser, err := oauthService.userRepo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	userInfo := newUserInfo(tokenUser, scopes)
+
+	claims := &idTokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    oauthService.issuer,
+			Audience:  client.ClientID,
+			ExpiresAt: time.Now().Add(oauthService.idTokenExpiresInSec * time.Second).Unix(),
+			IssuedAt:  time.Now().Unix(),
+			Subject:   userInfo.Subject,
+			Id:        uuid.NewV4().String(),
+		},
+		Nonce:             nonce,
+		PreferredUsername: userInfo.PreferredUsername,
+	}
+
+	return oauthService.keys.Sign(claims)
+}
+
+func newUserInfo(infoUser user.User, scopes []string) UserInfo {
+	userInfo := UserInfo{
+		Subject: strconv.FormatInt(infoUser.ID, 10),
+	}
+
+	if service.HasScope(scopes, service.ScopeProfile) {
+		userInfo.PreferredUsername = infoUser.UserName
+	}
+
+	return userInfo
+}
@@ -0,0 +1,44 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+const (
+	minCodeVerifierLength = 43
+	maxCodeVerifierLength = 128
+)
+
+// isValidCodeChallenge return true when challenge is base64url encoded SHA-256 hash
+// as S256 method of RFC 7636 produces.
+func isValidCodeChallenge(challenge string) bool {
+	return len(challenge) == base64.RawURLEncoding.EncodedLen(sha256.Size) && isUnreserved(challenge)
+}
+
+// verifyCodeVerifier return true when verifier is hashed into challenge by S256 method.
+func verifyCodeVerifier(challenge, verifier string) bool {
+	if len(verifier) < minCodeVerifierLength || len(verifier) > maxCodeVerifierLength || !isUnreserved(verifier) {
+		return false
+	}
+
+	hash := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// isUnreserved return true when s consists of unreserved characters of RFC 3986.
+func isUnreserved(s string) bool {
+	for _, c := range s {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-', c == '.', c == '_', c == '~':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
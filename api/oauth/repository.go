@@ -0,0 +1,120 @@
+package oauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/jinzhu/gorm"
+	pg "github.com/lib/pq"
+)
+
+// Repository communications with db connection.
+type Repository interface {
+	CreateClient(ctx context.Context, client Client) (Client, error)
+
+	GetClientsByUserID(ctx context.Context, userID int64) ([]Client, error)
+
+	GetClientByClientID(ctx context.Context, clientID string) (Client, error)
+
+	RemoveClientByID(ctx context.Context, userID int64, id int64) (Client, error)
+}
+
+type repository struct {
+	dbConn *db.Conn
+}
+
+// NewRepository return new instance.
+func NewRepository(dbConn *db.Conn) Repository {
+	return newTracedRepository(&repository{
+		dbConn: dbConn,
+	})
+}
+
+func (repo *repository) CreateClient(ctx context.Context, client Client) (Client, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	client.CreatedAt = time.Now().Unix()
+
+	err := repo.dbConn.WithContext(ctx).
+		Create(&client).
+		Error
+
+	if pgErr, ok := err.(*pg.Error); ok && pgErr.Code == "23505" {
+		// handle duplicate client id
+		return EmptyClient, common.ErrAlreadyExistsEntity
+	} else if err != nil {
+		return EmptyClient, err
+	}
+
+	return client, nil
+}
+
+func (repo *repository) GetClientsByUserID(ctx context.Context, userID int64) ([]Client, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	clients := []Client{}
+
+	err := repo.dbConn.WithContext(ctx).
+		Where("user_id=?", userID).
+		Order("id").
+		Find(&clients).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+func (repo *repository) GetClientByClientID(ctx context.Context, clientID string) (Client, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	var client Client
+
+	err := repo.dbConn.WithContext(ctx).
+		Where("client_id=?", clientID).
+		First(&client).
+		Error
+
+	if err == gorm.ErrRecordNotFound {
+		return EmptyClient, common.ErrEntityNotFound
+	} else if err != nil {
+		return EmptyClient, err
+	}
+
+	return client, nil
+}
+
+func (repo *repository) RemoveClientByID(ctx context.Context, userID int64, id int64) (Client, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	var client Client
+
+	err := repo.dbConn.WithContext(ctx).
+		Where("id=? AND user_id=?", id, userID).
+		First(&client).
+		Error
+
+	if err == gorm.ErrRecordNotFound {
+		return EmptyClient, common.ErrEntityNotFound
+	} else if err != nil {
+		return EmptyClient, err
+	}
+
+	err = repo.dbConn.WithContext(ctx).
+		Delete(&client).
+		Error
+
+	if err != nil {
+		return EmptyClient, err
+	}
+
+	return client, nil
+}
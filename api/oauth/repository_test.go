@@ -0,0 +1,192 @@
+package oauth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/oauth"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	WillFetchedClientIdx = 0
+	WillRemovedClientIdx = 1
+	OtherUserClientIdx   = 2
+
+	TestUserID  = 1
+	OtherUserID = 2
+)
+
+// repoSuite is shared by memory and postgres tests of clients,
+// so both reject duplicated client id.
+type repoSuite struct {
+	suite.Suite
+
+	repo oauth.Repository
+
+	testClients []oauth.Client
+}
+
+func TestOAuthMemoryRepoUnit(t *testing.T) {
+	suite.Run(t, &repoSuite{repo: oauth.NewMemoryRepository()})
+}
+
+// SetupSuite seed repo with test clients.
+func (suite *repoSuite) SetupSuite() {
+	testClients, err := pushTestDataToDB(suite.repo)
+	require.NoError(suite.T(), err)
+
+	suite.testClients = testClients
+}
+
+func pushTestDataToDB(repo oauth.Repository) ([]oauth.Client, error) {
+	clients := []oauth.Client{
+		oauth.Client{UserID: TestUserID, Name: "will fetched client", SecretHash: "hash", RedirectURIs: []string{"https://fetched.example.com/cb"}, Scopes: []string{"todos:read"}},
+		oauth.Client{UserID: TestUserID, Name: "will removed client", RedirectURIs: []string{"https://removed.example.com/cb"}, Scopes: []string{"todos:write"}},
+		oauth.Client{UserID: OtherUserID, Name: "other user client", RedirectURIs: []string{"https://other.example.com/cb"}, Scopes: []string{"todos:read"}},
+	}
+
+	var result []oauth.Client
+
+	for _, client := range clients {
+		client.ClientID = uniqueClientID()
+
+		insertedClient, err := repo.CreateClient(context.Background(), client)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, insertedClient)
+	}
+
+	return result, nil
+}
+
+// uniqueClientID return client id that does not collide across test runs on same database.
+func uniqueClientID() string {
+	return uuid.NewV4().String()
+}
+
+func (suite *repoSuite) TestCreateClient() {
+	clientID := uniqueClientID()
+
+	testCases := []struct {
+		description      string
+		argsClient       oauth.Client
+		expectedClientFn func(oauth.Client) oauth.Client
+		expectedErr      error
+	}{
+		{
+			description: "ShouldCreateClient",
+			argsClient:  oauth.Client{UserID: TestUserID, ClientID: clientID, Name: "new client", RedirectURIs: []string{"https://new.example.com/cb"}, Scopes: []string{"todos:read"}},
+			expectedClientFn: func(actualClient oauth.Client) oauth.Client {
+				client := oauth.Client{UserID: TestUserID, ClientID: clientID, Name: "new client", RedirectURIs: []string{"https://new.example.com/cb"}, Scopes: []string{"todos:read"}}
+				client.ID = actualClient.ID
+				client.CreatedAt = actualClient.CreatedAt
+
+				return client
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnAlreadyExistsErr_WhenDuplicateClientID",
+			argsClient:  oauth.Client{UserID: TestUserID, ClientID: clientID, Name: "duplicate client", RedirectURIs: []string{"https://new.example.com/cb"}, Scopes: []string{"todos:read"}},
+			expectedClientFn: func(oauth.Client) oauth.Client {
+				return oauth.EmptyClient
+			},
+			expectedErr: common.ErrAlreadyExistsEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualClient, actualErr := suite.repo.CreateClient(context.Background(), tc.argsClient)
+
+			suite.Equal(tc.expectedClientFn(actualClient), actualClient)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestGetClientsByUserID() {
+	actualClients, err := suite.repo.GetClientsByUserID(context.Background(), OtherUserID)
+
+	suite.NoError(err)
+	suite.Equal([]oauth.Client{suite.testClients[OtherUserClientIdx]}, actualClients)
+}
+
+func (suite *repoSuite) TestGetClientByClientID() {
+	testCases := []struct {
+		description    string
+		argsClientID   string
+		expectedClient oauth.Client
+		expectedErr    error
+	}{
+		{
+			description:    "ShouldFetchClient",
+			argsClientID:   suite.testClients[WillFetchedClientIdx].ClientID,
+			expectedClient: suite.testClients[WillFetchedClientIdx],
+			expectedErr:    nil,
+		},
+		{
+			description:    "ShouldReturnNotFoundErr",
+			argsClientID:   "NOT_EXISTS_CLIENT_ID",
+			expectedClient: oauth.EmptyClient,
+			expectedErr:    common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualClient, actualErr := suite.repo.GetClientByClientID(context.Background(), tc.argsClientID)
+
+			suite.Equal(tc.expectedClient, actualClient)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestRemoveClientByID() {
+	testCases := []struct {
+		description    string
+		argsUserID     int64
+		argsID         int64
+		expectedClient oauth.Client
+		expectedErr    error
+	}{
+		{
+			description:    "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsUserID:     TestUserID,
+			argsID:         suite.testClients[OtherUserClientIdx].ID,
+			expectedClient: oauth.EmptyClient,
+			expectedErr:    common.ErrEntityNotFound,
+		},
+		{
+			description:    "ShouldRemoveClient",
+			argsUserID:     TestUserID,
+			argsID:         suite.testClients[WillRemovedClientIdx].ID,
+			expectedClient: suite.testClients[WillRemovedClientIdx],
+			expectedErr:    nil,
+		},
+		{
+			description:    "ShouldReturnNotFoundErr_WhenRemoved",
+			argsUserID:     TestUserID,
+			argsID:         suite.testClients[WillRemovedClientIdx].ID,
+			expectedClient: oauth.EmptyClient,
+			expectedErr:    common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualClient, actualErr := suite.repo.RemoveClientByID(context.Background(), tc.argsUserID, tc.argsID)
+
+			suite.Equal(tc.expectedClient, actualClient)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
@@ -0,0 +1,548 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/auth"
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
+)
+
+const (
+	// ResponseTypeCode is response type of authorization code flow.
+	ResponseTypeCode = "code"
+
+	// CodeChallengeMethodS256 is the only accepted PKCE method, since plain leaks verifier.
+	CodeChallengeMethodS256 = "S256"
+
+	// GrantTypeAuthorizationCode is grant type exchanging authorization code.
+	GrantTypeAuthorizationCode = "authorization_code"
+
+	// GrantTypeRefreshToken is grant type rotating refresh token.
+	GrantTypeRefreshToken = "refresh_token"
+
+	// GrantTypeClientCredentials is grant type of confidential client acting as its owner.
+	GrantTypeClientCredentials = "client_credentials"
+
+	prefixAuthorizationCode = "oauth_code"
+
+	clientIDBytes     = 16
+	clientSecretBytes = 32
+	codeBytes         = 32
+)
+
+// Service is oauth authorization server service.
+type Service interface {
+	CreateClient(ctx context.Context, userID int64, name string, redirectURIs, scopes []string, confidential bool) (Client, string, error)
+	GetClientsByUserID(ctx context.Context, userID int64) ([]Client, error)
+	RemoveClient(ctx context.Context, userID int64, id int64) error
+
+	GetRedirectURI(ctx context.Context, clientID, redirectURI string) (Client, string, error)
+	GetRequestedScopes(ctx context.Context, client Client, req AuthorizationRequest) ([]string, error)
+	Authorize(ctx context.Context, client Client, userID int64, req AuthorizationRequest) (string, error)
+
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (Client, error)
+	ExchangeAuthorizationCode(ctx context.Context, client Client, code, redirectURI, codeVerifier string) (Token, error)
+	RefreshAccessToken(ctx context.Context, client Client, refreshToken, scope string) (Token, error)
+	IssueClientCredentialsToken(ctx context.Context, client Client, scope string) (Token, error)
+
+	IntrospectToken(ctx context.Context, client Client, token string) (auth.TokenIntrospection, error)
+	RevokeToken(ctx context.Context, client Client, token string) error
+
+	GetUserInfo(ctx context.Context, userID int64, scopes []string) (UserInfo, error)
+}
+
+// AuthorizationRequest is authorization request of user to client
+// whose redirect uri was resolved by GetRedirectURI.
+// RedirectURI is as requested, so it may be empty.
+// Nonce is echoed in id token when openid scope is granted.
+// Approved is consent of user, and code is issued only when it is set.
+type AuthorizationRequest struct {
+	ResponseType        string
+	RedirectURI         string
+	Scope               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	Approved            bool
+}
+
+// Token is tokens issued to client.
+// RefreshToken is empty when client acts without user session
+// and IDToken unless user authorized openid scope.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	Scopes       []string
+}
+
+// authorizationCode is grant of user that authorization code is exchanged for.
+type authorizationCode struct {
+	ClientID      string   `json:"client_id"`
+	UserID        int64    `json:"user_id"`
+	RedirectURI   string   `json:"redirect_uri"`
+	Scopes        []string `json:"scopes"`
+	CodeChallenge string   `json:"code_challenge"`
+	Nonce         string   `json:"nonce,omitempty"`
+}
+
+type oauthService struct {
+	codeExpiresInSec    time.Duration
+	idTokenExpiresInSec time.Duration
+	issuer              string
+
+	repo        Repository
+	userRepo    user.Repository
+	authService auth.Service
+	keys        service.KeyProvider
+	redis       db.RedisConn
+}
+
+// NewService return new oauth service instance.
+func NewService(
+	conf config.Configuration,
+	repo Repository,
+	userRepo user.Repository,
+	authService auth.Service,
+	keys service.KeyProvider,
+	redisConn db.RedisConn) Service {
+
+	return &oauthService{
+		codeExpiresInSec:    time.Duration(conf.OAuth.CodeExpiresSec),
+		idTokenExpiresInSec: time.Duration(conf.Jwt.AccessExpiresInSec),
+		issuer:              conf.OAuth.Issuer,
+		repo:                repo,
+		userRepo:            userRepo,
+		authService:         authService,
+		keys:                keys,
+		redis:               redisConn,
+	}
+}
+
+// CreateClient register client of user and return it with plain secret.
+// Secret is empty when client is public.
+// Confidential client may omit redirect uris when it uses only client credentials grant.
+func (oauthService *oauthService) CreateClient(ctx context.Context, userID int64, name string, redirectURIs, scopes []string, confidential bool) (Client, string, error) {
+	if len(redirectURIs) == 0 {
+		if !confidential {
+			return EmptyClient, "", ErrInvalidRedirectURI
+		}
+
+		redirectURIs = []string{}
+	}
+
+	for _, redirectURI := range redirectURIs {
+		if !isValidRedirectURI(redirectURI) {
+			return EmptyClient, "", ErrInvalidRedirectURI
+		}
+	}
+
+	for _, scope := range scopes {
+		if !service.IsValidScope(scope) {
+			return EmptyClient, "", ErrInvalidScope
+		}
+	}
+
+	clientID, err := randomString(clientIDBytes, hex.EncodeToString)
+	if err != nil {
+		return EmptyClient, "", err
+	}
+
+	client := Client{
+		UserID:       userID,
+		ClientID:     clientID,
+		Name:         name,
+		RedirectURIs: redirectURIs,
+		Scopes:       scopes,
+	}
+
+	var secret string
+	if confidential {
+		secret, err = randomString(clientSecretBytes, base64.RawURLEncoding.EncodeToString)
+		if err != nil {
+			return EmptyClient, "", err
+		}
+
+		client.SecretHash = hashSecret(secret)
+	}
+
+	createdClient, err := oauthService.repo.CreateClient(ctx, client)
+	if err != nil {
+		return EmptyClient, "", err
+	}
+
+	return createdClient, secret, nil
+}
+
+func (oauthService *oauthService) GetClientsByUserID(ctx context.Context, userID int64) ([]Client, error) {
+	return oauthService.repo.GetClientsByUserID(ctx, userID)
+}
+
+func (oauthService *oauthService) RemoveClient(ctx context.Context, userID int64, id int64) error {
+	_, err := oauthService.repo.RemoveClientByID(ctx, userID, id)
+	return err
+}
+
+// GetRedirectURI return client and redirect uri that authorization response is sent to.
+// Redirect uri may be omitted when client registered only one.
+func (oauthService *oauthService) GetRedirectURI(ctx context.Context, clientID, redirectURI string) (Client, string, error) {
+	client, err := oauthService.repo.GetClientByClientID(ctx, clientID)
+	if err == common.ErrEntityNotFound {
+		return EmptyClient, "", ErrInvalidClient
+	} else if err != nil {
+		return EmptyClient, "", err
+	}
+
+	if redirectURI == "" && len(client.RedirectURIs) == 1 {
+		return client, client.RedirectURIs[0], nil
+	}
+
+	if !client.HasRedirectURI(redirectURI) {
+		return EmptyClient, "", ErrInvalidRedirectURI
+	}
+
+	return client, redirectURI, nil
+}
+
+// GetRequestedScopes verify authorization request
+// and return scopes that user is asked to consent to.
+func (oauthService *oauthService) GetRequestedScopes(ctx context.Context, client Client, req AuthorizationRequest) ([]string, error) {
+	if req.ResponseType != ResponseTypeCode {
+		return nil, ErrUnsupportedResponseType
+	}
+
+	if req.CodeChallengeMethod != CodeChallengeMethodS256 || !isValidCodeChallenge(req.CodeChallenge) {
+		return nil, ErrInvalidRequest
+	}
+
+	if len(req.Nonce) > maxNonceLength {
+		return nil, ErrInvalidRequest
+	}
+
+	return resolveScopes(req.Scope, client.Scopes)
+}
+
+// Authorize issue authorization code of user to client that expires shortly
+// and is bound to PKCE code challenge, once user approved request.
+func (oauthService *oauthService) Authorize(ctx context.Context, client Client, userID int64, req AuthorizationRequest) (string, error) {
+	scopes, err := oauthService.GetRequestedScopes(ctx, client, req)
+	if err != nil {
+		return "", err
+	}
+
+	if !req.Approved {
+		return "", ErrAccessDenied
+	}
+
+	code, err := randomString(codeBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return "", err
+	}
+
+	grant, err := json.Marshal(authorizationCode{
+		ClientID:      client.ClientID,
+		UserID:        userID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        scopes,
+		CodeChallenge: req.CodeChallenge,
+		Nonce:         req.Nonce,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := oauthService.redis.Set(ctx,
+		AuthorizationCodeRedisStorageKey(code),
+		string(grant),
+		oauthService.codeExpiresInSec*time.Second,
+	); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// AuthenticateClient verify secret of confidential client.
+// Public clients must not present secret.
+func (oauthService *oauthService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (Client, error) {
+	if clientID == "" {
+		return EmptyClient, ErrInvalidClient
+	}
+
+	client, err := oauthService.repo.GetClientByClientID(ctx, clientID)
+	if err == common.ErrEntityNotFound {
+		return EmptyClient, ErrInvalidClient
+	} else if err != nil {
+		return EmptyClient, err
+	}
+
+	if !client.IsConfidential() {
+		if clientSecret != "" {
+			return EmptyClient, ErrInvalidClient
+		}
+
+		return client, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(client.SecretHash), []byte(hashSecret(clientSecret))) != 1 {
+		return EmptyClient, ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+// ExchangeAuthorizationCode consume authorization code issued to client
+// and issue tokens of new session restricted to granted scopes.
+func (oauthService *oauthService) ExchangeAuthorizationCode(ctx context.Context, client Client, code, redirectURI, codeVerifier string) (Token, error) {
+	if code == "" {
+		return Token{}, ErrInvalidRequest
+	}
+
+	codeKey := AuthorizationCodeRedisStorageKey(code)
+
+	// code is swapped with empty value, so only one of concurrent exchanges gets it.
+	grantJSON, err := oauthService.redis.GetSet(ctx, codeKey, "")
+	if delErr := oauthService.redis.Del(ctx, codeKey); delErr != nil {
+		return Token{}, delErr
+	}
+
+	if err == db.ErrNil || (err == nil && grantJSON == "") {
+		return Token{}, ErrInvalidGrant
+	} else if err != nil {
+		return Token{}, err
+	}
+
+	var grant authorizationCode
+	if err := json.Unmarshal([]byte(grantJSON), &grant); err != nil {
+		return Token{}, ErrInvalidGrant
+	}
+
+	if grant.ClientID != client.ClientID ||
+		grant.RedirectURI != redirectURI ||
+		!verifyCodeVerifier(grant.CodeChallenge, codeVerifier) {
+		return Token{}, ErrInvalidGrant
+	}
+
+	clientGrant := auth.ClientGrant{
+		ClientID: client.ClientID,
+		Scopes:   grant.Scopes,
+	}
+
+	refreshToken, err := oauthService.authService.IssueClientRefreshToken(ctx, grant.UserID, clientGrant)
+	if err != nil {
+		return Token{}, err
+	}
+
+	accessToken, err := oauthService.authService.GenerateClientAccessToken(ctx,
+		grant.UserID,
+		refreshToken.SessionID,
+		clientGrant,
+	)
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	var idToken string
+	if service.HasScope(grant.Scopes, service.ScopeOpenID) {
+		idToken, err = oauthService.generateIDToken(ctx, client, grant.UserID, grant.Scopes, grant.Nonce)
+		if err != nil {
+			return Token{}, err
+		}
+	}
+
+	return Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken.Token,
+		IDToken:      idToken,
+		Scopes:       grant.Scopes,
+	}, nil
+}
+
+// RefreshAccessToken rotate refresh token issued to client.
+// Access token may be narrowed to scope, while rotated refresh token keeps every granted scope.
+// Id token without nonce is issued again when openid scope was granted.
+func (oauthService *oauthService) RefreshAccessToken(ctx context.Context, client Client, refreshToken, scope string) (Token, error) {
+	if refreshToken == "" {
+		return Token{}, ErrInvalidRequest
+	}
+
+	// scope is checked before rotation, so rejected request does not consume refresh token.
+	claims, err := oauthService.authService.ExtractTokenClaims(refreshToken)
+	if err != nil {
+		return Token{}, ErrInvalidGrant
+	}
+
+	grantedScope, _ := claims["scope"].(string)
+	grantedScopes := strings.Fields(grantedScope)
+
+	scopes, err := resolveScopes(scope, grantedScopes)
+	if err != nil {
+		return Token{}, err
+	}
+
+	rotatedToken, err := oauthService.authService.RotateClientRefreshToken(ctx, client.ClientID, refreshToken)
+	if err != nil {
+		return Token{}, err
+	}
+
+	accessToken, err := oauthService.authService.GenerateClientAccessToken(ctx,
+		rotatedToken.UserID,
+		rotatedToken.SessionID,
+		auth.ClientGrant{
+			ClientID: client.ClientID,
+			Scopes:   scopes,
+		},
+	)
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	// id token follows original grant, so narrowed scope does not drop it.
+	var idToken string
+	if service.HasScope(grantedScopes, service.ScopeOpenID) {
+		idToken, err = oauthService.generateIDToken(ctx, client, rotatedToken.UserID, grantedScopes, "")
+		if err != nil {
+			return Token{}, err
+		}
+	}
+
+	return Token{
+		AccessToken:  accessToken,
+		RefreshToken: rotatedToken.Token,
+		IDToken:      idToken,
+		Scopes:       scopes,
+	}, nil
+}
+
+// IssueClientCredentialsToken issue access token of confidential client
+// that acts as user who registered it. Refresh token is not issued.
+func (oauthService *oauthService) IssueClientCredentialsToken(ctx context.Context, client Client, scope string) (Token, error) {
+	if !client.IsConfidential() {
+		return Token{}, ErrUnauthorizedClient
+	}
+
+	scopes, err := resolveScopes(scope, client.Scopes)
+	if err != nil {
+		return Token{}, err
+	}
+
+	accessToken, err := oauthService.authService.GenerateClientAccessToken(ctx,
+		client.UserID,
+		"",
+		auth.ClientGrant{
+			ClientID: client.ClientID,
+			Scopes:   scopes,
+		},
+	)
+
+	if err != nil {
+		return Token{}, err
+	}
+
+	return Token{
+		AccessToken: accessToken,
+		Scopes:      scopes,
+	}, nil
+}
+
+// IntrospectToken return state of token issued to confidential client.
+// Tokens of other clients and first party sessions are reported inactive,
+// so registering client does not reveal claims of other users.
+func (oauthService *oauthService) IntrospectToken(ctx context.Context, client Client, token string) (auth.TokenIntrospection, error) {
+	if !client.IsConfidential() {
+		return auth.TokenIntrospection{}, ErrUnauthorizedClient
+	}
+
+	if token == "" {
+		return auth.TokenIntrospection{}, ErrInvalidRequest
+	}
+
+	introspection, err := oauthService.authService.IntrospectToken(ctx, token)
+	if err != nil || introspection.ClientID != client.ClientID {
+		return auth.TokenIntrospection{}, err
+	}
+
+	return introspection, nil
+}
+
+// RevokeToken revoke access or refresh token issued to client.
+// First party sessions are revoked by logout of auth api instead.
+func (oauthService *oauthService) RevokeToken(ctx context.Context, client Client, token string) error {
+	if token == "" {
+		return ErrInvalidRequest
+	}
+
+	introspection, err := oauthService.authService.IntrospectToken(ctx, token)
+	if err != nil || !introspection.Active {
+		return err
+	}
+
+	if introspection.ClientID != client.ClientID {
+		return ErrUnauthorizedClient
+	}
+
+	return oauthService.authService.RevokeToken(ctx, token)
+}
+
+// resolveScopes return scopes of space delimited scope that must be within allowed.
+// Every allowed scope is granted when scope is empty.
+func resolveScopes(scope string, allowed []string) ([]string, error) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+
+	for _, s := range requested {
+		if !service.HasScope(allowed, s) {
+			return nil, ErrInvalidScope
+		}
+	}
+
+	return requested, nil
+}
+
+// isValidRedirectURI return true when uri is absolute without fragment as required by RFC 6749.
+func isValidRedirectURI(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+
+	return parsed.IsAbs() && !strings.Contains(uri, "#")
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encode(b), nil
+}
+
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// AuthorizationCodeRedisStorageKey return key of grant that authorization code is exchanged for.
+func AuthorizationCodeRedisStorageKey(code string) string {
+	return fmt.Sprintf("%s_%s", prefixAuthorizationCode, code)
+}
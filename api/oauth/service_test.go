@@ -0,0 +1,773 @@
+package oauth_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gghcode/go-gin-starterkit/api/auth"
+	"github.com/gghcode/go-gin-starterkit/api/oauth"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	// testCodeVerifier is hashed into testCodeChallenge by S256 method.
+	testCodeVerifier  = "dBjftJeZ4CVP-mJ92K1qwbnR81dL6y_TSNBNzTNZq0A0000"
+	testCodeChallenge = "Yzqq1C-RJH5GKiZLPX40f1y00mTPurMuEPwrBSFP2r0"
+
+	testRedirectURI = "https://client.example.com/cb"
+
+	testIssuer = "https://auth.example.com"
+)
+
+type serviceUnit struct {
+	suite.Suite
+
+	keys               service.KeyProvider
+	authService        auth.Service
+	oauthService       oauth.Service
+	testUser           user.User
+	publicClient       oauth.Client
+	confidentialClient oauth.Client
+	clientSecret       string
+}
+
+func TestOAuthServiceUnit(t *testing.T) {
+	suite.Run(t, new(serviceUnit))
+}
+
+func (suite *serviceUnit) SetupTest() {
+	ctx := context.Background()
+
+	conf := config.Configuration{
+		Jwt: config.JwtConfig{
+			SecretKey:           "testkey",
+			AccessExpiresInSec:  300,
+			RefreshExpiresInSec: 3000,
+		},
+		OAuth: config.OAuthConfig{
+			CodeExpiresSec: 60,
+			Issuer:         testIssuer,
+		},
+	}
+
+	keys, err := service.NewKeyProvider(conf)
+	suite.Require().NoError(err)
+
+	redisConn := db.NewMemoryRedisConn()
+	userRepo := user.NewMemoryRepository()
+
+	authService := auth.NewService(conf,
+		userRepo,
+		service.NewPassport(),
+		redisConn,
+		service.NewTokenDenylist(redisConn),
+		keys,
+		service.NewWriterNotifier(nil),
+	)
+
+	suite.keys = keys
+	suite.authService = authService
+	suite.oauthService = oauth.NewService(conf, oauth.NewMemoryRepository(), userRepo, authService, keys, redisConn)
+
+	suite.testUser, err = userRepo.CreateUser(ctx, user.User{UserName: "username", Role: service.RoleUser})
+	suite.Require().NoError(err)
+
+	suite.publicClient, _, err = suite.oauthService.CreateClient(ctx,
+		suite.testUser.ID,
+		"public",
+		[]string{testRedirectURI},
+		[]string{service.ScopeTodosRead, service.ScopeTodosWrite},
+		false,
+	)
+	suite.Require().NoError(err)
+
+	suite.confidentialClient, suite.clientSecret, err = suite.oauthService.CreateClient(ctx,
+		suite.testUser.ID,
+		"confidential",
+		[]string{testRedirectURI, "https://client.example.com/other"},
+		[]string{service.ScopeTodosRead},
+		true,
+	)
+	suite.Require().NoError(err)
+}
+
+func (suite *serviceUnit) TestCreateClient() {
+	suite.False(suite.publicClient.IsConfidential())
+	suite.True(suite.confidentialClient.IsConfidential())
+	suite.NotEmpty(suite.clientSecret)
+	suite.NotContains(suite.confidentialClient.SecretHash, suite.clientSecret)
+
+	testCases := []struct {
+		description      string
+		argsRedirectURIs []string
+		argsScopes       []string
+		argsConfidential bool
+		expectedErr      error
+	}{
+		{
+			description:      "ShouldCreateConfidentialClient_WhenNoRedirectURI",
+			argsRedirectURIs: nil,
+			argsScopes:       []string{service.ScopeTodosRead},
+			argsConfidential: true,
+			expectedErr:      nil,
+		},
+		{
+			description:      "ShouldReturnInvalidRedirectURIErr_WhenPublicClientHasNoRedirectURI",
+			argsRedirectURIs: nil,
+			argsScopes:       []string{service.ScopeTodosRead},
+			expectedErr:      oauth.ErrInvalidRedirectURI,
+		},
+		{
+			description:      "ShouldReturnInvalidRedirectURIErr_WhenRelative",
+			argsRedirectURIs: []string{"/cb"},
+			argsScopes:       []string{service.ScopeTodosRead},
+			expectedErr:      oauth.ErrInvalidRedirectURI,
+		},
+		{
+			description:      "ShouldReturnInvalidRedirectURIErr_WhenFragment",
+			argsRedirectURIs: []string{testRedirectURI + "#fragment"},
+			argsScopes:       []string{service.ScopeTodosRead},
+			expectedErr:      oauth.ErrInvalidRedirectURI,
+		},
+		{
+			description:      "ShouldReturnInvalidScopeErr",
+			argsRedirectURIs: []string{testRedirectURI},
+			argsScopes:       []string{"unknown:scope"},
+			expectedErr:      oauth.ErrInvalidScope,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			_, _, actualErr := suite.oauthService.CreateClient(context.Background(),
+				suite.testUser.ID,
+				"client",
+				tc.argsRedirectURIs,
+				tc.argsScopes,
+				tc.argsConfidential,
+			)
+
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *serviceUnit) TestGetRedirectURI() {
+	testCases := []struct {
+		description         string
+		argsClientID        string
+		argsRedirectURI     string
+		expectedRedirectURI string
+		expectedErr         error
+	}{
+		{
+			description:         "ShouldReturnRegisteredRedirectURI_WhenOmitted",
+			argsClientID:        suite.publicClient.ClientID,
+			argsRedirectURI:     "",
+			expectedRedirectURI: testRedirectURI,
+		},
+		{
+			description:         "ShouldReturnRedirectURI",
+			argsClientID:        suite.confidentialClient.ClientID,
+			argsRedirectURI:     "https://client.example.com/other",
+			expectedRedirectURI: "https://client.example.com/other",
+		},
+		{
+			description:     "ShouldReturnInvalidRedirectURIErr_WhenOmittedAndMany",
+			argsClientID:    suite.confidentialClient.ClientID,
+			argsRedirectURI: "",
+			expectedErr:     oauth.ErrInvalidRedirectURI,
+		},
+		{
+			description:     "ShouldReturnInvalidRedirectURIErr_WhenNotRegistered",
+			argsClientID:    suite.publicClient.ClientID,
+			argsRedirectURI: testRedirectURI + "/evil",
+			expectedErr:     oauth.ErrInvalidRedirectURI,
+		},
+		{
+			description:     "ShouldReturnInvalidClientErr",
+			argsClientID:    "unknown",
+			argsRedirectURI: testRedirectURI,
+			expectedErr:     oauth.ErrInvalidClient,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			_, actualRedirectURI, actualErr := suite.oauthService.GetRedirectURI(context.Background(),
+				tc.argsClientID,
+				tc.argsRedirectURI,
+			)
+
+			suite.Equal(tc.expectedErr, actualErr)
+			suite.Equal(tc.expectedRedirectURI, actualRedirectURI)
+		})
+	}
+}
+
+func (suite *serviceUnit) TestAuthorize() {
+	testCases := []struct {
+		description string
+		argsReq     oauth.AuthorizationRequest
+		expectedErr error
+	}{
+		{
+			description: "ShouldIssueCode",
+			argsReq:     suite.authorizationRequest(service.ScopeTodosRead),
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnAccessDeniedErr_WhenNotApproved",
+			argsReq: oauth.AuthorizationRequest{
+				ResponseType:        oauth.ResponseTypeCode,
+				RedirectURI:         testRedirectURI,
+				CodeChallenge:       testCodeChallenge,
+				CodeChallengeMethod: oauth.CodeChallengeMethodS256,
+			},
+			expectedErr: oauth.ErrAccessDenied,
+		},
+		{
+			description: "ShouldReturnUnsupportedResponseTypeErr",
+			argsReq: oauth.AuthorizationRequest{
+				ResponseType:        "token",
+				CodeChallenge:       testCodeChallenge,
+				CodeChallengeMethod: oauth.CodeChallengeMethodS256,
+			},
+			expectedErr: oauth.ErrUnsupportedResponseType,
+		},
+		{
+			description: "ShouldReturnInvalidRequestErr_WhenNoCodeChallenge",
+			argsReq: oauth.AuthorizationRequest{
+				ResponseType: oauth.ResponseTypeCode,
+			},
+			expectedErr: oauth.ErrInvalidRequest,
+		},
+		{
+			description: "ShouldReturnInvalidRequestErr_WhenPlainMethod",
+			argsReq: oauth.AuthorizationRequest{
+				ResponseType:        oauth.ResponseTypeCode,
+				CodeChallenge:       testCodeVerifier,
+				CodeChallengeMethod: "plain",
+			},
+			expectedErr: oauth.ErrInvalidRequest,
+		},
+		{
+			description: "ShouldReturnInvalidScopeErr_WhenNotRegisteredByClient",
+			argsReq:     suite.authorizationRequest(service.ScopeUsersWrite),
+			expectedErr: oauth.ErrInvalidScope,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			code, actualErr := suite.oauthService.Authorize(context.Background(),
+				suite.publicClient,
+				suite.testUser.ID,
+				tc.argsReq,
+			)
+
+			suite.Equal(tc.expectedErr, actualErr)
+			suite.Equal(tc.expectedErr == nil, code != "")
+		})
+	}
+}
+
+func (suite *serviceUnit) TestGetRequestedScopes() {
+	req := suite.authorizationRequest("")
+	req.Approved = false
+
+	scopes, err := suite.oauthService.GetRequestedScopes(context.Background(), suite.publicClient, req)
+	suite.NoError(err)
+	suite.Equal([]string(suite.publicClient.Scopes), scopes)
+
+	_, err = suite.oauthService.GetRequestedScopes(context.Background(),
+		suite.publicClient,
+		suite.authorizationRequest(service.ScopeUsersWrite),
+	)
+	suite.Equal(oauth.ErrInvalidScope, err)
+}
+
+func (suite *serviceUnit) TestExchangeAuthorizationCode() {
+	testCases := []struct {
+		description      string
+		argsClient       func() oauth.Client
+		argsRedirectURI  string
+		argsCodeVerifier string
+		expectedErr      error
+	}{
+		{
+			description:      "ShouldIssueToken",
+			argsClient:       func() oauth.Client { return suite.publicClient },
+			argsRedirectURI:  testRedirectURI,
+			argsCodeVerifier: testCodeVerifier,
+			expectedErr:      nil,
+		},
+		{
+			description:      "ShouldReturnInvalidGrantErr_WhenWrongVerifier",
+			argsClient:       func() oauth.Client { return suite.publicClient },
+			argsRedirectURI:  testRedirectURI,
+			argsCodeVerifier: testCodeVerifier + "0",
+			expectedErr:      oauth.ErrInvalidGrant,
+		},
+		{
+			description:      "ShouldReturnInvalidGrantErr_WhenOtherRedirectURI",
+			argsClient:       func() oauth.Client { return suite.publicClient },
+			argsRedirectURI:  "",
+			argsCodeVerifier: testCodeVerifier,
+			expectedErr:      oauth.ErrInvalidGrant,
+		},
+		{
+			description:      "ShouldReturnInvalidGrantErr_WhenOtherClient",
+			argsClient:       func() oauth.Client { return suite.confidentialClient },
+			argsRedirectURI:  testRedirectURI,
+			argsCodeVerifier: testCodeVerifier,
+			expectedErr:      oauth.ErrInvalidGrant,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			ctx := context.Background()
+
+			code, err := suite.oauthService.Authorize(ctx,
+				suite.publicClient,
+				suite.testUser.ID,
+				suite.authorizationRequest(service.ScopeTodosRead),
+			)
+			suite.Require().NoError(err)
+
+			token, actualErr := suite.oauthService.ExchangeAuthorizationCode(ctx,
+				tc.argsClient(),
+				code,
+				tc.argsRedirectURI,
+				tc.argsCodeVerifier,
+			)
+
+			suite.Equal(tc.expectedErr, actualErr)
+
+			if tc.expectedErr == nil {
+				suite.Equal([]string{service.ScopeTodosRead}, token.Scopes)
+				suite.NotEmpty(token.RefreshToken)
+
+				claims := suite.claims(token.AccessToken)
+				suite.Equal(strconv.FormatInt(suite.testUser.ID, 10), claims["sub"])
+				suite.Equal(suite.publicClient.ClientID, claims["client_id"])
+				suite.Equal(service.ScopeTodosRead, claims["scope"])
+			}
+
+			// code is consumed by every exchange.
+			_, reusedErr := suite.oauthService.ExchangeAuthorizationCode(ctx,
+				suite.publicClient,
+				code,
+				testRedirectURI,
+				testCodeVerifier,
+			)
+
+			suite.Equal(oauth.ErrInvalidGrant, reusedErr)
+		})
+	}
+}
+
+func (suite *serviceUnit) TestRefreshAccessToken() {
+	ctx := context.Background()
+
+	code, err := suite.oauthService.Authorize(ctx,
+		suite.publicClient,
+		suite.testUser.ID,
+		suite.authorizationRequest(""),
+	)
+	suite.Require().NoError(err)
+
+	token, err := suite.oauthService.ExchangeAuthorizationCode(ctx, suite.publicClient, code, testRedirectURI, testCodeVerifier)
+	suite.Require().NoError(err)
+
+	_, err = suite.oauthService.RefreshAccessToken(ctx, suite.confidentialClient, token.RefreshToken, "")
+	suite.Equal(auth.ErrInvalidRefreshToken, err)
+
+	// access token is not rotated, so session is not revoked as if refresh token was reused.
+	_, err = suite.oauthService.RefreshAccessToken(ctx, suite.publicClient, token.AccessToken, "")
+	suite.Equal(auth.ErrInvalidRefreshToken, err)
+
+	_, err = suite.oauthService.RefreshAccessToken(ctx, suite.publicClient, token.RefreshToken, service.ScopeUsersWrite)
+	suite.Equal(oauth.ErrInvalidScope, err)
+
+	narrowedToken, err := suite.oauthService.RefreshAccessToken(ctx, suite.publicClient, token.RefreshToken, service.ScopeTodosRead)
+	suite.Require().NoError(err)
+	suite.Equal([]string{service.ScopeTodosRead}, narrowedToken.Scopes)
+	suite.Equal(service.ScopeTodosRead, suite.claims(narrowedToken.AccessToken)["scope"])
+
+	// rotated refresh token keeps every granted scope.
+	refreshedToken, err := suite.oauthService.RefreshAccessToken(ctx, suite.publicClient, narrowedToken.RefreshToken, "")
+	suite.Require().NoError(err)
+	suite.Equal([]string{service.ScopeTodosRead, service.ScopeTodosWrite}, refreshedToken.Scopes)
+	suite.Empty(refreshedToken.IDToken)
+
+	_, err = suite.oauthService.RefreshAccessToken(ctx, suite.publicClient, token.RefreshToken, "")
+	suite.Equal(auth.ErrRefreshTokenReused, err)
+}
+
+func (suite *serviceUnit) TestAuthenticateClient() {
+	testCases := []struct {
+		description      string
+		argsClientID     string
+		argsClientSecret string
+		expectedErr      error
+	}{
+		{
+			description:      "ShouldAuthenticateConfidentialClient",
+			argsClientID:     suite.confidentialClient.ClientID,
+			argsClientSecret: suite.clientSecret,
+			expectedErr:      nil,
+		},
+		{
+			description:      "ShouldAuthenticatePublicClient",
+			argsClientID:     suite.publicClient.ClientID,
+			argsClientSecret: "",
+			expectedErr:      nil,
+		},
+		{
+			description:      "ShouldReturnInvalidClientErr_WhenWrongSecret",
+			argsClientID:     suite.confidentialClient.ClientID,
+			argsClientSecret: "wrong",
+			expectedErr:      oauth.ErrInvalidClient,
+		},
+		{
+			description:      "ShouldReturnInvalidClientErr_WhenNoSecret",
+			argsClientID:     suite.confidentialClient.ClientID,
+			argsClientSecret: "",
+			expectedErr:      oauth.ErrInvalidClient,
+		},
+		{
+			description:      "ShouldReturnInvalidClientErr_WhenPublicClientPresentsSecret",
+			argsClientID:     suite.publicClient.ClientID,
+			argsClientSecret: "secret",
+			expectedErr:      oauth.ErrInvalidClient,
+		},
+		{
+			description:      "ShouldReturnInvalidClientErr_WhenUnknown",
+			argsClientID:     "unknown",
+			argsClientSecret: "",
+			expectedErr:      oauth.ErrInvalidClient,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			_, actualErr := suite.oauthService.AuthenticateClient(context.Background(),
+				tc.argsClientID,
+				tc.argsClientSecret,
+			)
+
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *serviceUnit) TestIssueClientCredentialsToken() {
+	ctx := context.Background()
+
+	token, err := suite.oauthService.IssueClientCredentialsToken(ctx, suite.confidentialClient, "")
+	suite.Require().NoError(err)
+
+	suite.Empty(token.RefreshToken)
+	suite.Equal([]string{service.ScopeTodosRead}, token.Scopes)
+
+	claims := suite.claims(token.AccessToken)
+	suite.Equal(strconv.FormatInt(suite.testUser.ID, 10), claims["sub"])
+	suite.Equal(suite.confidentialClient.ClientID, claims["client_id"])
+	suite.Nil(claims["sid"])
+
+	_, err = suite.oauthService.IssueClientCredentialsToken(ctx, suite.confidentialClient, service.ScopeTodosWrite)
+	suite.Equal(oauth.ErrInvalidScope, err)
+
+	_, err = suite.oauthService.IssueClientCredentialsToken(ctx, suite.publicClient, "")
+	suite.Equal(oauth.ErrUnauthorizedClient, err)
+}
+
+func (suite *serviceUnit) TestIntrospectToken() {
+	ctx := context.Background()
+
+	token, err := suite.oauthService.IssueClientCredentialsToken(ctx, suite.confidentialClient, "")
+	suite.Require().NoError(err)
+
+	introspection, err := suite.oauthService.IntrospectToken(ctx, suite.confidentialClient, token.AccessToken)
+	suite.Require().NoError(err)
+
+	suite.True(introspection.Active)
+	suite.Equal(auth.TokenTypeBearer, introspection.TokenType)
+	suite.Equal(strconv.FormatInt(suite.testUser.ID, 10), introspection.Subject)
+	suite.Equal(suite.confidentialClient.ClientID, introspection.ClientID)
+	suite.Equal([]string{service.ScopeTodosRead}, introspection.Scopes)
+
+	introspection, err = suite.oauthService.IntrospectToken(ctx, suite.confidentialClient, "invalid_token")
+	suite.Require().NoError(err)
+	suite.False(introspection.Active)
+
+	_, err = suite.oauthService.IntrospectToken(ctx, suite.confidentialClient, "")
+	suite.Equal(oauth.ErrInvalidRequest, err)
+
+	_, err = suite.oauthService.IntrospectToken(ctx, suite.publicClient, token.AccessToken)
+	suite.Equal(oauth.ErrUnauthorizedClient, err)
+}
+
+func (suite *serviceUnit) TestIntrospectToken_ShouldBeInactive_WhenTokenOfOtherClient() {
+	ctx := context.Background()
+
+	code, err := suite.oauthService.Authorize(ctx,
+		suite.publicClient,
+		suite.testUser.ID,
+		suite.authorizationRequest(""),
+	)
+	suite.Require().NoError(err)
+
+	clientToken, err := suite.oauthService.ExchangeAuthorizationCode(ctx, suite.publicClient, code, testRedirectURI, testCodeVerifier)
+	suite.Require().NoError(err)
+
+	sessionToken, err := suite.authService.IssueRefreshToken(ctx, suite.testUser.ID)
+	suite.Require().NoError(err)
+
+	for _, token := range []string{clientToken.AccessToken, clientToken.RefreshToken, sessionToken.Token} {
+		introspection, err := suite.oauthService.IntrospectToken(ctx, suite.confidentialClient, token)
+		suite.Require().NoError(err)
+		suite.Equal(auth.TokenIntrospection{}, introspection)
+	}
+}
+
+func (suite *serviceUnit) TestRevokeToken() {
+	ctx := context.Background()
+
+	code, err := suite.oauthService.Authorize(ctx,
+		suite.publicClient,
+		suite.testUser.ID,
+		suite.authorizationRequest(""),
+	)
+	suite.Require().NoError(err)
+
+	token, err := suite.oauthService.ExchangeAuthorizationCode(ctx, suite.publicClient, code, testRedirectURI, testCodeVerifier)
+	suite.Require().NoError(err)
+
+	err = suite.oauthService.RevokeToken(ctx, suite.confidentialClient, token.RefreshToken)
+	suite.Equal(oauth.ErrUnauthorizedClient, err)
+
+	err = suite.oauthService.RevokeToken(ctx, suite.publicClient, token.RefreshToken)
+	suite.Require().NoError(err)
+
+	// access tokens of session are revoked with refresh token.
+	for _, revokedToken := range []string{token.RefreshToken, token.AccessToken} {
+		introspection, err := suite.authService.IntrospectToken(ctx, revokedToken)
+		suite.Require().NoError(err)
+		suite.False(introspection.Active)
+	}
+
+	_, err = suite.oauthService.RefreshAccessToken(ctx, suite.publicClient, token.RefreshToken, "")
+	suite.Equal(auth.ErrInvalidRefreshToken, err)
+
+	err = suite.oauthService.RevokeToken(ctx, suite.publicClient, token.RefreshToken)
+	suite.NoError(err)
+
+	err = suite.oauthService.RevokeToken(ctx, suite.publicClient, "")
+	suite.Equal(oauth.ErrInvalidRequest, err)
+}
+
+func (suite *serviceUnit) TestRevokeToken_ShouldReturnUnauthorizedClientErr_WhenTokenOfOtherClient() {
+	ctx := context.Background()
+
+	clientToken, err := suite.oauthService.IssueClientCredentialsToken(ctx, suite.confidentialClient, "")
+	suite.Require().NoError(err)
+
+	sessionToken, err := suite.authService.IssueRefreshToken(ctx, suite.testUser.ID)
+	suite.Require().NoError(err)
+
+	err = suite.oauthService.RevokeToken(ctx, suite.publicClient, clientToken.AccessToken)
+	suite.Equal(oauth.ErrUnauthorizedClient, err)
+
+	for _, client := range []oauth.Client{suite.publicClient, suite.confidentialClient} {
+		err = suite.oauthService.RevokeToken(ctx, client, sessionToken.Token)
+		suite.Equal(oauth.ErrUnauthorizedClient, err)
+	}
+
+	introspection, err := suite.oauthService.IntrospectToken(ctx, suite.confidentialClient, clientToken.AccessToken)
+	suite.Require().NoError(err)
+	suite.True(introspection.Active)
+
+	_, err = suite.authService.RotateRefreshToken(ctx, sessionToken.Token)
+	suite.NoError(err)
+}
+
+func (suite *serviceUnit) TestExchangeAuthorizationCode_ShouldIssueIDToken_WhenOpenIDScope() {
+	ctx := context.Background()
+
+	oidcClient, _, err := suite.oauthService.CreateClient(ctx,
+		suite.testUser.ID,
+		"oidc",
+		[]string{testRedirectURI},
+		[]string{service.ScopeOpenID, service.ScopeProfile, service.ScopeTodosRead},
+		false,
+	)
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		description               string
+		argsScope                 string
+		argsNonce                 string
+		expectedPreferredUsername interface{}
+	}{
+		{
+			description:               "ShouldIssueIDTokenWithProfile_WhenProfileScope",
+			argsScope:                 service.ScopeOpenID + " " + service.ScopeProfile,
+			argsNonce:                 "nonce",
+			expectedPreferredUsername: suite.testUser.UserName,
+		},
+		{
+			description:               "ShouldIssueIDTokenWithoutProfile_WhenOnlyOpenIDScope",
+			argsScope:                 service.ScopeOpenID,
+			argsNonce:                 "",
+			expectedPreferredUsername: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			req := suite.authorizationRequest(tc.argsScope)
+			req.Nonce = tc.argsNonce
+
+			code, err := suite.oauthService.Authorize(ctx, oidcClient, suite.testUser.ID, req)
+			suite.Require().NoError(err)
+
+			token, err := suite.oauthService.ExchangeAuthorizationCode(ctx, oidcClient, code, testRedirectURI, testCodeVerifier)
+			suite.Require().NoError(err)
+
+			claims := suite.claims(token.IDToken)
+			suite.Equal(testIssuer, claims["iss"])
+			suite.Equal(oidcClient.ClientID, claims["aud"])
+			suite.Equal(strconv.FormatInt(suite.testUser.ID, 10), claims["sub"])
+			suite.Equal(tc.expectedPreferredUsername, claims["preferred_username"])
+			suite.NotEmpty(claims["jti"])
+
+			if tc.argsNonce == "" {
+				suite.Nil(claims["nonce"])
+			} else {
+				suite.Equal(tc.argsNonce, claims["nonce"])
+			}
+		})
+	}
+}
+
+func (suite *serviceUnit) TestExchangeAuthorizationCode_ShouldNotIssueIDToken_WhenNoOpenIDScope() {
+	ctx := context.Background()
+
+	code, err := suite.oauthService.Authorize(ctx,
+		suite.publicClient,
+		suite.testUser.ID,
+		suite.authorizationRequest(""),
+	)
+	suite.Require().NoError(err)
+
+	token, err := suite.oauthService.ExchangeAuthorizationCode(ctx, suite.publicClient, code, testRedirectURI, testCodeVerifier)
+	suite.Require().NoError(err)
+
+	suite.Empty(token.IDToken)
+}
+
+func (suite *serviceUnit) TestRefreshAccessToken_ShouldIssueIDToken_WhenOpenIDScopeGranted() {
+	ctx := context.Background()
+
+	oidcClient, _, err := suite.oauthService.CreateClient(ctx,
+		suite.testUser.ID,
+		"oidc",
+		[]string{testRedirectURI},
+		[]string{service.ScopeOpenID, service.ScopeProfile, service.ScopeTodosRead},
+		false,
+	)
+	suite.Require().NoError(err)
+
+	req := suite.authorizationRequest(service.ScopeOpenID + " " + service.ScopeProfile + " " + service.ScopeTodosRead)
+	req.Nonce = "nonce"
+
+	code, err := suite.oauthService.Authorize(ctx, oidcClient, suite.testUser.ID, req)
+	suite.Require().NoError(err)
+
+	token, err := suite.oauthService.ExchangeAuthorizationCode(ctx, oidcClient, code, testRedirectURI, testCodeVerifier)
+	suite.Require().NoError(err)
+
+	// narrowed scope does not drop id token of original grant.
+	refreshedToken, err := suite.oauthService.RefreshAccessToken(ctx, oidcClient, token.RefreshToken, service.ScopeTodosRead)
+	suite.Require().NoError(err)
+
+	claims := suite.claims(refreshedToken.IDToken)
+	suite.Equal(testIssuer, claims["iss"])
+	suite.Equal(oidcClient.ClientID, claims["aud"])
+	suite.Equal(strconv.FormatInt(suite.testUser.ID, 10), claims["sub"])
+	suite.Equal(suite.testUser.UserName, claims["preferred_username"])
+	suite.Nil(claims["nonce"])
+	suite.NotEqual(suite.claims(token.IDToken)["jti"], claims["jti"])
+}
+
+func (suite *serviceUnit) TestAuthorize_ShouldReturnInvalidRequestErr_WhenNonceIsTooLong() {
+	req := suite.authorizationRequest("")
+	req.Nonce = strings.Repeat("n", 256)
+
+	_, err := suite.oauthService.Authorize(context.Background(), suite.publicClient, suite.testUser.ID, req)
+
+	suite.Equal(oauth.ErrInvalidRequest, err)
+}
+
+func (suite *serviceUnit) TestGetUserInfo() {
+	testCases := []struct {
+		description string
+		argsScopes  []string
+		expected    oauth.UserInfo
+	}{
+		{
+			description: "ShouldReturnProfile_WhenProfileScope",
+			argsScopes:  []string{service.ScopeOpenID, service.ScopeProfile},
+			expected: oauth.UserInfo{
+				Subject:           strconv.FormatInt(suite.testUser.ID, 10),
+				PreferredUsername: suite.testUser.UserName,
+			},
+		},
+		{
+			description: "ShouldReturnOnlySubject_WhenNoProfileScope",
+			argsScopes:  []string{service.ScopeOpenID},
+			expected: oauth.UserInfo{
+				Subject: strconv.FormatInt(suite.testUser.ID, 10),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actual, err := suite.oauthService.GetUserInfo(context.Background(), suite.testUser.ID, tc.argsScopes)
+
+			suite.NoError(err)
+			suite.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func (suite *serviceUnit) authorizationRequest(scope string) oauth.AuthorizationRequest {
+	return oauth.AuthorizationRequest{
+		ResponseType:        oauth.ResponseTypeCode,
+		RedirectURI:         testRedirectURI,
+		Scope:               scope,
+		CodeChallenge:       testCodeChallenge,
+		CodeChallengeMethod: oauth.CodeChallengeMethodS256,
+		Approved:            true,
+	}
+}
+
+func (suite *serviceUnit) claims(token string) jwt.MapClaims {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(token, &claims, suite.keys.Keyfunc)
+	suite.Require().NoError(err)
+
+	return claims
+}
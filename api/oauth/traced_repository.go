@@ -0,0 +1,57 @@
+package oauth
+
+import (
+	"context"
+
+	"github.com/gghcode/go-gin-starterkit/tracing"
+)
+
+// tracedRepository record span of each client query
+// that authorizing and issuing tokens make.
+type tracedRepository struct {
+	next Repository
+}
+
+func newTracedRepository(next Repository) Repository {
+	return &tracedRepository{next: next}
+}
+
+func (repo *tracedRepository) CreateClient(ctx context.Context, client Client) (Client, error) {
+	var result Client
+	err := tracing.Trace(ctx, "oauth.Repository/CreateClient", func(ctx context.Context) (err error) {
+		result, err = repo.next.CreateClient(ctx, client)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetClientsByUserID(ctx context.Context, userID int64) ([]Client, error) {
+	var result []Client
+	err := tracing.Trace(ctx, "oauth.Repository/GetClientsByUserID", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetClientsByUserID(ctx, userID)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetClientByClientID(ctx context.Context, clientID string) (Client, error) {
+	var result Client
+	err := tracing.Trace(ctx, "oauth.Repository/GetClientByClientID", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetClientByClientID(ctx, clientID)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) RemoveClientByID(ctx context.Context, userID int64, id int64) (Client, error) {
+	var result Client
+	err := tracing.Trace(ctx, "oauth.Repository/RemoveClientByID", func(ctx context.Context) (err error) {
+		result, err = repo.next.RemoveClientByID(ctx, userID, id)
+		return err
+	})
+
+	return result, err
+}
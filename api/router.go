@@ -0,0 +1,53 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+// RouteKey is key of registered path of route that request matched.
+const RouteKey = "route"
+
+// Router register routes under route group and records registered path of each route,
+// so logs, metrics and traces are labeled by it instead of requested path.
+type Router struct {
+	group    *gin.RouterGroup
+	handlers []gin.HandlerFunc
+}
+
+// NewRouter return new router of group.
+func NewRouter(group *gin.RouterGroup) *Router {
+	return &Router{group: group}
+}
+
+// Group return router of relative path whose routes run handlers first.
+func (router *Router) Group(relativePath string, handlers ...gin.HandlerFunc) *Router {
+	return &Router{
+		group:    router.group.Group(relativePath),
+		handlers: router.combineHandlers(handlers),
+	}
+}
+
+// Use add handlers that routes registered afterwards run first.
+func (router *Router) Use(handlers ...gin.HandlerFunc) *Router {
+	router.handlers = router.combineHandlers(handlers)
+	return router
+}
+
+// Handle register handlers of route.
+// Registered path is recorded before any handler runs, so it is known even when they abort.
+func (router *Router) Handle(method, relativePath string, handlers ...gin.HandlerFunc) {
+	route := router.group.Group(relativePath).BasePath()
+
+	recordRoute := func(ctx *gin.Context) {
+		ctx.Set(RouteKey, route)
+		ctx.Next()
+	}
+
+	router.group.Handle(method, relativePath,
+		append([]gin.HandlerFunc{recordRoute}, router.combineHandlers(handlers)...)...)
+}
+
+func (router *Router) combineHandlers(handlers []gin.HandlerFunc) []gin.HandlerFunc {
+	combined := make([]gin.HandlerFunc, 0, len(router.handlers)+len(handlers))
+	combined = append(combined, router.handlers...)
+
+	return append(combined, handlers...)
+}
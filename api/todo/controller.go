@@ -3,8 +3,10 @@ package todo
 import (
 	"net/http"
 
+	"github.com/gghcode/go-gin-starterkit/api"
 	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/middleware"
+	"github.com/gghcode/go-gin-starterkit/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,22 +26,34 @@ func NewController(repo Repository) *Controller {
 }
 
 // RegisterRoutes register handler routes.
-func (controller Controller) RegisterRoutes(router gin.IRouter) {
+func (controller Controller) RegisterRoutes(router *api.Router) {
 	todoRouter := router.Group(APIPath)
 	{
-		todoRouter.Handle("GET", "/", controller.getAllTodos)
-		todoRouter.Handle("POST", "/", controller.createTodo)
-
-		authorized := todoRouter.Use(middleware.AuthRequired())
+		authorized := todoRouter.Use(
+			middleware.AuthRequired(),
+			middleware.RateLimit(service.RateLimitUser))
 		{
-			authorized.Handle("GET", "/:id", controller.getTodoByTodoID)
-			authorized.Handle("PUT", "/:id", controller.updateTodoByTodoID)
-			authorized.Handle("DELETE", "/:id", controller.removeTodoByTodoID)
+			authorized.Handle("GET", "/",
+				middleware.RequireScope(service.ScopeTodosRead),
+				controller.getAllTodos)
+			authorized.Handle("POST", "/",
+				middleware.RequireScope(service.ScopeTodosWrite),
+				controller.createTodo)
+			authorized.Handle("GET", "/:id",
+				middleware.RequireScope(service.ScopeTodosRead),
+				controller.getTodoByTodoID)
+			authorized.Handle("PUT", "/:id",
+				middleware.RequireScope(service.ScopeTodosWrite),
+				controller.updateTodoByTodoID)
+			authorized.Handle("DELETE", "/:id",
+				middleware.RequireScope(service.ScopeTodosWrite),
+				controller.removeTodoByTodoID)
 		}
 	}
 }
 
 // @Description Create new todo
+// @Security ApiKeyAuth
 // @Accept json
 // @Produce json
 // @Param payload body todo.CreateTodoRequest true "todo payload"
@@ -51,38 +65,59 @@ func (controller *Controller) createTodo(ctx *gin.Context) {
 	var dtoReq CreateTodoRequest
 
 	if err := ctx.ShouldBindJSON(&dtoReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
 		return
 	}
 
 	todoEntity := Todo{
+		UserID:   ctx.GetInt64(middleware.UserIDKey),
 		Title:    dtoReq.Title,
 		Contents: dtoReq.Contents,
 	}
 
-	createdTodo, err := controller.repo.CreateTodo(todoEntity)
+	createdTodo, err := controller.repo.CreateTodo(ctx.Request.Context(), todoEntity)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, createdTodo.TodoResponse())
 }
 
-// @Description Get all todos
+// @Description Get page of todos of current user
+// @Security ApiKeyAuth
 // @Accept json
 // @Produce json
-// @Success 200 {array} todo.TodoResponse "ok"
+// @Param limit query int false "Page size (1~100)"
+// @Param sort query string false "Sort option (created_at, -created_at, title, -title)"
+// @Param cursor query string false "Next cursor of previous page"
+// @Param q query string false "Keyword of title or contents"
+// @Success 200 {object} todo.TodoListResponse "ok"
+// @Failure 400 {object} common.ErrorResponse "Invalid query"
 // @Tags Todo API
 // @Router /todos [get]
 func (controller *Controller) getAllTodos(ctx *gin.Context) {
-	todos, err := controller.repo.GetTodos()
+	userID := ctx.GetInt64(middleware.UserIDKey)
+
+	var dtoReq ListTodosRequest
+	if err := ctx.ShouldBindQuery(&dtoReq); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	query, err := NewQuery(dtoReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, todos)
+	page, err := controller.repo.GetTodosByUserID(ctx.Request.Context(), userID, query)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page.Response())
 }
 
 // @Description Get todo by todo id
@@ -94,14 +129,12 @@ func (controller *Controller) getAllTodos(ctx *gin.Context) {
 // @Tags Todo API
 // @Router /todos/{id} [get]
 func (controller *Controller) getTodoByTodoID(ctx *gin.Context) {
+	userID := ctx.GetInt64(middleware.UserIDKey)
 	todoID := ctx.Param("id")
 
-	bindTodo, err := controller.repo.GetTodoByTodoID(todoID)
-	if err == common.ErrEntityNotFound {
-		ctx.JSON(http.StatusNotFound, common.NewErrResp(err))
-		return
-	} else if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+	bindTodo, err := controller.repo.GetTodoByTodoID(ctx.Request.Context(), userID, todoID)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
@@ -119,11 +152,12 @@ func (controller *Controller) getTodoByTodoID(ctx *gin.Context) {
 // @Tags Todo API
 // @Router /todos/{id} [put]
 func (controller *Controller) updateTodoByTodoID(ctx *gin.Context) {
+	userID := ctx.GetInt64(middleware.UserIDKey)
 	todoID := ctx.Param("id")
 
 	var dtoReq CreateTodoRequest
 	if err := ctx.ShouldBindJSON(&dtoReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
 		return
 	}
 
@@ -132,12 +166,9 @@ func (controller *Controller) updateTodoByTodoID(ctx *gin.Context) {
 		Contents: dtoReq.Contents,
 	}
 
-	todo, err := controller.repo.UpdateTodoByTodoID(todoID, todoEntity)
-	if err == common.ErrEntityNotFound {
-		ctx.JSON(http.StatusNotFound, common.NewErrResp(err))
-		return
-	} else if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+	todo, err := controller.repo.UpdateTodoByTodoID(ctx.Request.Context(), userID, todoID, todoEntity)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
@@ -153,14 +184,12 @@ func (controller *Controller) updateTodoByTodoID(ctx *gin.Context) {
 // @Tags Todo API
 // @Router /todos/{id} [delete]
 func (controller *Controller) removeTodoByTodoID(ctx *gin.Context) {
+	userID := ctx.GetInt64(middleware.UserIDKey)
 	todoID := ctx.Param("id")
 
-	removedTodo, err := controller.repo.RemoveTodoByTodoID(todoID)
-	if err == common.ErrEntityNotFound {
-		ctx.JSON(http.StatusNotFound, common.NewErrResp(err))
-		return
-	} else if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+	removedTodo, err := controller.repo.RemoveTodoByTodoID(ctx.Request.Context(), userID, todoID)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/gghcode/go-gin-starterkit/api"
 	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/api/todo"
 	"github.com/gghcode/go-gin-starterkit/config"
@@ -47,9 +48,13 @@ func (suite *controllerIntegration) SetupSuite() {
 	dbConn, err := db.NewConn(conf)
 	require.NoError(suite.T(), err)
 
+	testutil.MigrateUp(suite.T(), dbConn)
+
 	suite.ginEngine = gin.New()
 	suite.ginEngine.Use(func(ctx *gin.Context) {
-		var innerHandler gin.HandlerFunc = func(ctx *gin.Context) {}
+		var innerHandler gin.HandlerFunc = func(ctx *gin.Context) {
+			ctx.Set(middleware.UserIDKey, int64(TestUserID))
+		}
 
 		ctx.Set(middleware.VerifyHandlerKey, innerHandler)
 		ctx.Next()
@@ -59,7 +64,7 @@ func (suite *controllerIntegration) SetupSuite() {
 
 	todoRepo := todo.NewRepository(dbConn)
 	todoController := todo.NewController(todoRepo)
-	todoController.RegisterRoutes(suite.ginEngine)
+	todoController.RegisterRoutes(api.NewRouter(&suite.ginEngine.RouterGroup))
 
 	suite.testTodos, err = pushTestDataToDB(todoRepo)
 	require.NoError(suite.T(), err)
@@ -72,12 +77,34 @@ func (suite *controllerIntegration) TearDownSuite() {
 func (suite *controllerIntegration) TestGetAllTodos() {
 	testCases := []struct {
 		description    string
+		argsQuery      string
 		expectedStatus int
 	}{
 		{
 			description:    "ShouldFetchTodos",
+			argsQuery:      "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldFetchTodos_WhenPaged",
+			argsQuery:      "?limit=1&sort=-title&q=todo",
 			expectedStatus: http.StatusOK,
 		},
+		{
+			description:    "ShouldReturnBadRequestErr_WhenInvalidLimit",
+			argsQuery:      "?limit=1000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "ShouldReturnBadRequestErr_WhenInvalidSort",
+			argsQuery:      "?sort=contents",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "ShouldReturnBadRequestErr_WhenInvalidCursor",
+			argsQuery:      "?cursor=invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -86,7 +113,7 @@ func (suite *controllerIntegration) TestGetAllTodos() {
 				suite.T(),
 				suite.ginEngine,
 				"GET",
-				todo.APIPath,
+				todo.APIPath+tc.argsQuery,
 				nil)
 
 			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
@@ -98,6 +125,36 @@ func (suite *controllerIntegration) TestGetAllTodos() {
 	}
 }
 
+func (suite *controllerIntegration) TestGetAllTodos_Paging() {
+	var fetchedTodos []todo.TodoResponse
+
+	nextCursor := ""
+	for {
+		actualRes := testutil.ActualResponse(
+			suite.T(),
+			suite.ginEngine,
+			"GET",
+			todo.APIPath+"?limit=1&cursor="+nextCursor,
+			nil)
+		suite.Require().Equal(http.StatusOK, actualRes.StatusCode)
+
+		var page todo.TodoListResponse
+		err := json.NewDecoder(actualRes.Body).Decode(&page)
+		suite.Require().NoError(err)
+
+		fetchedTodos = append(fetchedTodos, page.Items...)
+		if page.NextCursor == "" {
+			break
+		}
+
+		nextCursor = page.NextCursor
+	}
+
+	for _, fetchedTodo := range fetchedTodos {
+		suite.NotEqual(suite.testTodos[OtherUserTodoIdx].ID, fetchedTodo.ID)
+	}
+}
+
 func (suite *controllerIntegration) TestGetTodoByID() {
 	testCases := []struct {
 		description    string
@@ -117,7 +174,14 @@ func (suite *controllerIntegration) TestGetTodoByID() {
 			argsTodoID:     uuid.Nil.String(),
 			expectedStatus: http.StatusNotFound,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrEntityNotFound)),
+				common.NewErrResp(todo.ErrorCodes, common.ErrEntityNotFound)),
+		},
+		{
+			description:    "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsTodoID:     suite.testTodos[OtherUserTodoIdx].ID.String(),
+			expectedStatus: http.StatusNotFound,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(todo.ErrorCodes, common.ErrEntityNotFound)),
 		},
 	}
 
@@ -229,7 +293,7 @@ func (suite *controllerIntegration) TestUpdateTodoByID() {
 			}),
 			expectedStatus: http.StatusNotFound,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrEntityNotFound)),
+				common.NewErrResp(todo.ErrorCodes, common.ErrEntityNotFound)),
 		},
 	}
 
@@ -271,7 +335,14 @@ func (suite *controllerIntegration) TestRemoveTodoByID() {
 			argsTodoID:     todo.EmptyTodo.ID.String(),
 			expectedStatus: http.StatusNotFound,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrEntityNotFound)),
+				common.NewErrResp(todo.ErrorCodes, common.ErrEntityNotFound)),
+		},
+		{
+			description:    "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsTodoID:     suite.testTodos[OtherUserTodoIdx].ID.String(),
+			expectedStatus: http.StatusNotFound,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(todo.ErrorCodes, common.ErrEntityNotFound)),
 		},
 	}
 
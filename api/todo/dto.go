@@ -12,6 +12,20 @@ type CreateTodoRequest struct {
 	Contents string `json:"contents" example:"<new contents>" binding:"required,min=2,max=2048"`
 }
 
+// ListTodosRequest is query model for listing todos.
+type ListTodosRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort   string `form:"sort"`
+	Cursor string `form:"cursor"`
+	Q      string `form:"q" binding:"max=100"`
+}
+
+// TodoListResponse is paged todo response model.
+type TodoListResponse struct {
+	Items      []TodoResponse `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
 // TodoResponse is todo response model.
 type TodoResponse struct {
 	ID        uuid.UUID `json:"id"`
@@ -12,6 +12,7 @@ var EmptyTodo = Todo{}
 // Todo is todo data model.
 type Todo struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	UserID    int64     `gorm:"not null;index;"`
 	Title     string
 	Contents  string
 	CreatedAt int64
@@ -0,0 +1,23 @@
+package todo
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+)
+
+var (
+	// ErrInvalidCursor is occurred when cursor token is malformed.
+	ErrInvalidCursor = errors.New("Invalid cursor")
+
+	// ErrInvalidSort is occurred when sort option is not supported.
+	ErrInvalidSort = errors.New("Invalid sort option")
+)
+
+// ErrorCodes map errors of todo api into status and code of response.
+var ErrorCodes = common.ErrorCodes{
+	common.ErrEntityNotFound: {Status: http.StatusNotFound, Code: "todo.not_found"},
+	ErrInvalidCursor:         {Status: http.StatusBadRequest, Code: "todo.invalid_cursor"},
+	ErrInvalidSort:           {Status: http.StatusBadRequest, Code: "todo.invalid_sort"},
+}
@@ -0,0 +1,175 @@
+package todo
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+type memoryRepository struct {
+	mutex sync.RWMutex
+	todos map[string]Todo
+}
+
+// NewMemoryRepository return new in-memory repository
+// that behaves like postgres repository.
+// Titles are ordered by bytes instead of database collation.
+func NewMemoryRepository() Repository {
+	return newTracedRepository(&memoryRepository{
+		todos: map[string]Todo{},
+	})
+}
+
+func (repo *memoryRepository) GetTodosByUserID(ctx context.Context, userID int64, query Query) (Page, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	keyword := strings.ToLower(query.Keyword)
+
+	var todos []Todo
+	for _, todo := range repo.todos {
+		if todo.UserID != userID {
+			continue
+		}
+
+		if keyword != "" &&
+			!strings.Contains(strings.ToLower(todo.Title), keyword) &&
+			!strings.Contains(strings.ToLower(todo.Contents), keyword) {
+			continue
+		}
+
+		if query.After != nil && !isAfterCursor(query, todo) {
+			continue
+		}
+
+		todos = append(todos, todo)
+	}
+
+	sort.Slice(todos, func(i, j int) bool {
+		return compareTodos(query.SortBy, todos[i], todos[j]) < 0 != query.Desc
+	})
+
+	if len(todos) > query.Limit+1 {
+		todos = todos[:query.Limit+1]
+	}
+
+	return newPage(query, todos), nil
+}
+
+func (repo *memoryRepository) GetTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	todo, ok := repo.todos[todoID]
+	if !ok || todo.UserID != userID {
+		return EmptyTodo, common.ErrEntityNotFound
+	}
+
+	return todo, nil
+}
+
+func (repo *memoryRepository) CreateTodo(ctx context.Context, todo Todo) (Todo, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	todo.ID = uuid.NewV4()
+	todo.CreatedAt = time.Now().Unix()
+	repo.todos[todo.ID.String()] = todo
+
+	return todo, nil
+}
+
+// UpdateTodoByTodoID update only non-zero fields of todo like gorm does.
+func (repo *memoryRepository) UpdateTodoByTodoID(ctx context.Context, userID int64, todoID string, todo Todo) (Todo, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	fetchedTodo, ok := repo.todos[todoID]
+	if !ok || fetchedTodo.UserID != userID {
+		return EmptyTodo, common.ErrEntityNotFound
+	}
+
+	if todo.UserID != 0 {
+		fetchedTodo.UserID = todo.UserID
+	}
+
+	if todo.Title != "" {
+		fetchedTodo.Title = todo.Title
+	}
+
+	if todo.Contents != "" {
+		fetchedTodo.Contents = todo.Contents
+	}
+
+	if todo.CreatedAt != 0 {
+		fetchedTodo.CreatedAt = todo.CreatedAt
+	}
+
+	repo.todos[todoID] = fetchedTodo
+
+	return fetchedTodo, nil
+}
+
+func (repo *memoryRepository) RemoveTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	todo, ok := repo.todos[todoID]
+	if !ok || todo.UserID != userID {
+		return EmptyTodo, common.ErrEntityNotFound
+	}
+
+	delete(repo.todos, todoID)
+
+	return todo, nil
+}
+
+// compareTodos compare todos by (sortBy, id) like keyset of postgres repository.
+func compareTodos(sortBy string, a, b Todo) int {
+	var result int
+
+	switch sortBy {
+	case SortByTitle:
+		result = strings.Compare(a.Title, b.Title)
+	default:
+		result = compareInt64(a.CreatedAt, b.CreatedAt)
+	}
+
+	if result != 0 {
+		return result
+	}
+
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+func isAfterCursor(query Query, todo Todo) bool {
+	cursor := query.After
+
+	result := compareTodos(query.SortBy, todo, Todo{
+		ID:        cursor.ID,
+		Title:     cursor.Title,
+		CreatedAt: cursor.CreatedAt,
+	})
+
+	if query.Desc {
+		return result < 0
+	}
+
+	return result > 0
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,148 @@
+package todo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// DefaultLimit is page size when limit was not specified.
+	DefaultLimit = 20
+
+	// MaxLimit is the largest page size.
+	MaxLimit = 100
+
+	// SortByCreatedAt sort todos by creation time.
+	SortByCreatedAt = "created_at"
+
+	// SortByTitle sort todos by title.
+	SortByTitle = "title"
+)
+
+// Query is options to fetch page of todos.
+type Query struct {
+	Limit   int
+	SortBy  string
+	Desc    bool
+	Keyword string
+	After   *Cursor
+}
+
+// Page is fetched todos with position of next page.
+type Page struct {
+	Todos []Todo
+	Next  *Cursor
+}
+
+// Cursor points last todo of fetched page.
+type Cursor struct {
+	Sort      string    `json:"s"`
+	CreatedAt int64     `json:"c,omitempty"`
+	Title     string    `json:"t,omitempty"`
+	ID        uuid.UUID `json:"i"`
+}
+
+// NewQuery return new query from request.
+func NewQuery(req ListTodosRequest) (Query, error) {
+	query := Query{
+		Limit:   req.Limit,
+		SortBy:  SortByCreatedAt,
+		Desc:    true,
+		Keyword: req.Q,
+	}
+
+	if query.Limit == 0 {
+		query.Limit = DefaultLimit
+	}
+
+	if req.Sort != "" {
+		query.Desc = strings.HasPrefix(req.Sort, "-")
+		query.SortBy = strings.TrimPrefix(req.Sort, "-")
+	}
+
+	if query.SortBy != SortByCreatedAt && query.SortBy != SortByTitle {
+		return Query{}, ErrInvalidSort
+	}
+
+	if req.Cursor != "" {
+		cursor, err := DecodeCursor(req.Cursor)
+		if err != nil {
+			return Query{}, err
+		}
+
+		if cursor.Sort != query.sortKey() {
+			return Query{}, ErrInvalidCursor
+		}
+
+		query.After = &cursor
+	}
+
+	return query, nil
+}
+
+// NewCursor return cursor that points todo.
+func (query Query) NewCursor(todo Todo) *Cursor {
+	cursor := Cursor{
+		Sort: query.sortKey(),
+		ID:   todo.ID,
+	}
+
+	if query.SortBy == SortByTitle {
+		cursor.Title = todo.Title
+	} else {
+		cursor.CreatedAt = todo.CreatedAt
+	}
+
+	return &cursor
+}
+
+func (query Query) sortKey() string {
+	if query.Desc {
+		return "-" + query.SortBy
+	}
+
+	return query.SortBy
+}
+
+// Response return instance of TodoListResponse by Page.
+func (page Page) Response() TodoListResponse {
+	res := TodoListResponse{
+		Items: make([]TodoResponse, 0, len(page.Todos)),
+	}
+
+	for _, todo := range page.Todos {
+		res.Items = append(res.Items, todo.TodoResponse())
+	}
+
+	if page.Next != nil {
+		res.NextCursor = page.Next.Encode()
+	}
+
+	return res
+}
+
+// Encode return opaque token of cursor.
+func (cursor Cursor) Encode() string {
+	bytes, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// DecodeCursor return cursor from opaque token.
+func DecodeCursor(token string) (Cursor, error) {
+	var cursor Cursor
+
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,96 @@
+package todo
+
+import (
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type queryUnit struct {
+	suite.Suite
+}
+
+func TestTodoQueryUnit(t *testing.T) {
+	suite.Run(t, new(queryUnit))
+}
+
+func (suite *queryUnit) TestNewQuery() {
+	cursor := Cursor{Sort: "title", Title: "title", ID: uuid.NewV4()}
+
+	testCases := []struct {
+		description   string
+		argsReq       ListTodosRequest
+		expectedQuery Query
+		expectedErr   error
+	}{
+		{
+			description: "ShouldReturnDefaultQuery",
+			argsReq:     ListTodosRequest{},
+			expectedQuery: Query{
+				Limit:  DefaultLimit,
+				SortBy: SortByCreatedAt,
+				Desc:   true,
+			},
+		},
+		{
+			description: "ShouldReturnAscendingQuery",
+			argsReq:     ListTodosRequest{Limit: 5, Sort: "title", Q: "keyword"},
+			expectedQuery: Query{
+				Limit:   5,
+				SortBy:  SortByTitle,
+				Keyword: "keyword",
+			},
+		},
+		{
+			description: "ShouldReturnQueryWithCursor",
+			argsReq:     ListTodosRequest{Sort: "title", Cursor: cursor.Encode()},
+			expectedQuery: Query{
+				Limit:  DefaultLimit,
+				SortBy: SortByTitle,
+				After:  &cursor,
+			},
+		},
+		{
+			description: "ShouldReturnInvalidSortErr",
+			argsReq:     ListTodosRequest{Sort: "contents"},
+			expectedErr: ErrInvalidSort,
+		},
+		{
+			description: "ShouldReturnInvalidCursorErr_WhenMalformedCursor",
+			argsReq:     ListTodosRequest{Cursor: "!!!"},
+			expectedErr: ErrInvalidCursor,
+		},
+		{
+			description: "ShouldReturnInvalidCursorErr_WhenSortMismatched",
+			argsReq:     ListTodosRequest{Sort: "-created_at", Cursor: cursor.Encode()},
+			expectedErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualQuery, actualErr := NewQuery(tc.argsReq)
+
+			suite.Equal(tc.expectedQuery, actualQuery)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *queryUnit) TestPageResponse() {
+	todos := []Todo{
+		Todo{ID: uuid.NewV4(), Title: "first"},
+		Todo{ID: uuid.NewV4(), Title: "second"},
+	}
+
+	query := Query{Limit: 2, SortBy: SortByTitle}
+	page := Page{Todos: todos, Next: query.NewCursor(todos[1])}
+
+	res := page.Response()
+	suite.Len(res.Items, 2)
+
+	cursor, err := DecodeCursor(res.NextCursor)
+	suite.NoError(err)
+	suite.Equal(*page.Next, cursor)
+}
@@ -1,6 +1,8 @@
 package todo
 
 import (
+	"context"
+	"strings"
 	"time"
 
 	"github.com/gghcode/go-gin-starterkit/api/common"
@@ -11,15 +13,15 @@ import (
 
 // Repository communications with db connection.
 type Repository interface {
-	CreateTodo(todo Todo) (Todo, error)
+	CreateTodo(ctx context.Context, todo Todo) (Todo, error)
 
-	GetTodos() ([]Todo, error)
+	GetTodosByUserID(ctx context.Context, userID int64, query Query) (Page, error)
 
-	GetTodoByTodoID(todoID string) (Todo, error)
+	GetTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error)
 
-	UpdateTodoByTodoID(todoID string, todo Todo) (Todo, error)
+	UpdateTodoByTodoID(ctx context.Context, userID int64, todoID string, todo Todo) (Todo, error)
 
-	RemoveTodoByTodoID(todoID string) (Todo, error)
+	RemoveTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error)
 }
 
 type repository struct {
@@ -28,29 +30,74 @@ type repository struct {
 
 // NewRepository return new instance.
 func NewRepository(dbConn *db.Conn) Repository {
-	dbConn.GetDB().AutoMigrate(Todo{})
-
-	return &repository{
+	return newTracedRepository(&repository{
 		dbConn: dbConn,
-	}
+	})
 }
 
-func (repo *repository) GetTodos() ([]Todo, error) {
+func (repo *repository) GetTodosByUserID(ctx context.Context, userID int64, query Query) (Page, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
 	var todos []Todo
 
-	db := repo.dbConn.GetDB()
+	order, op := "ASC", ">"
+	if query.Desc {
+		order, op = "DESC", "<"
+	}
+
+	db := repo.dbConn.WithContext(ctx).
+		Where("user_id=?", userID).
+		Order(query.SortBy + " " + order).
+		Order("id " + order).
+		Limit(query.Limit + 1)
+
+	if query.Keyword != "" {
+		pattern := "%" + escapeLike(query.Keyword) + "%"
+		db = db.Where("title ILIKE ? OR contents ILIKE ?", pattern, pattern)
+	}
+
+	if cursor := query.After; cursor != nil {
+		var sortValue interface{} = cursor.CreatedAt
+		if query.SortBy == SortByTitle {
+			sortValue = cursor.Title
+		}
+
+		db = db.Where("("+query.SortBy+", id) "+op+" (?, ?)", sortValue, cursor.ID)
+	}
+
 	if err := db.Find(&todos).Error; err != nil {
-		return nil, err
+		return Page{}, err
 	}
 
-	return todos, nil
+	return newPage(query, todos), nil
 }
 
-func (repo *repository) GetTodoByTodoID(todoID string) (Todo, error) {
+func newPage(query Query, todos []Todo) Page {
+	if len(todos) <= query.Limit {
+		return Page{Todos: todos}
+	}
+
+	todos = todos[:query.Limit]
+
+	return Page{
+		Todos: todos,
+		Next:  query.NewCursor(todos[len(todos)-1]),
+	}
+}
+
+func escapeLike(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(keyword)
+}
+
+func (repo *repository) GetTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
 	var todo Todo
 
-	err := repo.dbConn.GetDB().
-		Where("id=?", todoID).
+	err := repo.dbConn.WithContext(ctx).
+		Where("id=? AND user_id=?", todoID, userID).
 		First(&todo).
 		Error
 
@@ -63,11 +110,14 @@ func (repo *repository) GetTodoByTodoID(todoID string) (Todo, error) {
 	return todo, nil
 }
 
-func (repo *repository) CreateTodo(todo Todo) (Todo, error) {
+func (repo *repository) CreateTodo(ctx context.Context, todo Todo) (Todo, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
 	todo.ID = uuid.NewV4()
 	todo.CreatedAt = time.Now().Unix()
 
-	err := repo.dbConn.GetDB().
+	err := repo.dbConn.WithContext(ctx).
 		Create(&todo).
 		Error
 
@@ -78,13 +128,16 @@ func (repo *repository) CreateTodo(todo Todo) (Todo, error) {
 	return todo, nil
 }
 
-func (repo *repository) UpdateTodoByTodoID(todoID string, todo Todo) (Todo, error) {
-	fetchedTodo, err := repo.GetTodoByTodoID(todoID)
+func (repo *repository) UpdateTodoByTodoID(ctx context.Context, userID int64, todoID string, todo Todo) (Todo, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	fetchedTodo, err := repo.GetTodoByTodoID(ctx, userID, todoID)
 	if err != nil {
 		return EmptyTodo, err
 	}
 
-	err = repo.dbConn.GetDB().
+	err = repo.dbConn.WithContext(ctx).
 		Model(&fetchedTodo).
 		Updates(&todo).
 		Error
@@ -96,13 +149,16 @@ func (repo *repository) UpdateTodoByTodoID(todoID string, todo Todo) (Todo, erro
 	return fetchedTodo, nil
 }
 
-func (repo *repository) RemoveTodoByTodoID(todoID string) (Todo, error) {
-	todo, err := repo.GetTodoByTodoID(todoID)
+func (repo *repository) RemoveTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	todo, err := repo.GetTodoByTodoID(ctx, userID, todoID)
 	if err != nil {
 		return EmptyTodo, err
 	}
 
-	err = repo.dbConn.GetDB().
+	err = repo.dbConn.WithContext(ctx).
 		Delete(&todo).
 		Error
 
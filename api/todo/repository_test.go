@@ -0,0 +1,310 @@
+package todo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/todo"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	WillFetchedTodoIdx = 0
+	WillUpdatedTodoIdx = 1
+	WillRemovedTodoIdx = 2
+	OtherUserTodoIdx   = 3
+
+	TestUserID  = 1
+	OtherUserID = 2
+)
+
+// repoSuite pages todos of user through memory and postgres repository
+// and expects same order from both.
+type repoSuite struct {
+	suite.Suite
+
+	repo todo.Repository
+
+	testTodos []todo.Todo
+}
+
+func TestTodoMemoryRepoUnit(t *testing.T) {
+	suite.Run(t, &repoSuite{repo: todo.NewMemoryRepository()})
+}
+
+// SetupSuite seed repo with test todos.
+func (suite *repoSuite) SetupSuite() {
+	testTodos, err := pushTestDataToDB(suite.repo)
+	require.NoError(suite.T(), err)
+
+	suite.testTodos = testTodos
+}
+
+func pushTestDataToDB(repo todo.Repository) ([]todo.Todo, error) {
+	todos := []todo.Todo{
+		todo.Todo{UserID: TestUserID, Title: "will fetched todo", Contents: "first new contents"},
+		todo.Todo{UserID: TestUserID, Title: "will updated todo", Contents: "second new contents"},
+		todo.Todo{UserID: TestUserID, Title: "will removed todo", Contents: "third new contents"},
+		todo.Todo{UserID: OtherUserID, Title: "other user todo", Contents: "fourth new contents"},
+	}
+
+	var result []todo.Todo
+
+	for _, todo := range todos {
+		insertedTodo, err := repo.CreateTodo(context.Background(), todo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, insertedTodo)
+	}
+
+	return result, nil
+}
+
+func (suite *repoSuite) TestGetTodosByUserID() {
+	testCases := []struct {
+		description string
+		argsUserID  int64
+		argsQuery   todo.Query
+		expectedErr error
+	}{
+		{
+			description: "ShouldFetchOnlyOwnedTodos",
+			argsUserID:  TestUserID,
+			argsQuery:   todo.Query{Limit: todo.MaxLimit, SortBy: todo.SortByCreatedAt},
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldFetchOnlyOwnedTodos_WhenOtherUser",
+			argsUserID:  OtherUserID,
+			argsQuery:   todo.Query{Limit: todo.MaxLimit, SortBy: todo.SortByTitle, Desc: true},
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldFetchTodos_WhenKeywordMatched",
+			argsUserID:  TestUserID,
+			argsQuery: todo.Query{
+				Limit:   todo.MaxLimit,
+				SortBy:  todo.SortByTitle,
+				Keyword: "FETCHED",
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualPage, actualErr := suite.repo.GetTodosByUserID(context.Background(), tc.argsUserID, tc.argsQuery)
+
+			suite.NotEmpty(actualPage.Todos)
+			for _, actualTodo := range actualPage.Todos {
+				suite.Equal(tc.argsUserID, actualTodo.UserID)
+			}
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestGetTodosByUserID_Paging() {
+	query := todo.Query{Limit: 1, SortBy: todo.SortByTitle}
+
+	var fetchedTodos []todo.Todo
+	for {
+		page, err := suite.repo.GetTodosByUserID(context.Background(), TestUserID, query)
+		suite.Require().NoError(err)
+		suite.Require().True(len(page.Todos) <= query.Limit)
+
+		fetchedTodos = append(fetchedTodos, page.Todos...)
+		if page.Next == nil {
+			break
+		}
+
+		query.After = page.Next
+	}
+
+	allPage, err := suite.repo.GetTodosByUserID(context.Background(), TestUserID, todo.Query{
+		Limit:  todo.MaxLimit,
+		SortBy: todo.SortByTitle,
+	})
+	suite.NoError(err)
+	suite.Equal(allPage.Todos, fetchedTodos)
+
+	for i := 1; i < len(fetchedTodos); i++ {
+		suite.True(fetchedTodos[i-1].Title <= fetchedTodos[i].Title)
+	}
+}
+
+func (suite *repoSuite) TestGetTodoByID() {
+	testCases := []struct {
+		description  string
+		argsUserID   int64
+		argsTodoID   string
+		expectedTodo todo.Todo
+		expectedErr  error
+	}{
+		{
+			description:  "ShouldFetchTodo",
+			argsUserID:   TestUserID,
+			argsTodoID:   suite.testTodos[WillFetchedTodoIdx].ID.String(),
+			expectedTodo: suite.testTodos[WillFetchedTodoIdx],
+			expectedErr:  nil,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr",
+			argsUserID:   TestUserID,
+			argsTodoID:   todo.EmptyTodo.ID.String(),
+			expectedTodo: todo.EmptyTodo,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsUserID:   TestUserID,
+			argsTodoID:   suite.testTodos[OtherUserTodoIdx].ID.String(),
+			expectedTodo: todo.EmptyTodo,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualTodo, actualErr := suite.repo.GetTodoByTodoID(context.Background(), tc.argsUserID, tc.argsTodoID)
+
+			suite.Equal(tc.expectedTodo, actualTodo)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestCreateTodo() {
+	testCases := []struct {
+		description    string
+		argsTodo       todo.Todo
+		expectedTodoFn func(todo.Todo) todo.Todo
+		expectedErr    error
+	}{
+		{
+			description: "ShouldCreateTodo",
+			argsTodo:    todo.Todo{UserID: TestUserID, Title: "new title", Contents: "new contents"},
+			expectedTodoFn: func(insertedTodo todo.Todo) todo.Todo {
+				todo := todo.Todo{UserID: TestUserID, Title: "new title", Contents: "new contents"}
+				todo.ID = insertedTodo.ID
+				todo.CreatedAt = insertedTodo.CreatedAt
+
+				return todo
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualTodo, actualErr := suite.repo.CreateTodo(context.Background(), tc.argsTodo)
+
+			suite.Equal(tc.expectedTodoFn(actualTodo), actualTodo)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestUpdateTodoByID() {
+	testCases := []struct {
+		description  string
+		argsUserID   int64
+		argsTodoID   string
+		argsTodo     todo.Todo
+		expectedTodo todo.Todo
+		expectedErr  error
+	}{
+		{
+			description: "ShouldUpdateTodo",
+			argsUserID:  TestUserID,
+			argsTodoID:  suite.testTodos[WillUpdatedTodoIdx].ID.String(),
+			argsTodo: todo.Todo{
+				ID:        suite.testTodos[WillUpdatedTodoIdx].ID,
+				Title:     "will update title",
+				Contents:  "will update contents",
+				CreatedAt: suite.testTodos[WillUpdatedTodoIdx].CreatedAt,
+			},
+			expectedTodo: todo.Todo{
+				ID:        suite.testTodos[WillUpdatedTodoIdx].ID,
+				UserID:    TestUserID,
+				Title:     "will update title",
+				Contents:  "will update contents",
+				CreatedAt: suite.testTodos[WillUpdatedTodoIdx].CreatedAt,
+			},
+			expectedErr: nil,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr",
+			argsUserID:   TestUserID,
+			argsTodoID:   todo.EmptyTodo.ID.String(),
+			argsTodo:     todo.EmptyTodo,
+			expectedTodo: todo.EmptyTodo,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsUserID:   TestUserID,
+			argsTodoID:   suite.testTodos[OtherUserTodoIdx].ID.String(),
+			argsTodo:     todo.Todo{Title: "will update title"},
+			expectedTodo: todo.EmptyTodo,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualTodo, actualErr := suite.repo.UpdateTodoByTodoID(context.Background(),
+				tc.argsUserID, tc.argsTodoID, tc.argsTodo,
+			)
+
+			suite.Equal(tc.expectedTodo, actualTodo)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) testRemoveTodoByID() {
+	testCases := []struct {
+		description  string
+		argsUserID   int64
+		argsTodoID   string
+		expectedTodo todo.Todo
+		expectedErr  error
+	}{
+		{
+			description:  "ShouldRemoveTodo",
+			argsUserID:   TestUserID,
+			argsTodoID:   suite.testTodos[WillRemovedTodoIdx].ID.String(),
+			expectedTodo: suite.testTodos[WillRemovedTodoIdx],
+			expectedErr:  nil,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr",
+			argsUserID:   TestUserID,
+			argsTodoID:   todo.EmptyTodo.ID.String(),
+			expectedTodo: todo.EmptyTodo,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr_WhenNotOwner",
+			argsUserID:   TestUserID,
+			argsTodoID:   suite.testTodos[OtherUserTodoIdx].ID.String(),
+			expectedTodo: todo.EmptyTodo,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualTodo, actualErr := suite.repo.RemoveTodoByTodoID(context.Background(), tc.argsUserID, tc.argsTodoID)
+
+			suite.Equal(tc.expectedTodo, actualTodo)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
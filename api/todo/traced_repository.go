@@ -0,0 +1,67 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/gghcode/go-gin-starterkit/tracing"
+)
+
+// tracedRepository record span of each todo query,
+// such as paging todos of user.
+type tracedRepository struct {
+	next Repository
+}
+
+func newTracedRepository(next Repository) Repository {
+	return &tracedRepository{next: next}
+}
+
+func (repo *tracedRepository) CreateTodo(ctx context.Context, todo Todo) (Todo, error) {
+	var result Todo
+	err := tracing.Trace(ctx, "todo.Repository/CreateTodo", func(ctx context.Context) (err error) {
+		result, err = repo.next.CreateTodo(ctx, todo)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetTodosByUserID(ctx context.Context, userID int64, query Query) (Page, error) {
+	var result Page
+	err := tracing.Trace(ctx, "todo.Repository/GetTodosByUserID", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetTodosByUserID(ctx, userID, query)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error) {
+	var result Todo
+	err := tracing.Trace(ctx, "todo.Repository/GetTodoByTodoID", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetTodoByTodoID(ctx, userID, todoID)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) UpdateTodoByTodoID(ctx context.Context, userID int64, todoID string, todo Todo) (Todo, error) {
+	var result Todo
+	err := tracing.Trace(ctx, "todo.Repository/UpdateTodoByTodoID", func(ctx context.Context) (err error) {
+		result, err = repo.next.UpdateTodoByTodoID(ctx, userID, todoID, todo)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) RemoveTodoByTodoID(ctx context.Context, userID int64, todoID string) (Todo, error) {
+	var result Todo
+	err := tracing.Trace(ctx, "todo.Repository/RemoveTodoByTodoID", func(ctx context.Context) (err error) {
+		result, err = repo.next.RemoveTodoByTodoID(ctx, userID, todoID)
+		return err
+	})
+
+	return result, err
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/gghcode/go-gin-starterkit/api"
 	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/middleware"
 	"github.com/gghcode/go-gin-starterkit/service"
@@ -28,16 +29,36 @@ func NewController(repo Repository, passport service.Passport) *Controller {
 }
 
 // RegisterRoutes register handler routes.
-func (controller *Controller) RegisterRoutes(router gin.IRouter) {
+func (controller *Controller) RegisterRoutes(router *api.Router) {
 	userRouter := router.Group(APIPath)
 	{
-		userRouter.Handle("POST", "/", controller.createUser)
+		userRouter.Handle("POST", "/",
+			middleware.RateLimit(service.RateLimitSignup),
+			controller.createUser)
 
-		authorized := userRouter.Use(middleware.AuthRequired())
+		authorized := userRouter.Use(
+			middleware.AuthRequired(),
+			middleware.RateLimit(service.RateLimitUser))
 		{
-			authorized.Handle("GET", "/:username", controller.getUserByUserName)
-			authorized.Handle("PUT", "/:id", controller.updateUserByID)
-			authorized.Handle("DELETE", "/:id", controller.removeUserByID)
+			authorized.Handle("GET", "/:username",
+				middleware.RequireScope(service.ScopeUsersRead),
+				controller.getUserByUserName)
+			authorized.Handle("PUT", "/:id",
+				middleware.RequireScope(service.ScopeUsersWrite),
+				middleware.RequireSelfOrPermission("id", service.PermissionManageUsers),
+				controller.updateUserByID)
+			authorized.Handle("DELETE", "/:id",
+				middleware.RequireScope(service.ScopeUsersWrite),
+				middleware.RequireSelfOrPermission("id", service.PermissionManageUsers),
+				controller.removeUserByID)
+			authorized.Handle("PUT", "/:id/password",
+				middleware.RequireFullAccess(),
+				middleware.RequireSelf("id"),
+				controller.changePassword)
+			authorized.Handle("PUT", "/:id/role",
+				middleware.RequireScope(service.ScopeUsersWrite),
+				middleware.RequirePermission(service.PermissionManageRoles),
+				controller.updateUserRoleByID)
 		}
 	}
 }
@@ -48,19 +69,20 @@ func (controller *Controller) RegisterRoutes(router gin.IRouter) {
 // @Param payload body user.CreateUserRequest true "user payload"
 // @Success 201 {object} user.UserResponse "ok"
 // @Failure 400 {object} common.ErrorResponse "Invalid user payload"
+// @Failure 429 {object} common.ErrorResponse "Too many requests"
 // @Tags User API
 // @Router /users [post]
 func (controller *Controller) createUser(ctx *gin.Context) {
 	var dtoReq CreateUserRequest
 
 	if err := ctx.ShouldBindJSON(&dtoReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
 		return
 	}
 
 	passwordHash, err := controller.passport.HashPassword(dtoReq.Password)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
@@ -69,12 +91,9 @@ func (controller *Controller) createUser(ctx *gin.Context) {
 		PasswordHash: passwordHash,
 	}
 
-	createdUser, err := controller.repo.CreateUser(userEntity)
-	if err == common.ErrAlreadyExistsEntity {
-		ctx.JSON(http.StatusConflict, common.NewErrResp(err))
-		return
-	} else if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+	createdUser, err := controller.repo.CreateUser(ctx.Request.Context(), userEntity)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
@@ -92,12 +111,9 @@ func (controller *Controller) createUser(ctx *gin.Context) {
 func (controller *Controller) getUserByUserName(ctx *gin.Context) {
 	userName := ctx.Param("username")
 
-	user, err := controller.repo.GetUserByUserName(userName)
-	if err == common.ErrEntityNotFound {
-		ctx.JSON(http.StatusNotFound, common.NewErrResp(err))
-		return
-	} else if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+	user, err := controller.repo.GetUserByUserName(ctx.Request.Context(), userName)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
@@ -112,19 +128,21 @@ func (controller *Controller) getUserByUserName(ctx *gin.Context) {
 // @Param payload body user.UpdateUserRequest true "user payload"
 // @Success 200 {object} user.UserResponse "ok"
 // @Failure 400 {object} common.ErrorResponse "Invalid user payload"
+// @Failure 403 {object} common.ErrorResponse "Permission denied"
 // @Failure 404 {object} common.ErrorResponse "Not found entity"
+// @Failure 409 {object} common.ErrorResponse "Already exists user name"
 // @Tags User API
 // @Router /users/{id} [put]
 func (controller *Controller) updateUserByID(ctx *gin.Context) {
 	userID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, common.NewErrResp(common.ErrParsingFailed))
+		common.WriteErrResp(ctx, ErrorCodes, common.ErrParsingFailed)
 		return
 	}
 
 	var reqBody UpdateUserRequest
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, err)
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
 		return
 	}
 
@@ -132,12 +150,9 @@ func (controller *Controller) updateUserByID(ctx *gin.Context) {
 		UserName: reqBody.UserName,
 	}
 
-	user, err := controller.repo.UpdateUserByUserID(userID, entity)
-	if err == common.ErrEntityNotFound {
-		ctx.JSON(http.StatusNotFound, common.NewErrResp(err))
-		return
-	} else if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+	user, err := controller.repo.UpdateUserByUserID(ctx.Request.Context(), userID, entity)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
@@ -149,24 +164,106 @@ func (controller *Controller) updateUserByID(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "user id"
 // @Success 200 {object} user.UserResponse "ok"
+// @Failure 403 {object} common.ErrorResponse "Permission denied"
 // @Failure 404 {object} common.ErrorResponse "Not found entity"
 // @Tags User API
 // @Router /users/{id} [delete]
 func (controller *Controller) removeUserByID(ctx *gin.Context) {
 	userID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, common.NewErrResp(common.ErrParsingFailed))
+		common.WriteErrResp(ctx, ErrorCodes, common.ErrParsingFailed)
 		return
 	}
 
-	removedUser, err := controller.repo.RemoveUserByUserID(userID)
-	if err == common.ErrEntityNotFound {
-		ctx.JSON(http.StatusNotFound, common.NewErrResp(err))
-		return
-	} else if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.NewErrResp(err))
+	removedUser, err := controller.repo.RemoveUserByUserID(ctx.Request.Context(), userID)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, removedUser.Response())
 }
+
+// @Description Update role of user by user id
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "user id"
+// @Param payload body user.UpdateUserRoleRequest true "role payload"
+// @Success 200 {object} user.UserResponse "ok"
+// @Failure 400 {object} common.ErrorResponse "Invalid role payload"
+// @Failure 403 {object} common.ErrorResponse "Permission denied"
+// @Failure 404 {object} common.ErrorResponse "Not found entity"
+// @Tags User API
+// @Router /users/{id}/role [put]
+func (controller *Controller) updateUserRoleByID(ctx *gin.Context) {
+	userID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.ErrParsingFailed)
+		return
+	}
+
+	var reqBody UpdateUserRoleRequest
+	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	if !service.IsValidRole(reqBody.Role) {
+		common.WriteErrResp(ctx, ErrorCodes, ErrInvalidRole)
+		return
+	}
+
+	user, err := controller.repo.UpdateUserByUserID(ctx.Request.Context(), userID, User{Role: reqBody.Role})
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user.Response())
+}
+
+// @Description Change password of current user
+// @Security ApiKeyAuth
+// @Accept json
+// @Param id path string true "me or user id of current user"
+// @Param payload body user.ChangePasswordRequest true "password payload"
+// @Success 204
+// @Failure 400 {object} common.ErrorResponse "Invalid password payload"
+// @Failure 403 {object} common.ErrorResponse "Invalid current password or permission denied"
+// @Tags User API
+// @Router /users/{id}/password [put]
+func (controller *Controller) changePassword(ctx *gin.Context) {
+	var reqBody ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, common.BindingErr(err))
+		return
+	}
+
+	userID := ctx.GetInt64(middleware.UserIDKey)
+
+	user, err := controller.repo.GetUserByUserID(ctx.Request.Context(), userID)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	if !controller.passport.IsValidPassword(reqBody.CurrentPassword, user.PasswordHash) {
+		common.WriteErrResp(ctx, ErrorCodes, ErrInvalidCurrentPassword)
+		return
+	}
+
+	passwordHash, err := controller.passport.HashPassword(reqBody.NewPassword)
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	_, err = controller.repo.UpdateUserByUserID(ctx.Request.Context(), userID, User{PasswordHash: passwordHash})
+	if err != nil {
+		common.WriteErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
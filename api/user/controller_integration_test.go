@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/gghcode/go-gin-starterkit/api"
 	"github.com/gghcode/go-gin-starterkit/middleware"
 	"github.com/gghcode/go-gin-starterkit/service"
 
@@ -25,6 +26,7 @@ type controllerIntegration struct {
 	ginEngine *gin.Engine
 	dbConn    *db.Conn
 
+	authRole  string
 	testUsers []user.User
 }
 
@@ -46,18 +48,24 @@ func (suite *controllerIntegration) SetupSuite() {
 	dbConn, err := db.NewConn(conf)
 	require.NoError(suite.T(), err)
 
+	testutil.MigrateUp(suite.T(), dbConn)
+
 	suite.ginEngine = gin.New()
 	suite.ginEngine.Use(func(ctx *gin.Context) {
-		var innerHandler gin.HandlerFunc = func(ctx *gin.Context) {}
+		var innerHandler gin.HandlerFunc = func(ctx *gin.Context) {
+			ctx.Set(middleware.UserIDKey, user.EmptyUser.ID)
+			ctx.Set(middleware.RoleKey, suite.authRole)
+		}
 
 		ctx.Set(middleware.VerifyHandlerKey, innerHandler)
 		ctx.Next()
 	})
 	suite.dbConn = dbConn
+	suite.authRole = service.RoleAdmin
 
 	userRepo := user.NewRepository(dbConn)
 	userController := user.NewController(userRepo, service.NewPassport())
-	userController.RegisterRoutes(suite.ginEngine)
+	userController.RegisterRoutes(api.NewRouter(&suite.ginEngine.RouterGroup))
 
 	suite.testUsers, err = pushTestDataToDB(userRepo, "controller")
 	require.NoError(suite.T(), err)
@@ -82,6 +90,7 @@ func (suite *controllerIntegration) TestCreateUser() {
 				expectedUserRes := user.UserResponse{
 					ID:        actualUserRes.ID,
 					UserName:  "New User",
+					Role:      service.RoleUser,
 					CreatedAt: actualUserRes.CreatedAt,
 				}
 
@@ -97,7 +106,7 @@ func (suite *controllerIntegration) TestCreateUser() {
 			expectedStatus: http.StatusConflict,
 			expectedJSON: func(string) string {
 				return testutil.JSONStringFromInterface(suite.T(),
-					common.NewErrResp(common.ErrAlreadyExistsEntity))
+					common.NewErrResp(user.ErrorCodes, common.ErrAlreadyExistsEntity))
 			},
 		},
 	}
@@ -135,7 +144,7 @@ func (suite *controllerIntegration) TestGetUserByName() {
 			username:       "NOT_EXISTS_USER_NAME",
 			expectedStatus: http.StatusNotFound,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrEntityNotFound)),
+				common.NewErrResp(user.ErrorCodes, common.ErrEntityNotFound)),
 		},
 	}
 
@@ -167,6 +176,7 @@ func (suite *controllerIntegration) TestUpdateUserByID() {
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(), user.UserResponse{
 				ID:        suite.testUsers[WillUpdatedEntityIdx].ID,
 				UserName:  "updated_username",
+				Role:      service.RoleUser,
 				CreatedAt: suite.testUsers[WillUpdatedEntityIdx].Response().CreatedAt,
 			}),
 		},
@@ -183,7 +193,7 @@ func (suite *controllerIntegration) TestUpdateUserByID() {
 			updateUserReq:  &user.UpdateUserRequest{UserName: "username"},
 			expectedStatus: http.StatusBadRequest,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrParsingFailed)),
+				common.NewErrResp(user.ErrorCodes, common.ErrParsingFailed)),
 		},
 		{
 			description:    "ShouldReturnBadRequestErr_WhenLessUserNameLenMin4",
@@ -198,7 +208,7 @@ func (suite *controllerIntegration) TestUpdateUserByID() {
 			updateUserReq:  &user.UpdateUserRequest{UserName: "username100"},
 			expectedStatus: http.StatusNotFound,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrEntityNotFound)),
+				common.NewErrResp(user.ErrorCodes, common.ErrEntityNotFound)),
 		},
 	}
 
@@ -237,14 +247,14 @@ func (suite *controllerIntegration) TestRemoveUser() {
 			userID:         "INVALID_STRING_USER_ID",
 			expectedStatus: http.StatusBadRequest,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrParsingFailed)),
+				common.NewErrResp(user.ErrorCodes, common.ErrParsingFailed)),
 		},
 		{
 			description:    "ShouldReturnNotFoundErr_WhenNotExistEntity",
 			userID:         strconv.FormatInt(user.EmptyUser.ID, 10),
 			expectedStatus: http.StatusNotFound,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(common.ErrEntityNotFound)),
+				common.NewErrResp(user.ErrorCodes, common.ErrEntityNotFound)),
 		},
 	}
 
@@ -260,6 +270,74 @@ func (suite *controllerIntegration) TestRemoveUser() {
 	}
 }
 
+func (suite *controllerIntegration) TestUpdateUserRoleByID() {
+	testCases := []struct {
+		description    string
+		authRole       string
+		userID         string
+		updateRoleReq  *user.UpdateUserRoleRequest
+		expectedStatus int
+		expectedJSON   string
+	}{
+		{
+			description:    "ShouldReturnOK",
+			authRole:       service.RoleAdmin,
+			userID:         strconv.FormatInt(suite.testUsers[WillFetchedEntityIdx].ID, 10),
+			updateRoleReq:  &user.UpdateUserRoleRequest{Role: service.RoleAdmin},
+			expectedStatus: http.StatusOK,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(), user.UserResponse{
+				ID:        suite.testUsers[WillFetchedEntityIdx].ID,
+				UserName:  suite.testUsers[WillFetchedEntityIdx].UserName,
+				Role:      service.RoleAdmin,
+				CreatedAt: suite.testUsers[WillFetchedEntityIdx].Response().CreatedAt,
+			}),
+		},
+		{
+			description:    "ShouldReturnBadRequestErr_WhenInvalidRole",
+			authRole:       service.RoleAdmin,
+			userID:         strconv.FormatInt(suite.testUsers[WillFetchedEntityIdx].ID, 10),
+			updateRoleReq:  &user.UpdateUserRoleRequest{Role: "NOT_EXISTS_ROLE"},
+			expectedStatus: http.StatusBadRequest,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(user.ErrorCodes, user.ErrInvalidRole)),
+		},
+		{
+			description:    "ShouldReturnNotFoundErr_WhenNotExistsEntity",
+			authRole:       service.RoleAdmin,
+			userID:         strconv.FormatInt(user.EmptyUser.ID, 10),
+			updateRoleReq:  &user.UpdateUserRoleRequest{Role: service.RoleAdmin},
+			expectedStatus: http.StatusNotFound,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(user.ErrorCodes, common.ErrEntityNotFound)),
+		},
+		{
+			description:    "ShouldReturnForbiddenErr_WhenNotAdmin",
+			authRole:       service.RoleUser,
+			userID:         strconv.FormatInt(suite.testUsers[WillFetchedEntityIdx].ID, 10),
+			updateRoleReq:  &user.UpdateUserRoleRequest{Role: service.RoleAdmin},
+			expectedStatus: http.StatusForbidden,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(middleware.ErrorCodes, middleware.ErrPermissionDenied)),
+		},
+	}
+
+	defer func() { suite.authRole = service.RoleAdmin }()
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			suite.authRole = tc.authRole
+			reqBody := testutil.ReqBodyFromInterface(suite.T(), tc.updateRoleReq)
+
+			actualRes := testutil.ActualResponse(suite.T(), suite.ginEngine,
+				"PUT", user.APIPath+tc.userID+"/role", reqBody)
+			suite.Equal(tc.expectedStatus, actualRes.StatusCode)
+
+			actualJSON := testutil.JSONStringFromResBody(suite.T(), actualRes.Body)
+			suite.JSONEq(tc.expectedJSON, actualJSON)
+		})
+	}
+}
+
 func UserResFromJSONString(t *testing.T, jsonString string) user.UserResponse {
 	var result user.UserResponse
 
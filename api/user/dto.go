@@ -13,9 +13,22 @@ type UpdateUserRequest struct {
 	UserName string `json:"user_name" example:"<new user name>" binding:"min=4,max=100"`
 }
 
+// UpdateUserRoleRequest is dto that contains role to grant user.
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" example:"admin" binding:"required"`
+}
+
+// ChangePasswordRequest is dto that contains current password and new one.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"<current password>" binding:"required"`
+	NewPassword     string `json:"new_password" example:"<new password>" binding:"required,min=8,max=50"`
+}
+
 // UserResponse is user response model.
 type UserResponse struct {
-	ID        int64     `json:"id"`
-	UserName  string    `json:"user_name"`
-	CreatedAt time.Time `json:"create_at"`
+	ID         int64     `json:"id"`
+	UserName   string    `json:"user_name"`
+	Role       string    `json:"role"`
+	MFAEnabled bool      `json:"mfa_enabled"`
+	CreatedAt  time.Time `json:"create_at"`
 }
@@ -1,6 +1,10 @@
 package user
 
-import "time"
+import (
+	"time"
+
+	pg "github.com/lib/pq"
+)
 
 // EmptyUser is empty user model
 var EmptyUser = User{}
@@ -10,14 +14,22 @@ type User struct {
 	ID           int64  `gorm:"primary_key;"`
 	UserName     string `gorm:"unique;not null;"`
 	PasswordHash []byte `gorm:"not null;"`
+	Role         string `gorm:"not null;"`
 	CreatedAt    int64  `gorm:"not null;"`
+
+	// TOTPSecret is kept while enrolling even before TOTPEnabled is set by confirmation.
+	TOTPSecret         string         `gorm:"column:totp_secret;not null;"`
+	TOTPEnabled        bool           `gorm:"column:totp_enabled;not null;"`
+	RecoveryCodeHashes pg.StringArray `gorm:"column:recovery_code_hashes;type:text[];not null;default:'{}';"`
 }
 
 // Response return new user response from user entity.
 func (user User) Response() UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		UserName:  user.UserName,
-		CreatedAt: time.Unix(user.CreatedAt, 0),
+		ID:         user.ID,
+		UserName:   user.UserName,
+		Role:       user.Role,
+		MFAEnabled: user.TOTPEnabled,
+		CreatedAt:  time.Unix(user.CreatedAt, 0),
 	}
 }
@@ -0,0 +1,24 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidRole is occurred when requested role is unknown.
+	ErrInvalidRole = errors.New("Role is invalid")
+
+	// ErrInvalidCurrentPassword is occurred when current password does not match.
+	ErrInvalidCurrentPassword = errors.New("Current password is invalid")
+)
+
+// ErrorCodes map errors of user api into status and code of response.
+var ErrorCodes = common.ErrorCodes{
+	common.ErrEntityNotFound:      {Status: http.StatusNotFound, Code: "user.not_found"},
+	common.ErrAlreadyExistsEntity: {Status: http.StatusConflict, Code: "user.already_exists"},
+	ErrInvalidRole:                {Status: http.StatusBadRequest, Code: "user.invalid_role"},
+	ErrInvalidCurrentPassword:     {Status: http.StatusForbidden, Code: "user.invalid_current_password"},
+}
@@ -0,0 +1,164 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/service"
+)
+
+type memoryRepository struct {
+	mutex  sync.RWMutex
+	users  map[int64]User
+	lastID int64
+}
+
+// NewMemoryRepository return new in-memory repository
+// that behaves like postgres repository.
+func NewMemoryRepository() Repository {
+	return newTracedRepository(&memoryRepository{
+		users: map[int64]User{},
+	})
+}
+
+func (repo *memoryRepository) CreateUser(ctx context.Context, user User) (User, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.existsUserName(user.UserName, 0) {
+		return EmptyUser, common.ErrAlreadyExistsEntity
+	}
+
+	repo.lastID++
+
+	user.ID = repo.lastID
+	user.CreatedAt = time.Now().Unix()
+	if user.Role == "" {
+		user.Role = service.RoleUser
+	}
+	repo.users[user.ID] = user
+
+	return user, nil
+}
+
+func (repo *memoryRepository) GetUserByUserName(ctx context.Context, userName string) (User, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, user := range repo.users {
+		if user.UserName == userName {
+			return user, nil
+		}
+	}
+
+	return EmptyUser, common.ErrEntityNotFound
+}
+
+func (repo *memoryRepository) GetUserByUserID(ctx context.Context, userID int64) (User, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	user, ok := repo.users[userID]
+	if !ok {
+		return EmptyUser, common.ErrEntityNotFound
+	}
+
+	return user, nil
+}
+
+// UpdateUserByUserID update only non-zero fields of user like gorm does.
+func (repo *memoryRepository) UpdateUserByUserID(ctx context.Context, userID int64, user User) (User, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	entity, ok := repo.users[userID]
+	if !ok {
+		return EmptyUser, common.ErrEntityNotFound
+	}
+
+	if user.UserName != "" {
+		if repo.existsUserName(user.UserName, userID) {
+			return EmptyUser, common.ErrAlreadyExistsEntity
+		}
+
+		entity.UserName = user.UserName
+	}
+
+	if len(user.PasswordHash) > 0 {
+		entity.PasswordHash = user.PasswordHash
+	}
+
+	if user.Role != "" {
+		entity.Role = user.Role
+	}
+
+	repo.users[userID] = entity
+
+	return entity, nil
+}
+
+func (repo *memoryRepository) RemoveUserByUserID(ctx context.Context, userID int64) (User, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	entity, ok := repo.users[userID]
+	if !ok {
+		return EmptyUser, common.ErrEntityNotFound
+	}
+
+	delete(repo.users, userID)
+
+	return entity, nil
+}
+
+func (repo *memoryRepository) UpdateTOTPByUserID(ctx context.Context, userID int64, secret string, enabled bool, recoveryCodeHashes []string) (User, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	entity, ok := repo.users[userID]
+	if !ok {
+		return EmptyUser, common.ErrEntityNotFound
+	}
+
+	entity.TOTPSecret = secret
+	entity.TOTPEnabled = enabled
+	entity.RecoveryCodeHashes = append([]string{}, recoveryCodeHashes...)
+
+	repo.users[userID] = entity
+
+	return entity, nil
+}
+
+func (repo *memoryRepository) RemoveRecoveryCodeByUserID(ctx context.Context, userID int64, codeHash string) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	entity, ok := repo.users[userID]
+	if !ok {
+		return common.ErrEntityNotFound
+	}
+
+	for i, hash := range entity.RecoveryCodeHashes {
+		if hash == codeHash {
+			remaining := append([]string{}, entity.RecoveryCodeHashes[:i]...)
+			entity.RecoveryCodeHashes = append(remaining, entity.RecoveryCodeHashes[i+1:]...)
+			repo.users[userID] = entity
+
+			return nil
+		}
+	}
+
+	return common.ErrEntityNotFound
+}
+
+func (repo *memoryRepository) existsUserName(userName string, exceptUserID int64) bool {
+	for _, user := range repo.users {
+		if user.UserName == userName && user.ID != exceptUserID {
+			return true
+		}
+	}
+
+	return false
+}
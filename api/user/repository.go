@@ -1,25 +1,35 @@
 package user
 
 import (
+	"context"
 	"time"
 
 	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
 	"github.com/jinzhu/gorm"
 	pg "github.com/lib/pq"
 )
 
 // Repository communications with db connection.
 type Repository interface {
-	CreateUser(User) (User, error)
+	CreateUser(ctx context.Context, user User) (User, error)
 
-	GetUserByUserName(userName string) (User, error)
+	GetUserByUserName(ctx context.Context, userName string) (User, error)
 
-	GetUserByUserID(userID int64) (User, error)
+	GetUserByUserID(ctx context.Context, userID int64) (User, error)
 
-	UpdateUserByUserID(userID int64, user User) (User, error)
+	UpdateUserByUserID(ctx context.Context, userID int64, user User) (User, error)
 
-	RemoveUserByUserID(userID int64) (User, error)
+	RemoveUserByUserID(ctx context.Context, userID int64) (User, error)
+
+	// UpdateTOTPByUserID replace totp state of user including zero values
+	// that UpdateUserByUserID ignores.
+	UpdateTOTPByUserID(ctx context.Context, userID int64, secret string, enabled bool, recoveryCodeHashes []string) (User, error)
+
+	// RemoveRecoveryCodeByUserID remove recovery code hash of user atomically,
+	// so ErrEntityNotFound is returned when the code was already used.
+	RemoveRecoveryCodeByUserID(ctx context.Context, userID int64, codeHash string) error
 }
 
 type repository struct {
@@ -28,19 +38,21 @@ type repository struct {
 
 // NewRepository return new instance.
 func NewRepository(dbConn *db.Conn) Repository {
-	if dbConn != nil {
-		dbConn.GetDB().AutoMigrate(User{})
-	}
-
-	return &repository{
+	return newTracedRepository(&repository{
 		dbConn: dbConn,
-	}
+	})
 }
 
-func (repo *repository) CreateUser(user User) (User, error) {
+func (repo *repository) CreateUser(ctx context.Context, user User) (User, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
 	user.CreatedAt = time.Now().Unix()
+	if user.Role == "" {
+		user.Role = service.RoleUser
+	}
 
-	err := repo.dbConn.GetDB().
+	err := repo.dbConn.WithContext(ctx).
 		Create(&user).
 		Error
 
@@ -56,10 +68,13 @@ func (repo *repository) CreateUser(user User) (User, error) {
 	return user, nil
 }
 
-func (repo *repository) GetUserByUserName(userName string) (User, error) {
+func (repo *repository) GetUserByUserName(ctx context.Context, userName string) (User, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
 	var result User
 
-	err := repo.dbConn.GetDB().
+	err := repo.dbConn.WithContext(ctx).
 		Where("user_name=?", userName).
 		First(&result).
 		Error
@@ -73,10 +88,13 @@ func (repo *repository) GetUserByUserName(userName string) (User, error) {
 	return result, nil
 }
 
-func (repo *repository) GetUserByUserID(userID int64) (User, error) {
+func (repo *repository) GetUserByUserID(ctx context.Context, userID int64) (User, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
 	var result User
 
-	err := repo.dbConn.GetDB().
+	err := repo.dbConn.WithContext(ctx).
 		Where("id=?", userID).
 		First(&result).
 		Error
@@ -90,31 +108,42 @@ func (repo *repository) GetUserByUserID(userID int64) (User, error) {
 	return result, nil
 }
 
-func (repo *repository) UpdateUserByUserID(userID int64, user User) (User, error) {
-	entity, err := repo.GetUserByUserID(userID)
+func (repo *repository) UpdateUserByUserID(ctx context.Context, userID int64, user User) (User, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	entity, err := repo.GetUserByUserID(ctx, userID)
 	if err != nil {
 		return EmptyUser, err
 	}
 
-	err = repo.dbConn.GetDB().
+	err = repo.dbConn.WithContext(ctx).
 		Model(&entity).
 		Updates(&user).
 		Error
 
-	if err != nil {
+	if pgErr, ok := err.(*pg.Error); ok {
+		if pgErr.Code == "23505" {
+			// handle duplicate update
+			return EmptyUser, common.ErrAlreadyExistsEntity
+		}
+	} else if err != nil {
 		return EmptyUser, err
 	}
 
 	return entity, nil
 }
 
-func (repo *repository) RemoveUserByUserID(userID int64) (User, error) {
-	entity, err := repo.GetUserByUserID(userID)
+func (repo *repository) RemoveUserByUserID(ctx context.Context, userID int64) (User, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	entity, err := repo.GetUserByUserID(ctx, userID)
 	if err != nil {
 		return EmptyUser, err
 	}
 
-	err = repo.dbConn.GetDB().
+	err = repo.dbConn.WithContext(ctx).
 		Delete(&entity).
 		Error
 
@@ -124,3 +153,52 @@ func (repo *repository) RemoveUserByUserID(userID int64) (User, error) {
 
 	return entity, nil
 }
+
+func (repo *repository) UpdateTOTPByUserID(ctx context.Context, userID int64, secret string, enabled bool, recoveryCodeHashes []string) (User, error) {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	entity, err := repo.GetUserByUserID(ctx, userID)
+	if err != nil {
+		return EmptyUser, err
+	}
+
+	if recoveryCodeHashes == nil {
+		recoveryCodeHashes = []string{}
+	}
+
+	err = repo.dbConn.WithContext(ctx).
+		Model(&entity).
+		Updates(map[string]interface{}{
+			"totp_secret":          secret,
+			"totp_enabled":         enabled,
+			"recovery_code_hashes": pg.StringArray(recoveryCodeHashes),
+		}).
+		Error
+
+	if err != nil {
+		return EmptyUser, err
+	}
+
+	return entity, nil
+}
+
+func (repo *repository) RemoveRecoveryCodeByUserID(ctx context.Context, userID int64, codeHash string) error {
+	ctx, cancel := repo.dbConn.WithTimeout(ctx)
+	defer cancel()
+
+	result := repo.dbConn.WithContext(ctx).
+		Model(&User{}).
+		Where("id=? AND ?=ANY(recovery_code_hashes)", userID, codeHash).
+		Update("recovery_code_hashes", gorm.Expr("array_remove(recovery_code_hashes, ?)", codeHash))
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrEntityNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,313 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/service"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	WillFetchedEntityIdx = 0
+	WillUpdatedEntityIdx = 1
+	WillRemovedEntityIdx = 2
+)
+
+// repoSuite runs user cases on memory and postgres repository,
+// so recovery codes are consumed once by both.
+type repoSuite struct {
+	suite.Suite
+
+	repo user.Repository
+
+	testUsers []user.User
+}
+
+func TestUserMemoryRepoUnit(t *testing.T) {
+	suite.Run(t, &repoSuite{repo: user.NewMemoryRepository()})
+}
+
+// SetupSuite seed repo with test users.
+func (suite *repoSuite) SetupSuite() {
+	testUsers, err := pushTestDataToDB(suite.repo, "repo")
+	require.NoError(suite.T(), err)
+
+	suite.testUsers = testUsers
+}
+
+func pushTestDataToDB(repo user.Repository, prefix string) ([]user.User, error) {
+	users := []user.User{
+		user.User{UserName: prefix + "willFetchedUser", PasswordHash: []byte("passwordHash")},
+		user.User{UserName: prefix + "willUpdatedUser", PasswordHash: []byte("passwordHash")},
+		user.User{UserName: prefix + "willRemovedUser", PasswordHash: []byte("passwordHash")},
+	}
+
+	var result []user.User
+
+	for _, user := range users {
+		insertedUser, err := repo.CreateUser(context.Background(), user)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, insertedUser)
+	}
+
+	return result, nil
+}
+
+func (suite *repoSuite) TestCreateUser() {
+	testCases := []struct {
+		description    string
+		argsUser       user.User
+		expectedUserFn func(user.User) user.User
+		expectedErr    error
+	}{
+		{
+			description: "ShouldCreateUser",
+			argsUser:    user.User{UserName: "newUser", PasswordHash: []byte("password")},
+			expectedUserFn: func(actualUser user.User) user.User {
+				user := user.User{UserName: "newUser", PasswordHash: []byte("password")}
+				user.ID = actualUser.ID
+				user.Role = service.RoleUser
+				user.CreatedAt = actualUser.CreatedAt
+
+				return user
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnConflictErr_WhenAlreadyExistsUserName",
+			argsUser: user.User{
+				UserName:     suite.testUsers[WillFetchedEntityIdx].UserName,
+				PasswordHash: []byte("password"),
+			},
+			expectedUserFn: func(user.User) user.User {
+				return user.EmptyUser
+			},
+			expectedErr: common.ErrAlreadyExistsEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualUser, actualErr := suite.repo.CreateUser(context.Background(), tc.argsUser)
+
+			suite.Equal(tc.expectedUserFn(actualUser), actualUser)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestGetUserByUserName() {
+	testCases := []struct {
+		description  string
+		argsUserName string
+		expectedUser user.User
+		expectedErr  error
+	}{
+		{
+			description:  "ShouldFetchUser",
+			argsUserName: suite.testUsers[WillFetchedEntityIdx].UserName,
+			expectedUser: suite.testUsers[WillFetchedEntityIdx],
+			expectedErr:  nil,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr",
+			argsUserName: user.EmptyUser.UserName,
+			expectedUser: user.EmptyUser,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualUser, actualErr := suite.repo.GetUserByUserName(context.Background(), tc.argsUserName)
+
+			suite.Equal(tc.expectedUser, actualUser)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestGetUserByID() {
+	testCases := []struct {
+		description  string
+		argsUserID   int64
+		expectedUser user.User
+		expectedErr  error
+	}{
+		{
+			description:  "ShouldFetchUser",
+			argsUserID:   suite.testUsers[WillFetchedEntityIdx].ID,
+			expectedUser: suite.testUsers[WillFetchedEntityIdx],
+			expectedErr:  nil,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr",
+			argsUserID:   user.EmptyUser.ID,
+			expectedUser: user.EmptyUser,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualUser, actualErr := suite.repo.GetUserByUserID(context.Background(), tc.argsUserID)
+
+			suite.Equal(tc.expectedUser, actualUser)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestUpdateUserByID() {
+	testCases := []struct {
+		description  string
+		argsUserID   int64
+		argsUser     user.User
+		expectedUser user.User
+		expectedErr  error
+	}{
+		{
+			description: "ShouldUpdateUser",
+			argsUserID:  suite.testUsers[WillUpdatedEntityIdx].ID,
+			argsUser: user.User{
+				UserName: "willUpdateUserName",
+			},
+			expectedUser: user.User{
+				ID:                 suite.testUsers[WillUpdatedEntityIdx].ID,
+				UserName:           "willUpdateUserName",
+				PasswordHash:       suite.testUsers[WillUpdatedEntityIdx].PasswordHash,
+				Role:               suite.testUsers[WillUpdatedEntityIdx].Role,
+				CreatedAt:          suite.testUsers[WillUpdatedEntityIdx].CreatedAt,
+				RecoveryCodeHashes: suite.testUsers[WillUpdatedEntityIdx].RecoveryCodeHashes,
+			},
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnConflictErr_WhenAlreadyExistsUserName",
+			argsUserID:  suite.testUsers[WillUpdatedEntityIdx].ID,
+			argsUser: user.User{
+				UserName: suite.testUsers[WillFetchedEntityIdx].UserName,
+			},
+			expectedUser: user.EmptyUser,
+			expectedErr:  common.ErrAlreadyExistsEntity,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr",
+			argsUserID:   user.EmptyUser.ID,
+			argsUser:     user.EmptyUser,
+			expectedUser: user.EmptyUser,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualUser, actualErr := suite.repo.UpdateUserByUserID(context.Background(),
+				tc.argsUserID, tc.argsUser,
+			)
+
+			suite.Equal(tc.expectedUser, actualUser)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestRemoveUserByID() {
+	testCases := []struct {
+		description  string
+		argsUserID   int64
+		expectedUser user.User
+		expectedErr  error
+	}{
+		{
+			description:  "ShouldRemoveUser",
+			argsUserID:   suite.testUsers[WillRemovedEntityIdx].ID,
+			expectedUser: suite.testUsers[WillRemovedEntityIdx],
+			expectedErr:  nil,
+		},
+		{
+			description:  "ShouldReturnNotFoundErr",
+			argsUserID:   user.EmptyUser.ID,
+			expectedUser: user.EmptyUser,
+			expectedErr:  common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualUser, actualErr := suite.repo.RemoveUserByUserID(context.Background(), tc.argsUserID)
+
+			suite.Equal(tc.expectedUser, actualUser)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *repoSuite) TestUpdateTOTPByUserID() {
+	ctx := context.Background()
+	userID := suite.testUsers[WillFetchedEntityIdx].ID
+
+	enabledUser, err := suite.repo.UpdateTOTPByUserID(ctx, userID, "SECRET", true, []string{"first", "second"})
+	suite.NoError(err)
+	suite.Equal("SECRET", enabledUser.TOTPSecret)
+	suite.True(enabledUser.TOTPEnabled)
+
+	disabledUser, err := suite.repo.UpdateTOTPByUserID(ctx, userID, "", false, nil)
+	suite.NoError(err)
+	suite.Empty(disabledUser.TOTPSecret)
+	suite.False(disabledUser.TOTPEnabled)
+	suite.Empty(disabledUser.RecoveryCodeHashes)
+
+	_, err = suite.repo.UpdateTOTPByUserID(ctx, user.EmptyUser.ID, "SECRET", true, nil)
+	suite.Equal(common.ErrEntityNotFound, err)
+}
+
+func (suite *repoSuite) TestRemoveRecoveryCodeByUserID() {
+	ctx := context.Background()
+	userID := suite.testUsers[WillFetchedEntityIdx].ID
+
+	_, err := suite.repo.UpdateTOTPByUserID(ctx, userID, "SECRET", true, []string{"first", "second"})
+	suite.NoError(err)
+	defer suite.repo.UpdateTOTPByUserID(ctx, userID, "", false, nil)
+
+	testCases := []struct {
+		description string
+		codeHash    string
+		expectedErr error
+	}{
+		{
+			description: "ShouldRemoveCode",
+			codeHash:    "first",
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnNotFoundErr_WhenCodeUsed",
+			codeHash:    "first",
+			expectedErr: common.ErrEntityNotFound,
+		},
+		{
+			description: "ShouldReturnNotFoundErr_WhenUnknownCode",
+			codeHash:    "unknown",
+			expectedErr: common.ErrEntityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actualErr := suite.repo.RemoveRecoveryCodeByUserID(ctx, userID, tc.codeHash)
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+
+	fetchedUser, err := suite.repo.GetUserByUserID(ctx, userID)
+	suite.NoError(err)
+	suite.Equal([]string{"second"}, []string(fetchedUser.RecoveryCodeHashes))
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+
+	"github.com/gghcode/go-gin-starterkit/tracing"
+)
+
+// tracedRepository record span of each user query,
+// including totp and recovery code updates of mfa.
+type tracedRepository struct {
+	next Repository
+}
+
+func newTracedRepository(next Repository) Repository {
+	return &tracedRepository{next: next}
+}
+
+func (repo *tracedRepository) CreateUser(ctx context.Context, user User) (User, error) {
+	var result User
+	err := tracing.Trace(ctx, "user.Repository/CreateUser", func(ctx context.Context) (err error) {
+		result, err = repo.next.CreateUser(ctx, user)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetUserByUserName(ctx context.Context, userName string) (User, error) {
+	var result User
+	err := tracing.Trace(ctx, "user.Repository/GetUserByUserName", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetUserByUserName(ctx, userName)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) GetUserByUserID(ctx context.Context, userID int64) (User, error) {
+	var result User
+	err := tracing.Trace(ctx, "user.Repository/GetUserByUserID", func(ctx context.Context) (err error) {
+		result, err = repo.next.GetUserByUserID(ctx, userID)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) UpdateUserByUserID(ctx context.Context, userID int64, user User) (User, error) {
+	var result User
+	err := tracing.Trace(ctx, "user.Repository/UpdateUserByUserID", func(ctx context.Context) (err error) {
+		result, err = repo.next.UpdateUserByUserID(ctx, userID, user)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) RemoveUserByUserID(ctx context.Context, userID int64) (User, error) {
+	var result User
+	err := tracing.Trace(ctx, "user.Repository/RemoveUserByUserID", func(ctx context.Context) (err error) {
+		result, err = repo.next.RemoveUserByUserID(ctx, userID)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) UpdateTOTPByUserID(ctx context.Context, userID int64, secret string, enabled bool, recoveryCodeHashes []string) (User, error) {
+	var result User
+	err := tracing.Trace(ctx, "user.Repository/UpdateTOTPByUserID", func(ctx context.Context) (err error) {
+		result, err = repo.next.UpdateTOTPByUserID(ctx, userID, secret, enabled, recoveryCodeHashes)
+		return err
+	})
+
+	return result, err
+}
+
+func (repo *tracedRepository) RemoveRecoveryCodeByUserID(ctx context.Context, userID int64, codeHash string) error {
+	return tracing.Trace(ctx, "user.Repository/RemoveRecoveryCodeByUserID", func(ctx context.Context) error {
+		return repo.next.RemoveRecoveryCodeByUserID(ctx, userID, codeHash)
+	})
+}
@@ -0,0 +1,78 @@
+package wellknown
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/api/auth"
+	"github.com/gghcode/go-gin-starterkit/api/oauth"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+)
+
+// APIPath is path prefix
+const APIPath = "/.well-known/"
+
+// Controller serves well-known documents of server.
+type Controller struct {
+	keys   service.KeyProvider
+	issuer string
+}
+
+// NewController return new well-known controller instance.
+func NewController(conf config.Configuration, keys service.KeyProvider) *Controller {
+	return &Controller{
+		keys:   keys,
+		issuer: strings.TrimSuffix(conf.OAuth.Issuer, "/"),
+	}
+}
+
+// RegisterRoutes register handler routes.
+func (controller *Controller) RegisterRoutes(router *api.Router) {
+	router.Handle("GET", APIPath+"jwks.json", controller.getJWKS)
+	router.Handle("GET", APIPath+"openid-configuration", controller.getOpenIDConfiguration)
+}
+
+// @Description Get public keys that verify issued tokens
+// @Produce json
+// @Success 200 {object} service.JSONWebKeySet "ok"
+// @Tags Well-Known API
+// @Router /.well-known/jwks.json [get]
+func (controller *Controller) getJWKS(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, controller.keys.PublicKeys())
+}
+
+// @Description Get OpenID Connect discovery document of authorization server
+// @Produce json
+// @Success 200 {object} wellknown.OpenIDConfiguration "ok"
+// @Tags Well-Known API
+// @Router /.well-known/openid-configuration [get]
+func (controller *Controller) getOpenIDConfiguration(ctx *gin.Context) {
+	res := OpenIDConfiguration{
+		Issuer:                            controller.issuer,
+		AuthorizationEndpoint:             controller.apiURL(oauth.APIPath, "authorize"),
+		TokenEndpoint:                     controller.apiURL(oauth.APIPath, "token"),
+		UserInfoEndpoint:                  controller.apiURL(oauth.APIPath, "userinfo"),
+		JWKSURI:                           controller.issuer + APIPath + "jwks.json",
+		IntrospectionEndpoint:             controller.apiURL(auth.APIPath, "introspect"),
+		RevocationEndpoint:                controller.apiURL(auth.APIPath, "revoke"),
+		ScopesSupported:                   service.Scopes,
+		ResponseTypesSupported:            []string{oauth.ResponseTypeCode},
+		GrantTypesSupported:               []string{oauth.GrantTypeAuthorizationCode, oauth.GrantTypeRefreshToken, oauth.GrantTypeClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{controller.keys.Algorithm()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{oauth.CodeChallengeMethodS256},
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "exp", "iat", "nonce", "preferred_username"},
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// apiURL return absolute url of api route under issuer.
+func (controller *Controller) apiURL(apiPath, route string) string {
+	return controller.issuer + path.Join(api.BasePath, apiPath, route)
+}
@@ -70,9 +70,48 @@ func bindEnvsToViper(viperObj *viper.Viper, iface interface{}, parts ...string)
 	}
 }
 
+func setDefaults(viperObj *viper.Viper) {
+	viperObj.SetDefault("server.read_timeout_sec", 15)
+	viperObj.SetDefault("server.write_timeout_sec", 15)
+	viperObj.SetDefault("server.idle_timeout_sec", 60)
+	viperObj.SetDefault("server.shutdown_grace_sec", 30)
+	viperObj.SetDefault("log.level", "info")
+	viperObj.SetDefault("log.format", "json")
+	viperObj.SetDefault("metrics.path", "/metrics")
+	viperObj.SetDefault("tracing.exporter", "none")
+	viperObj.SetDefault("tracing.endpoint", "http://localhost:4318")
+	viperObj.SetDefault("tracing.service_name", "go-gin-starterkit")
+	viperObj.SetDefault("timeout.postgres_ms", 5000)
+	viperObj.SetDefault("timeout.redis_ms", 1000)
+	viperObj.SetDefault("rate_limit.storage", "redis")
+	viperObj.SetDefault("rate_limit.token.limit", 10)
+	viperObj.SetDefault("rate_limit.token.window_sec", 60)
+	viperObj.SetDefault("rate_limit.refresh.limit", 30)
+	viperObj.SetDefault("rate_limit.refresh.window_sec", 60)
+	viperObj.SetDefault("rate_limit.mfa.limit", 5)
+	viperObj.SetDefault("rate_limit.mfa.window_sec", 300)
+	viperObj.SetDefault("rate_limit.signup.limit", 5)
+	viperObj.SetDefault("rate_limit.signup.window_sec", 3600)
+	viperObj.SetDefault("rate_limit.user.limit", 600)
+	viperObj.SetDefault("rate_limit.user.window_sec", 60)
+	viperObj.SetDefault("rate_limit.password_reset.limit", 5)
+	viperObj.SetDefault("rate_limit.password_reset.window_sec", 3600)
+	viperObj.SetDefault("lockout.user_threshold", 5)
+	viperObj.SetDefault("lockout.ip_threshold", 20)
+	viperObj.SetDefault("lockout.window_sec", 900)
+	viperObj.SetDefault("lockout.lock_sec", 60)
+	viperObj.SetDefault("lockout.max_lock_sec", 3600)
+	viperObj.SetDefault("password_reset.expires_sec", 900)
+	viperObj.SetDefault("mfa.issuer", "go-gin-starterkit")
+	viperObj.SetDefault("mfa.challenge_expires_sec", 300)
+	viperObj.SetDefault("oauth.code_expires_sec", 60)
+	viperObj.SetDefault("oauth.issuer", "http://localhost:8080")
+}
+
 // Build return new configuration instance.
 func (builder *Builder) Build() (Configuration, error) {
 	viperObj := viper.New()
+	setDefaults(viperObj)
 
 	for _, pipeline := range builder.pipelines {
 		if err := pipeline(viperObj); err != nil {
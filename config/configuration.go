@@ -1,11 +1,38 @@
 package config
 
+// StorageMemory is storage that keeps every data in process
+// so app runs without postgres and redis.
+const StorageMemory = "memory"
+
 // Configuration is config type.
 type Configuration struct {
-	Addr     string         `mapstructure:"addr"`
-	Postgres PostgresConfig `mapstructure:"postgres"`
-	Jwt      JwtConfig      `mapstructure:"jwt"`
-	Redis    RedisConfig    `mapstructure:"redis"`
+	Addr          string              `mapstructure:"addr"`
+	Storage       string              `mapstructure:"storage"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Postgres      PostgresConfig      `mapstructure:"postgres"`
+	Jwt           JwtConfig           `mapstructure:"jwt"`
+	Redis         RedisConfig         `mapstructure:"redis"`
+	Health        HealthConfig        `mapstructure:"health"`
+	Log           LogConfig           `mapstructure:"log"`
+	Metrics       MetricsConfig       `mapstructure:"metrics"`
+	Tracing       TracingConfig       `mapstructure:"tracing"`
+	Timeout       TimeoutConfig       `mapstructure:"timeout"`
+	RateLimit     RateLimitConfig     `mapstructure:"rate_limit"`
+	Lockout       LockoutConfig       `mapstructure:"lockout"`
+	Notifier      NotifierConfig      `mapstructure:"notifier"`
+	PasswordReset PasswordResetConfig `mapstructure:"password_reset"`
+	MFA           MFAConfig           `mapstructure:"mfa"`
+	OAuth         OAuthConfig         `mapstructure:"oauth"`
+}
+
+// ServerConfig is http server config
+type ServerConfig struct {
+	ReadTimeoutSec   int64  `mapstructure:"read_timeout_sec"`
+	WriteTimeoutSec  int64  `mapstructure:"write_timeout_sec"`
+	IdleTimeoutSec   int64  `mapstructure:"idle_timeout_sec"`
+	ShutdownDelaySec int64  `mapstructure:"shutdown_delay_sec"`
+	ShutdownGraceSec int64  `mapstructure:"shutdown_grace_sec"`
+	TrustedProxies   string `mapstructure:"trusted_proxies"`
 }
 
 // PostgresConfig is postgres config
@@ -20,12 +47,122 @@ type PostgresConfig struct {
 
 // JwtConfig is jwt config
 type JwtConfig struct {
-	SecretKey           string `mapstructure:"secret_key"`
-	AccessExpiresInSec  int64  `mapstructure:"access_expires_sec"`
-	RefreshExpiresInSec int64  `mapstructure:"refresh_expires_sec"`
+	SecretKey           string         `mapstructure:"secret_key"`
+	Algorithm           string         `mapstructure:"algorithm"`
+	SigningKeyID        string         `mapstructure:"signing_key_id"`
+	Keys                []JwtKeyConfig `mapstructure:"keys"`
+	AccessExpiresInSec  int64          `mapstructure:"access_expires_sec"`
+	RefreshExpiresInSec int64          `mapstructure:"refresh_expires_sec"`
+}
+
+// JwtKeyConfig is config of PEM encoded jwt key.
+// RetiresAt is RFC3339 time after which the key no longer verifies tokens.
+type JwtKeyConfig struct {
+	ID        string `mapstructure:"id"`
+	File      string `mapstructure:"file"`
+	RetiresAt string `mapstructure:"retires_at"`
 }
 
 // RedisConfig is redis config
 type RedisConfig struct {
 	Addr string `mapstructure:"addr"`
 }
+
+// HealthConfig is health check config
+type HealthConfig struct {
+	CheckTimeoutMs int64 `mapstructure:"check_timeout_ms"`
+}
+
+// LogConfig is logger config
+// Level is one of panic, fatal, error, warn, info, debug and trace.
+// Format is json or text.
+type LogConfig struct {
+	Level  string `mapstructure:"level"`
+	Format string `mapstructure:"format"`
+}
+
+// MetricsConfig is prometheus metrics config
+// Endpoint is served by separate admin listener when Addr is set.
+type MetricsConfig struct {
+	Path string `mapstructure:"path"`
+	Addr string `mapstructure:"addr"`
+}
+
+// TracingConfig is tracing config
+// Exporter is one of none, stdout, file and otlp.
+// File is path that file exporter appends spans to.
+// Endpoint is base url of otlp/http collector such as http://localhost:4318.
+type TracingConfig struct {
+	Exporter    string `mapstructure:"exporter"`
+	File        string `mapstructure:"file"`
+	Endpoint    string `mapstructure:"endpoint"`
+	ServiceName string `mapstructure:"service_name"`
+}
+
+// TimeoutConfig is timeout of each postgres query and redis command in milliseconds.
+// Zero disables timeout.
+type TimeoutConfig struct {
+	PostgresMs int `mapstructure:"postgres_ms"`
+	RedisMs    int `mapstructure:"redis_ms"`
+}
+
+// RateLimitConfig is rate limit config of routes.
+// Storage is redis that is shared by instances or memory that is kept per instance.
+// Token, Refresh, MFA, Signup and PasswordReset limit clients by ip
+// and User limits authenticated routes by user id.
+type RateLimitConfig struct {
+	Storage       string          `mapstructure:"storage"`
+	Token         RateLimitPolicy `mapstructure:"token"`
+	Refresh       RateLimitPolicy `mapstructure:"refresh"`
+	MFA           RateLimitPolicy `mapstructure:"mfa"`
+	Signup        RateLimitPolicy `mapstructure:"signup"`
+	User          RateLimitPolicy `mapstructure:"user"`
+	PasswordReset RateLimitPolicy `mapstructure:"password_reset"`
+}
+
+// RateLimitPolicy allow Limit requests within sliding window of WindowSec.
+// Zero limit disables policy.
+type RateLimitPolicy struct {
+	Limit     int64 `mapstructure:"limit"`
+	WindowSec int64 `mapstructure:"window_sec"`
+}
+
+// LockoutConfig is lockout config of failed logins.
+// Username or ip is locked for LockSec after threshold failures within WindowSec,
+// and lock doubles on each further failure up to MaxLockSec.
+// Zero threshold disables lockout.
+type LockoutConfig struct {
+	UserThreshold int64 `mapstructure:"user_threshold"`
+	IPThreshold   int64 `mapstructure:"ip_threshold"`
+	WindowSec     int64 `mapstructure:"window_sec"`
+	LockSec       int64 `mapstructure:"lock_sec"`
+	MaxLockSec    int64 `mapstructure:"max_lock_sec"`
+}
+
+// NotifierConfig is config of notifier that delivers messages to users.
+// Type is log or file that appends notifications to File.
+type NotifierConfig struct {
+	Type string `mapstructure:"type"`
+	File string `mapstructure:"file"`
+}
+
+// PasswordResetConfig is config of password reset tokens.
+type PasswordResetConfig struct {
+	ExpiresSec int64 `mapstructure:"expires_sec"`
+}
+
+// MFAConfig is config of two factor authentication.
+// Issuer is account issuer shown by authenticator apps
+// and challenge of two step login expires after ChallengeExpiresSec.
+type MFAConfig struct {
+	Issuer              string `mapstructure:"issuer"`
+	ChallengeExpiresSec int64  `mapstructure:"challenge_expires_sec"`
+}
+
+// OAuthConfig is config of oauth2 authorization server.
+// Authorization code expires after CodeExpiresSec.
+// Issuer is base url of server that OpenID Connect clients discover it by.
+type OAuthConfig struct {
+	CodeExpiresSec int64  `mapstructure:"code_expires_sec"`
+	Issuer         string `mapstructure:"issuer"`
+}
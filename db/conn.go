@@ -1,16 +1,27 @@
 package db
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/health"
+	"github.com/gghcode/go-gin-starterkit/logging"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 
 	_ "github.com/lib/pq"
 )
 
+const contextScopeKey = "app:context"
+
+var registerCallbacksOnce sync.Once
+
 // Conn is object that has database connection.
 type Conn struct {
-	db *gorm.DB
+	db      *gorm.DB
+	timeout time.Duration
 }
 
 // NewConn return new instance.
@@ -27,8 +38,14 @@ func NewConn(config config.Configuration) (*Conn, error) {
 		return nil, errors.Wrap(err, "db connect failed...")
 	}
 
+	// connections bound to context share default callbacks with db.
+	registerCallbacksOnce.Do(func() {
+		registerErrorCallbacks(gorm.DefaultCallback)
+	})
+
 	return &Conn{
-		db: db,
+		db:      db,
+		timeout: time.Duration(config.Timeout.PostgresMs) * time.Millisecond,
 	}, nil
 }
 
@@ -41,7 +58,66 @@ func (conn *Conn) GetDB() *gorm.DB {
 	return conn.db
 }
 
+// WithTimeout return ctx bounded by configured timeout of operation.
+func (conn *Conn) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, conn.timeout)
+}
+
+// WithContext return database connection whose queries are canceled when ctx is done
+// and whose failed queries are logged with logger of ctx that carries request id.
+func (conn *Conn) WithContext(ctx context.Context) *gorm.DB {
+	db, _ := gorm.Open(conn.GetDB().Dialect().GetName(), &contextDB{
+		db:  conn.GetDB().DB(),
+		ctx: ctx,
+	})
+
+	return db.Set(contextScopeKey, ctx)
+}
+
 // Close close db session.
 func (conn *Conn) Close() error {
 	return conn.GetDB().Close()
 }
+
+// RegisterHealthCheck register checker that pings postgres.
+func (conn *Conn) RegisterHealthCheck(registry health.Registry, timeout time.Duration) {
+	registry.Register("postgres", timeout, func(ctx context.Context) error {
+		return conn.GetDB().DB().PingContext(ctx)
+	})
+}
+
+func registerErrorCallbacks(callback *gorm.Callback) {
+	callback.Create().Register("app:handle_error", handleScopeErr)
+	callback.Query().Register("app:handle_error", handleScopeErr)
+	callback.Update().Register("app:handle_error", handleScopeErr)
+	callback.Delete().Register("app:handle_error", handleScopeErr)
+	callback.RowQuery().After("gorm:row_query").Register("app:handle_error", handleScopeErr)
+}
+
+// handleScopeErr replace error of query canceled by ctx with ErrTimeout or ErrCanceled
+// and log failed query with logger of ctx that carries request id.
+func handleScopeErr(scope *gorm.Scope) {
+	err := scope.DB().Error
+	if err == nil || err == gorm.ErrRecordNotFound {
+		return
+	}
+
+	ctx := context.Background()
+	if value, ok := scope.Get(contextScopeKey); ok {
+		ctx = value.(context.Context)
+	}
+
+	err = ContextErr(ctx, err)
+	scope.DB().Error = err
+
+	entry := logging.FromContext(ctx).
+		WithError(err).
+		WithField("table", scope.TableName())
+
+	if err == ErrTimeout || err == ErrCanceled {
+		entry.Warn("postgres query aborted")
+		return
+	}
+
+	entry.Error("postgres query failed")
+}
@@ -0,0 +1,335 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/health"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrWrongType is occurred when operation is against key holding other kind of value.
+var ErrWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+// ErrNotInteger is occurred when incremented value is not integer.
+var ErrNotInteger = errors.New("ERR value is not an integer or out of range")
+
+const memoryRedisSweepInterval = time.Minute
+
+type memoryEntry struct {
+	value     string
+	members   map[string]struct{}
+	hits      []time.Time
+	expiresAt time.Time
+}
+
+func (entry memoryEntry) isString() bool {
+	return entry.members == nil && entry.hits == nil
+}
+
+func (entry memoryEntry) isExpired(now time.Time) bool {
+	return !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt)
+}
+
+type memoryRedisConn struct {
+	mutex   sync.Mutex
+	entries map[string]memoryEntry
+	done    chan struct{}
+}
+
+// NewMemoryRedisConn return new in-process key value store
+// that behaves like redis for commands of RedisConn.
+func NewMemoryRedisConn() RedisConn {
+	conn := memoryRedisConn{
+		entries: map[string]memoryEntry{},
+		done:    make(chan struct{}),
+	}
+
+	go conn.sweep()
+
+	return &conn
+}
+
+func (conn *memoryRedisConn) Get(ctx context.Context, key string) (string, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if !ok {
+		return "", ErrNil
+	}
+
+	if !entry.isString() {
+		return "", ErrWrongType
+	}
+
+	return entry.value, nil
+}
+
+func (conn *memoryRedisConn) Set(ctx context.Context, key, value string, expiration time.Duration) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry := memoryEntry{value: value}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	conn.entries[key] = entry
+
+	return nil
+}
+
+func (conn *memoryRedisConn) GetSet(ctx context.Context, key, value string) (string, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if ok && !entry.isString() {
+		return "", ErrWrongType
+	}
+
+	conn.entries[key] = memoryEntry{value: value}
+
+	if !ok {
+		return "", ErrNil
+	}
+
+	return entry.value, nil
+}
+
+func (conn *memoryRedisConn) Incr(ctx context.Context, key string) (int64, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if ok && !entry.isString() {
+		return 0, ErrWrongType
+	}
+
+	var value int64
+	if ok {
+		parsed, err := strconv.ParseInt(entry.value, 10, 64)
+		if err != nil {
+			return 0, ErrNotInteger
+		}
+
+		value = parsed
+	}
+
+	value++
+
+	// expiration of key is kept like redis does.
+	entry.value = strconv.FormatInt(value, 10)
+	conn.entries[key] = entry
+
+	return value, nil
+}
+
+func (conn *memoryRedisConn) Del(ctx context.Context, keys ...string) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(conn.entries, key)
+	}
+
+	return nil
+}
+
+func (conn *memoryRedisConn) Exists(ctx context.Context, keys ...string) (int64, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	var count int64
+	for _, key := range keys {
+		if _, ok := conn.lookup(key); ok {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (conn *memoryRedisConn) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if !ok {
+		return nil
+	}
+
+	if expiration <= 0 {
+		delete(conn.entries, key)
+		return nil
+	}
+
+	entry.expiresAt = time.Now().Add(expiration)
+	conn.entries[key] = entry
+
+	return nil
+}
+
+func (conn *memoryRedisConn) SAdd(ctx context.Context, key string, members ...string) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if ok && entry.members == nil {
+		return ErrWrongType
+	}
+
+	if !ok {
+		entry = memoryEntry{members: map[string]struct{}{}}
+	}
+
+	for _, member := range members {
+		entry.members[member] = struct{}{}
+	}
+
+	conn.entries[key] = entry
+
+	return nil
+}
+
+func (conn *memoryRedisConn) SRem(ctx context.Context, key string, members ...string) error {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if !ok {
+		return nil
+	}
+
+	if entry.members == nil {
+		return ErrWrongType
+	}
+
+	for _, member := range members {
+		delete(entry.members, member)
+	}
+
+	if len(entry.members) == 0 {
+		delete(conn.entries, key)
+	}
+
+	return nil
+}
+
+func (conn *memoryRedisConn) SMembers(ctx context.Context, key string) ([]string, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if !ok {
+		return []string{}, nil
+	}
+
+	if entry.members == nil {
+		return nil, ErrWrongType
+	}
+
+	result := make([]string, 0, len(entry.members))
+	for member := range entry.members {
+		result = append(result, member)
+	}
+
+	return result, nil
+}
+
+func (conn *memoryRedisConn) SlidingWindow(ctx context.Context, key string, limit int64, window time.Duration) (RateLimit, error) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	entry, ok := conn.lookup(key)
+	if ok && entry.hits == nil {
+		return RateLimit{}, ErrWrongType
+	}
+
+	now := time.Now()
+
+	// hits are appended in order, so expired ones are prefix of them.
+	hits := entry.hits
+	for len(hits) > 0 && !hits[0].After(now.Add(-window)) {
+		hits = hits[1:]
+	}
+
+	allowed := int64(len(hits)) < limit
+	if allowed {
+		hits = append(hits, now)
+	}
+
+	result := RateLimit{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: limit - int64(len(hits)),
+	}
+
+	if len(hits) == 0 {
+		delete(conn.entries, key)
+		return result, nil
+	}
+
+	result.ResetAfter = hits[0].Add(window).Sub(now)
+	conn.entries[key] = memoryEntry{
+		hits:      append([]time.Time{}, hits...),
+		expiresAt: now.Add(window),
+	}
+
+	return result, nil
+}
+
+// RegisterHealthCheck register nothing because store lives in process.
+func (conn *memoryRedisConn) RegisterHealthCheck(registry health.Registry, timeout time.Duration) {
+}
+
+// RegisterMetrics register nothing because store never fails on network.
+func (conn *memoryRedisConn) RegisterMetrics(registerer prometheus.Registerer) error {
+	return nil
+}
+
+// Close stop sweeping expired keys.
+func (conn *memoryRedisConn) Close() error {
+	close(conn.done)
+	return nil
+}
+
+// lookup return live entry of key and drop it when expired.
+// It must be called while holding mutex.
+func (conn *memoryRedisConn) lookup(key string) (memoryEntry, bool) {
+	entry, ok := conn.entries[key]
+	if !ok {
+		return memoryEntry{}, false
+	}
+
+	if entry.isExpired(time.Now()) {
+		delete(conn.entries, key)
+		return memoryEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (conn *memoryRedisConn) sweep() {
+	ticker := time.NewTicker(memoryRedisSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-conn.done:
+			return
+		case now := <-ticker.C:
+			conn.mutex.Lock()
+			for key, entry := range conn.entries {
+				if entry.isExpired(now) {
+					delete(conn.entries, key)
+				}
+			}
+			conn.mutex.Unlock()
+		}
+	}
+}
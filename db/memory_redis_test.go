@@ -0,0 +1,52 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/stretchr/testify/suite"
+)
+
+type memoryRedisUnit struct {
+	redisConnSuite
+}
+
+func TestMemoryRedisConnUnit(t *testing.T) {
+	suite.Run(t, new(memoryRedisUnit))
+}
+
+func (suite *memoryRedisUnit) SetupTest() {
+	suite.conn = db.NewMemoryRedisConn()
+}
+
+func (suite *memoryRedisUnit) TearDownTest() {
+	suite.conn.Close()
+}
+
+func (suite *memoryRedisUnit) TestWrongType() {
+	ctx := context.Background()
+
+	suite.NoError(suite.conn.SAdd(ctx, "set", "member"))
+	suite.NoError(suite.conn.Set(ctx, "string", "value", 0))
+
+	_, err := suite.conn.Get(ctx, "set")
+	suite.Equal(db.ErrWrongType, err)
+
+	suite.Equal(db.ErrWrongType, suite.conn.SAdd(ctx, "string", "member"))
+}
+
+func (suite *memoryRedisUnit) TestSlidingWindow_WrongType() {
+	ctx := context.Background()
+
+	suite.NoError(suite.conn.Set(ctx, "string", "value", 0))
+	_, err := suite.conn.SlidingWindow(ctx, "string", 1, time.Second)
+	suite.Equal(db.ErrWrongType, err)
+
+	_, err = suite.conn.SlidingWindow(ctx, "window", 1, time.Second)
+	suite.NoError(err)
+
+	_, err = suite.conn.Get(ctx, "window")
+	suite.Equal(db.ErrWrongType, err)
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterMetrics register collector of connection pool statistics.
+func (conn *Conn) RegisterMetrics(registerer prometheus.Registerer) error {
+	return registerer.Register(newDBStatsCollector(conn.GetDB().DB().Stats))
+}
+
+type dbStatsCollector struct {
+	stats func() sql.DBStats
+
+	maxOpen      *prometheus.Desc
+	open         *prometheus.Desc
+	inUse        *prometheus.Desc
+	idle         *prometheus.Desc
+	waitCount    *prometheus.Desc
+	waitDuration *prometheus.Desc
+}
+
+func newDBStatsCollector(stats func() sql.DBStats) prometheus.Collector {
+	return &dbStatsCollector{
+		stats: stats,
+		maxOpen: prometheus.NewDesc("db_max_open_connections",
+			"Maximum number of open connections to postgres.", nil, nil),
+		open: prometheus.NewDesc("db_open_connections",
+			"Number of established connections to postgres.", nil, nil),
+		inUse: prometheus.NewDesc("db_in_use_connections",
+			"Number of connections currently in use.", nil, nil),
+		idle: prometheus.NewDesc("db_idle_connections",
+			"Number of idle connections.", nil, nil),
+		waitCount: prometheus.NewDesc("db_wait_count_total",
+			"Total number of connections waited for.", nil, nil),
+		waitDuration: prometheus.NewDesc("db_wait_duration_seconds_total",
+			"Total time blocked waiting for new connection.", nil, nil),
+	}
+}
+
+func (collector *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.maxOpen
+	ch <- collector.open
+	ch <- collector.inUse
+	ch <- collector.idle
+	ch <- collector.waitCount
+	ch <- collector.waitDuration
+}
+
+func (collector *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := collector.stats()
+
+	ch <- prometheus.MustNewConstMetric(collector.maxOpen,
+		prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(collector.open,
+		prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(collector.inUse,
+		prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(collector.idle,
+		prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(collector.waitCount,
+		prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(collector.waitDuration,
+		prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type metricsUnit struct {
+	suite.Suite
+}
+
+func TestMetricsUnit(t *testing.T) {
+	suite.Run(t, new(metricsUnit))
+}
+
+func (suite *metricsUnit) TestDBStatsCollector() {
+	collector := newDBStatsCollector(func() sql.DBStats {
+		return sql.DBStats{
+			MaxOpenConnections: 10,
+			OpenConnections:    3,
+			InUse:              1,
+			Idle:               2,
+			WaitCount:          5,
+			WaitDuration:       1500 * time.Millisecond,
+		}
+	})
+
+	expected := `
+# HELP db_idle_connections Number of idle connections.
+# TYPE db_idle_connections gauge
+db_idle_connections 2
+# HELP db_in_use_connections Number of connections currently in use.
+# TYPE db_in_use_connections gauge
+db_in_use_connections 1
+# HELP db_max_open_connections Maximum number of open connections to postgres.
+# TYPE db_max_open_connections gauge
+db_max_open_connections 10
+# HELP db_open_connections Number of established connections to postgres.
+# TYPE db_open_connections gauge
+db_open_connections 3
+# HELP db_wait_count_total Total number of connections waited for.
+# TYPE db_wait_count_total counter
+db_wait_count_total 5
+# HELP db_wait_duration_seconds_total Total time blocked waiting for new connection.
+# TYPE db_wait_duration_seconds_total counter
+db_wait_duration_seconds_total 1.5
+`
+
+	suite.NoError(testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
+
+func (suite *metricsUnit) TestRedisCommandErrors() {
+	conn := NewRedisConn(config.Configuration{
+		Redis: config.RedisConfig{Addr: "127.0.0.1:1"},
+	}).(*redisConn)
+	defer conn.Close()
+
+	_, err := conn.Get(context.Background(), "key")
+	suite.Error(err)
+
+	suite.Equal(float64(1), testutil.ToFloat64(conn.errors.WithLabelValues("get")))
+	suite.Equal(float64(0), testutil.ToFloat64(conn.errors.WithLabelValues("set")))
+}
@@ -0,0 +1,103 @@
+package migration
+
+// Migrations is every schema change of app in version order.
+var Migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_users",
+		Up: `
+CREATE TABLE IF NOT EXISTS users (
+	id            bigserial PRIMARY KEY,
+	user_name     text NOT NULL UNIQUE,
+	password_hash bytea NOT NULL,
+	created_at    bigint NOT NULL
+);
+
+-- users created by AutoMigrate of earlier releases are kept and upgraded in place.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS user_name text UNIQUE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash bytea NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS created_at bigint NOT NULL DEFAULT 0;`,
+		Down: `
+DROP TABLE IF EXISTS users;`,
+	},
+	{
+		Version: 2,
+		Name:    "create_todos",
+		Up: `
+CREATE TABLE IF NOT EXISTS todos (
+	id         uuid PRIMARY KEY,
+	user_id    bigint NOT NULL,
+	title      text,
+	contents   text,
+	created_at bigint
+);
+
+-- todos created by AutoMigrate of earlier releases have no owner,
+-- so they are backfilled with user id 0 that no user has.
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS user_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE todos ALTER COLUMN user_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_todos_user_id ON todos (user_id);`,
+		Down: `
+DROP TABLE IF EXISTS todos;`,
+	},
+	{
+		Version: 3,
+		Name:    "add_users_role",
+		Up: `
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role text NOT NULL DEFAULT 'user';`,
+		Down: `
+ALTER TABLE users DROP COLUMN IF EXISTS role;`,
+	},
+	{
+		Version: 4,
+		Name:    "add_users_totp",
+		Up: `
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret text NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled boolean NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS recovery_code_hashes text[] NOT NULL DEFAULT '{}';`,
+		Down: `
+ALTER TABLE users DROP COLUMN IF EXISTS recovery_code_hashes;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;`,
+	},
+	{
+		Version: 5,
+		Name:    "create_api_keys",
+		Up: `
+CREATE TABLE IF NOT EXISTS api_keys (
+	id           bigserial PRIMARY KEY,
+	user_id      bigint NOT NULL,
+	name         text NOT NULL,
+	prefix       text NOT NULL UNIQUE,
+	key_hash     text NOT NULL,
+	scopes       text[] NOT NULL DEFAULT '{}',
+	expires_at   bigint NOT NULL DEFAULT 0,
+	last_used_at bigint NOT NULL DEFAULT 0,
+	created_at   bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);`,
+		Down: `
+DROP TABLE IF EXISTS api_keys;`,
+	},
+	{
+		Version: 6,
+		Name:    "create_oauth_clients",
+		Up: `
+CREATE TABLE IF NOT EXISTS oauth_clients (
+	id            bigserial PRIMARY KEY,
+	user_id       bigint NOT NULL,
+	client_id     text NOT NULL UNIQUE,
+	secret_hash   text NOT NULL DEFAULT '',
+	name          text NOT NULL,
+	redirect_uris text[] NOT NULL DEFAULT '{}',
+	scopes        text[] NOT NULL DEFAULT '{}',
+	created_at    bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_clients_user_id ON oauth_clients (user_id);`,
+		Down: `
+DROP TABLE IF EXISTS oauth_clients;`,
+	},
+}
@@ -0,0 +1,21 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/db/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	var prevVersion int64
+
+	for _, m := range migration.Migrations {
+		assert.True(t, m.Version > prevVersion, "version %d is not ascending", m.Version)
+		assert.NotEmpty(t, m.Name)
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+
+		prevVersion = m.Version
+	}
+}
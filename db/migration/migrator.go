@@ -0,0 +1,203 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/pkg/errors"
+)
+
+// lockID is key of postgres advisory lock that serializes migrators.
+const lockID = 7245091783341
+
+// Migration is versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is applied state of migration.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies migrations and tracks them in schema_migrations table.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator return new migrator of migrations.
+func NewMigrator(dbConn *db.Conn, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         dbConn.GetDB().DB(),
+		migrations: migrations,
+	}
+}
+
+// Up apply every pending migration and return applied ones.
+func (migrator *Migrator) Up() ([]Migration, error) {
+	var result []Migration
+
+	err := migrator.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrator.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			if err := apply(ctx, conn, migration.Up,
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+				migration.Version, migration.Name, time.Now().Unix(),
+			); err != nil {
+				return errors.Wrapf(err, "migration %d_%s failed", migration.Version, migration.Name)
+			}
+
+			result = append(result, migration)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Down revert latest n applied migrations and return reverted ones.
+func (migrator *Migrator) Down(n int) ([]Migration, error) {
+	var result []Migration
+
+	err := migrator.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrator.migrations) - 1; i >= 0 && len(result) < n; i-- {
+			migration := migrator.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			if err := apply(ctx, conn, migration.Down,
+				"DELETE FROM schema_migrations WHERE version = $1",
+				migration.Version,
+			); err != nil {
+				return errors.Wrapf(err, "migration %d_%s failed", migration.Version, migration.Name)
+			}
+
+			result = append(result, migration)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Status return applied state of every migration.
+func (migrator *Migrator) Status() ([]Status, error) {
+	var result []Status
+
+	err := migrator.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrator.migrations {
+			appliedAt, ok := applied[migration.Version]
+
+			status := Status{
+				Version: migration.Version,
+				Name:    migration.Name,
+				Applied: ok,
+			}
+
+			if ok {
+				status.AppliedAt = time.Unix(appliedAt, 0)
+			}
+
+			result = append(result, status)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func (migrator *Migrator) withLock(fn func(context.Context, *sql.Conn) error) error {
+	ctx := context.Background()
+
+	conn, err := migrator.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockID)
+
+	if _, err := conn.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    bigint PRIMARY KEY,
+	name       text NOT NULL,
+	applied_at bigint NOT NULL
+);`); err != nil {
+		return err
+	}
+
+	return fn(ctx, conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]int64, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int64]int64{}
+	for rows.Next() {
+		var version, appliedAt int64
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		result[version] = appliedAt
+	}
+
+	return result, rows.Err()
+}
+
+func apply(ctx context.Context, conn *sql.Conn, query, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
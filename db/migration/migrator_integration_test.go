@@ -0,0 +1,84 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/db/migration"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+var testMigrations = []migration.Migration{
+	{
+		Version: 900001,
+		Name:    "create_migration_tests",
+		Up:      "CREATE TABLE migration_tests (id bigserial PRIMARY KEY);",
+		Down:    "DROP TABLE migration_tests;",
+	},
+	{
+		Version: 900002,
+		Name:    "add_migration_tests_name",
+		Up:      "ALTER TABLE migration_tests ADD COLUMN name text;",
+		Down:    "ALTER TABLE migration_tests DROP COLUMN name;",
+	},
+}
+
+type migratorIntegration struct {
+	suite.Suite
+
+	dbConn   *db.Conn
+	migrator *migration.Migrator
+}
+
+func TestMigratorIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	suite.Run(t, new(migratorIntegration))
+}
+
+func (suite *migratorIntegration) SetupSuite() {
+	conf, err := config.NewBuilder().
+		BindEnvs("TEST").
+		Build()
+	require.NoError(suite.T(), err)
+
+	dbConn, err := db.NewConn(conf)
+	require.NoError(suite.T(), err)
+
+	suite.dbConn = dbConn
+	suite.migrator = migration.NewMigrator(dbConn, testMigrations)
+}
+
+func (suite *migratorIntegration) TearDownSuite() {
+	suite.migrator.Down(len(testMigrations))
+	suite.dbConn.Close()
+}
+
+func (suite *migratorIntegration) TestMigrate() {
+	applied, err := suite.migrator.Up()
+	suite.NoError(err)
+	suite.Len(applied, 2)
+
+	applied, err = suite.migrator.Up()
+	suite.NoError(err)
+	suite.Len(applied, 0)
+
+	reverted, err := suite.migrator.Down(1)
+	suite.NoError(err)
+	suite.Equal([]migration.Migration{testMigrations[1]}, reverted)
+
+	statuses, err := suite.migrator.Status()
+	suite.NoError(err)
+	suite.True(statuses[0].Applied)
+	suite.False(statuses[1].Applied)
+	suite.False(suite.dbConn.GetDB().Dialect().HasColumn("migration_tests", "name"))
+
+	reverted, err = suite.migrator.Down(5)
+	suite.NoError(err)
+	suite.Equal([]migration.Migration{testMigrations[0]}, reverted)
+	suite.False(suite.dbConn.GetDB().HasTable("migration_tests"))
+}
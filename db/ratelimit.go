@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+	uuid "github.com/satori/go.uuid"
+)
+
+// RateLimit is result of hit on sliding window.
+// ResetAfter is duration until the oldest hit in window expires.
+type RateLimit struct {
+	Allowed    bool
+	Limit      int64
+	Remaining  int64
+	ResetAfter time.Duration
+}
+
+// slidingWindowScript keeps hits of window in sorted set scored by microseconds
+// so that expired hits are dropped and new hit is counted atomically.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+
+local resetAfter = 0
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	resetAfter = tonumber(oldest[2]) + window - now
+	redis.call('PEXPIRE', KEYS[1], math.ceil(window / 1000))
+end
+
+return {allowed, count, resetAfter}
+`)
+
+func (conn *redisConn) SlidingWindow(ctx context.Context, key string, limit int64, window time.Duration) (RateLimit, error) {
+	ctx, span := startCommandSpan(ctx, "evalsha")
+	defer span.End()
+
+	now := time.Now()
+	result, err := slidingWindowScript.Run(conn.client.WithContext(ctx), []string{key},
+		toMicroseconds(now.Sub(time.Unix(0, 0))),
+		toMicroseconds(window),
+		limit,
+		uuid.NewV4().String(),
+	).Result()
+
+	if err = conn.observeErr(ctx, span, "evalsha", err); err != nil {
+		return RateLimit{}, err
+	}
+
+	values := result.([]interface{})
+	count := values[1].(int64)
+
+	return RateLimit{
+		Allowed:    values[0].(int64) == 1,
+		Limit:      limit,
+		Remaining:  limit - count,
+		ResetAfter: time.Duration(values[2].(int64)) * time.Microsecond,
+	}, nil
+}
+
+func toMicroseconds(d time.Duration) int64 {
+	return int64(d / time.Microsecond)
+}
@@ -1,30 +1,204 @@
 package db
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/health"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gghcode/go-gin-starterkit/tracing"
 	"github.com/go-redis/redis"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// ErrNil is occurred when key does not exist.
+var ErrNil = redis.Nil
+
 // RedisConn can access redis
 type RedisConn interface {
-	Client() *redis.Client
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string, expiration time.Duration) error
+	GetSet(ctx context.Context, key, value string) (string, error)
+	Incr(ctx context.Context, key string) (int64, error)
+	Del(ctx context.Context, keys ...string) error
+	Exists(ctx context.Context, keys ...string) (int64, error)
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+
+	SAdd(ctx context.Context, key string, members ...string) error
+	SRem(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+
+	// SlidingWindow record hit of key unless limit hits were recorded
+	// within window and return result of the hit.
+	SlidingWindow(ctx context.Context, key string, limit int64, window time.Duration) (RateLimit, error)
+
+	RegisterHealthCheck(registry health.Registry, timeout time.Duration)
+	RegisterMetrics(registerer prometheus.Registerer) error
+	Close() error
 }
 
 type redisConn struct {
 	client *redis.Client
+	errors *prometheus.CounterVec
+}
+
+func (conn *redisConn) Get(ctx context.Context, key string) (string, error) {
+	ctx, span := startCommandSpan(ctx, "get")
+	defer span.End()
+
+	result, err := conn.client.WithContext(ctx).Get(key).Result()
+	return result, conn.observeErr(ctx, span, "get", err)
+}
+
+func (conn *redisConn) Set(ctx context.Context, key, value string, expiration time.Duration) error {
+	ctx, span := startCommandSpan(ctx, "set")
+	defer span.End()
+
+	err := conn.client.WithContext(ctx).Set(key, value, expiration).Err()
+	return conn.observeErr(ctx, span, "set", err)
+}
+
+func (conn *redisConn) GetSet(ctx context.Context, key, value string) (string, error) {
+	ctx, span := startCommandSpan(ctx, "getset")
+	defer span.End()
+
+	result, err := conn.client.WithContext(ctx).GetSet(key, value).Result()
+	return result, conn.observeErr(ctx, span, "getset", err)
 }
 
-func (conn *redisConn) Client() *redis.Client {
-	return conn.client
+func (conn *redisConn) Incr(ctx context.Context, key string) (int64, error) {
+	ctx, span := startCommandSpan(ctx, "incr")
+	defer span.End()
+
+	result, err := conn.client.WithContext(ctx).Incr(key).Result()
+	return result, conn.observeErr(ctx, span, "incr", err)
+}
+
+func (conn *redisConn) Del(ctx context.Context, keys ...string) error {
+	ctx, span := startCommandSpan(ctx, "del")
+	defer span.End()
+
+	err := conn.client.WithContext(ctx).Del(keys...).Err()
+	return conn.observeErr(ctx, span, "del", err)
+}
+
+func (conn *redisConn) Exists(ctx context.Context, keys ...string) (int64, error) {
+	ctx, span := startCommandSpan(ctx, "exists")
+	defer span.End()
+
+	result, err := conn.client.WithContext(ctx).Exists(keys...).Result()
+	return result, conn.observeErr(ctx, span, "exists", err)
+}
+
+func (conn *redisConn) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	ctx, span := startCommandSpan(ctx, "expire")
+	defer span.End()
+
+	err := conn.client.WithContext(ctx).PExpire(key, expiration).Err()
+	return conn.observeErr(ctx, span, "expire", err)
+}
+
+func (conn *redisConn) SAdd(ctx context.Context, key string, members ...string) error {
+	ctx, span := startCommandSpan(ctx, "sadd")
+	defer span.End()
+
+	err := conn.client.WithContext(ctx).SAdd(key, toInterfaces(members)...).Err()
+	return conn.observeErr(ctx, span, "sadd", err)
+}
+
+func (conn *redisConn) SRem(ctx context.Context, key string, members ...string) error {
+	ctx, span := startCommandSpan(ctx, "srem")
+	defer span.End()
+
+	err := conn.client.WithContext(ctx).SRem(key, toInterfaces(members)...).Err()
+	return conn.observeErr(ctx, span, "srem", err)
+}
+
+func (conn *redisConn) SMembers(ctx context.Context, key string) ([]string, error) {
+	ctx, span := startCommandSpan(ctx, "smembers")
+	defer span.End()
+
+	result, err := conn.client.WithContext(ctx).SMembers(key).Result()
+	return result, conn.observeErr(ctx, span, "smembers", err)
+}
+
+// Close close redis client.
+func (conn *redisConn) Close() error {
+	return conn.client.Close()
+}
+
+// RegisterHealthCheck register checker that pings redis.
+func (conn *redisConn) RegisterHealthCheck(registry health.Registry, timeout time.Duration) {
+	registry.Register("redis", timeout, func(ctx context.Context) error {
+		return conn.client.WithContext(ctx).Ping().Err()
+	})
+}
+
+// RegisterMetrics register counter of failed commands.
+func (conn *redisConn) RegisterMetrics(registerer prometheus.Registerer) error {
+	return registerer.Register(conn.errors)
 }
 
 // NewRedisConn return new connection of redis
 func NewRedisConn(conf config.Configuration) RedisConn {
+	// go-redis ignores deadline of context,
+	// so each command is bounded by socket timeouts instead.
+	timeout := time.Duration(conf.Timeout.RedisMs) * time.Millisecond
+
 	conn := redisConn{
 		client: redis.NewClient(&redis.Options{
-			Addr: conf.Redis.Addr,
+			Addr:         conf.Redis.Addr,
+			ReadTimeout:  timeout,
+			WriteTimeout: timeout,
 		}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "redis_command_errors_total",
+			Help: "Total number of failed redis commands.",
+		}, []string{"command"}),
 	}
 
 	return &conn
 }
+
+// startCommandSpan start client span of command when ctx carries span.
+func startCommandSpan(ctx context.Context, command string) (context.Context, *tracing.Span) {
+	ctx, span := tracing.StartSpan(ctx, "redis "+strings.ToUpper(command), tracing.SpanKindClient)
+	span.SetAttribute("db.system", "redis")
+	span.SetAttribute("db.operation", command)
+
+	return ctx, span
+}
+
+// observeErr count, trace and log failed command
+// with logger of ctx that carries request id.
+// Timed out command is reported as ErrTimeout.
+func (conn *redisConn) observeErr(ctx context.Context, span *tracing.Span, command string, err error) error {
+	if err != nil && err != ErrNil {
+		err = ContextErr(ctx, err)
+		span.SetError(err)
+		conn.errors.WithLabelValues(command).Inc()
+
+		entry := logging.FromContext(ctx).
+			WithError(err).
+			WithField("command", command)
+
+		if err == ErrTimeout || err == ErrCanceled {
+			entry.Warn("redis command aborted")
+		} else {
+			entry.Error("redis command failed")
+		}
+	}
+
+	return err
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
@@ -1,7 +1,9 @@
 package db_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/gghcode/go-gin-starterkit/config"
 	"github.com/gghcode/go-gin-starterkit/db"
@@ -11,7 +13,7 @@ import (
 )
 
 type redisIntegration struct {
-	suite.Suite
+	redisConnSuite
 
 	conf config.Configuration
 }
@@ -24,6 +26,11 @@ func (suite *redisIntegration) SetupSuite() {
 	require.NoError(suite.T(), err)
 
 	suite.conf = conf
+	suite.conn = db.NewRedisConn(conf)
+}
+
+func (suite *redisIntegration) TearDownSuite() {
+	suite.conn.Close()
 }
 
 func TestRedisConnIntegration(t *testing.T) {
@@ -36,8 +43,8 @@ func TestRedisConnIntegration(t *testing.T) {
 
 func (suite *redisIntegration) TestNewRedisConn() {
 	conn := db.NewRedisConn(suite.conf)
-	pong, err := conn.Client().Ping().Result()
+	defer conn.Close()
 
-	assert.Equal(suite.T(), pong, "PONG")
+	err := conn.Set(context.Background(), "redis_integration_ping", "PONG", time.Second)
 	assert.Equal(suite.T(), err, nil)
 }
@@ -0,0 +1,136 @@
+package db_test
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/stretchr/testify/suite"
+)
+
+// redisConnSuite verifies commands of RedisConn
+// so every implementation behaves like redis.
+type redisConnSuite struct {
+	suite.Suite
+
+	conn db.RedisConn
+}
+
+func (suite *redisConnSuite) TestGetSet() {
+	ctx := context.Background()
+	key := "redisconn_test_string"
+	defer suite.conn.Del(ctx, key)
+
+	_, err := suite.conn.Get(ctx, key)
+	suite.Equal(db.ErrNil, err)
+
+	_, err = suite.conn.GetSet(ctx, key, "first")
+	suite.Equal(db.ErrNil, err)
+
+	prev, err := suite.conn.GetSet(ctx, key, "second")
+	suite.NoError(err)
+	suite.Equal("first", prev)
+
+	suite.NoError(suite.conn.Set(ctx, key, "third", 0))
+
+	actual, err := suite.conn.Get(ctx, key)
+	suite.NoError(err)
+	suite.Equal("third", actual)
+
+	suite.NoError(suite.conn.Del(ctx, key))
+
+	count, err := suite.conn.Exists(ctx, key)
+	suite.NoError(err)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *redisConnSuite) TestExpiration() {
+	ctx := context.Background()
+	key := "redisconn_test_expiration"
+	otherKey := "redisconn_test_expiration_other"
+	defer suite.conn.Del(ctx, key, otherKey)
+
+	suite.NoError(suite.conn.Set(ctx, key, "value", 100*time.Millisecond))
+	suite.NoError(suite.conn.Set(ctx, otherKey, "value", 0))
+	suite.NoError(suite.conn.Expire(ctx, otherKey, 100*time.Millisecond))
+
+	count, err := suite.conn.Exists(ctx, key, otherKey, "redisconn_test_unknown")
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+
+	time.Sleep(200 * time.Millisecond)
+
+	count, err = suite.conn.Exists(ctx, key, otherKey)
+	suite.NoError(err)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *redisConnSuite) TestSet() {
+	ctx := context.Background()
+	key := "redisconn_test_set"
+	defer suite.conn.Del(ctx, key)
+
+	members, err := suite.conn.SMembers(ctx, key)
+	suite.NoError(err)
+	suite.Empty(members)
+
+	suite.NoError(suite.conn.SAdd(ctx, key, "a", "b", "c"))
+	suite.NoError(suite.conn.SRem(ctx, key, "b"))
+
+	members, err = suite.conn.SMembers(ctx, key)
+	sort.Strings(members)
+	suite.NoError(err)
+	suite.Equal([]string{"a", "c"}, members)
+
+	suite.NoError(suite.conn.SRem(ctx, key, "a", "c"))
+
+	count, err := suite.conn.Exists(ctx, key)
+	suite.NoError(err)
+	suite.Equal(int64(0), count)
+}
+
+func (suite *redisConnSuite) TestSlidingWindow() {
+	ctx := context.Background()
+	key := "redisconn_test_sliding_window"
+	defer suite.conn.Del(ctx, key)
+
+	window := 200 * time.Millisecond
+
+	for i := int64(1); i <= 3; i++ {
+		result, err := suite.conn.SlidingWindow(ctx, key, 3, window)
+		suite.NoError(err)
+		suite.True(result.Allowed)
+		suite.Equal(int64(3), result.Limit)
+		suite.Equal(3-i, result.Remaining)
+		suite.True(result.ResetAfter > 0 && result.ResetAfter <= window)
+	}
+
+	result, err := suite.conn.SlidingWindow(ctx, key, 3, window)
+	suite.NoError(err)
+	suite.False(result.Allowed)
+	suite.Equal(int64(0), result.Remaining)
+
+	time.Sleep(result.ResetAfter + 50*time.Millisecond)
+
+	result, err = suite.conn.SlidingWindow(ctx, key, 3, window)
+	suite.NoError(err)
+	suite.True(result.Allowed)
+}
+
+func (suite *redisConnSuite) TestIncr() {
+	ctx := context.Background()
+	key := "redisconn_test_incr"
+	defer suite.conn.Del(ctx, key)
+
+	for i := int64(1); i <= 3; i++ {
+		actual, err := suite.conn.Incr(ctx, key)
+		suite.NoError(err)
+		suite.Equal(i, actual)
+	}
+
+	suite.NoError(suite.conn.Set(ctx, key, "value", 0))
+
+	_, err := suite.conn.Incr(ctx, key)
+	suite.Error(err)
+}
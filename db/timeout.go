@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrTimeout is occurred when operation exceeded its deadline.
+	ErrTimeout = errors.New("Operation timed out")
+
+	// ErrCanceled is occurred when operation was canceled
+	// such as client disconnected before response.
+	ErrCanceled = errors.New("Operation was canceled")
+)
+
+// ContextErr return ErrTimeout or ErrCanceled when err is caused by
+// done ctx or network timeout, otherwise err as it is.
+func ContextErr(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return ErrTimeout
+	case context.Canceled:
+		return ErrCanceled
+	}
+
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return ErrTimeout
+	}
+
+	return err
+}
+
+// withTimeout return ctx bounded by timeout. zero timeout only inherits deadline of ctx.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextDB bind queries of gorm to ctx, so they are canceled
+// when deadline exceeded or client disconnected.
+type contextDB struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func (c *contextDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) Prepare(query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(c.ctx, query)
+}
+
+func (c *contextDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(c.ctx, query, args...)
+}
+
+// Begin let gorm start transaction of create, update and delete bound to ctx.
+func (c *contextDB) Begin() (*sql.Tx, error) {
+	return c.db.BeginTx(c.ctx, nil)
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type timeoutUnit struct {
+	suite.Suite
+}
+
+func TestTimeoutUnit(t *testing.T) {
+	suite.Run(t, new(timeoutUnit))
+}
+
+type fakeNetErr struct{}
+
+func (fakeNetErr) Error() string   { return "i/o timeout" }
+func (fakeNetErr) Timeout() bool   { return true }
+func (fakeNetErr) Temporary() bool { return true }
+
+func (suite *timeoutUnit) TestContextErr() {
+	fakeErr := errors.New("fake error")
+
+	expiredCtx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		description string
+		ctx         context.Context
+		err         error
+		expectedErr error
+	}{
+		{
+			description: "ShouldReturnNil_WhenErrIsNil",
+			ctx:         expiredCtx,
+			err:         nil,
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnErrTimeout_WhenDeadlineExceeded",
+			ctx:         expiredCtx,
+			err:         fakeErr,
+			expectedErr: ErrTimeout,
+		},
+		{
+			description: "ShouldReturnErrCanceled_WhenCanceled",
+			ctx:         canceledCtx,
+			err:         fakeErr,
+			expectedErr: ErrCanceled,
+		},
+		{
+			description: "ShouldReturnErrTimeout_WhenNetworkTimeout",
+			ctx:         context.Background(),
+			err:         fakeNetErr{},
+			expectedErr: ErrTimeout,
+		},
+		{
+			description: "ShouldReturnErr_WhenContextIsAlive",
+			ctx:         context.Background(),
+			err:         fakeErr,
+			expectedErr: fakeErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			suite.Equal(tc.expectedErr, ContextErr(tc.ctx, tc.err))
+		})
+	}
+}
+
+func (suite *timeoutUnit) TestRedisCommand_ShouldReturnErrTimeout_WhenServerHangs() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer listener.Close()
+
+	// accept connections but never respond.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	conn := NewRedisConn(config.Configuration{
+		Redis:   config.RedisConfig{Addr: listener.Addr().String()},
+		Timeout: config.TimeoutConfig{RedisMs: 50},
+	})
+	defer conn.Close()
+
+	_, err = conn.Get(context.Background(), "key")
+	suite.Equal(ErrTimeout, err)
+}
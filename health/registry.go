@@ -0,0 +1,173 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// StatusUp is status of available dependency.
+	StatusUp = "up"
+
+	// StatusDown is status of unavailable dependency.
+	StatusDown = "down"
+
+	// DefaultTimeout is used when checker was registered without timeout.
+	DefaultTimeout = 2 * time.Second
+)
+
+var (
+	// ErrCheckTimeout is occurred when checker didn't respond in time.
+	ErrCheckTimeout = errors.New("Health check timed out")
+
+	// ErrShuttingDown is occurred when server is draining requests.
+	ErrShuttingDown = errors.New("Server is shutting down")
+)
+
+// CheckFunc return error when dependency is unavailable.
+type CheckFunc func(ctx context.Context) error
+
+// Registry keeps health checkers of dependencies.
+type Registry interface {
+	Register(name string, timeout time.Duration, check CheckFunc)
+	Check(ctx context.Context) Report
+	SetReady(ready bool)
+}
+
+// Report is health status of all registered dependencies.
+type Report struct {
+	Status string                 `json:"status"`
+	Error  string                 `json:"error,omitempty"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// IsUp return true when every dependency is up.
+func (report Report) IsUp() bool {
+	return report.Status == StatusUp
+}
+
+// CheckResult is health status of dependency.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type checker struct {
+	name    string
+	timeout time.Duration
+	check   CheckFunc
+}
+
+type registry struct {
+	mutex    sync.RWMutex
+	checkers []checker
+	ready    bool
+}
+
+// NewRegistry return new health check registry.
+func NewRegistry() Registry {
+	return &registry{
+		ready: true,
+	}
+}
+
+// SetReady set whether server accepts traffic regardless of dependencies.
+func (registry *registry) SetReady(ready bool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.ready = ready
+}
+
+func (registry *registry) Register(name string, timeout time.Duration, check CheckFunc) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.checkers = append(registry.checkers, checker{
+		name:    name,
+		timeout: timeout,
+		check:   check,
+	})
+}
+
+// Check run every checker concurrently and
+// report down when any of them failed.
+func (registry *registry) Check(ctx context.Context) Report {
+	registry.mutex.RLock()
+	checkers := registry.checkers
+	ready := registry.ready
+	registry.mutex.RUnlock()
+
+	results := make([]CheckResult, len(checkers))
+
+	var wg sync.WaitGroup
+	for i, c := range checkers {
+		wg.Add(1)
+
+		go func(i int, c checker) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, c)
+		}(i, c)
+	}
+
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: map[string]CheckResult{},
+	}
+
+	for i, c := range checkers {
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+
+		report.Checks[c.name] = results[i]
+	}
+
+	if !ready {
+		report.Status = StatusDown
+		report.Error = ErrShuttingDown.Error()
+	}
+
+	return report
+}
+
+func runCheck(ctx context.Context, c checker) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- c.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ErrCheckTimeout
+	}
+
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(startedAt)) / float64(time.Millisecond),
+	}
+
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,105 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/health"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type registryUnit struct {
+	suite.Suite
+}
+
+func TestRegistryUnit(t *testing.T) {
+	suite.Run(t, new(registryUnit))
+}
+
+func (suite *registryUnit) TestCheck() {
+	testCases := []struct {
+		description    string
+		timeout        time.Duration
+		check          health.CheckFunc
+		expectedStatus string
+		expectedErr    string
+	}{
+		{
+			description: "ShouldReturnUp",
+			check: func(ctx context.Context) error {
+				return nil
+			},
+			expectedStatus: health.StatusUp,
+		},
+		{
+			description: "ShouldReturnDown_WhenCheckFailed",
+			check: func(ctx context.Context) error {
+				return errors.New("connection refused")
+			},
+			expectedStatus: health.StatusDown,
+			expectedErr:    "connection refused",
+		},
+		{
+			description: "ShouldReturnDown_WhenCheckTimedOut",
+			timeout:     10 * time.Millisecond,
+			check: func(ctx context.Context) error {
+				time.Sleep(time.Second)
+				return nil
+			},
+			expectedStatus: health.StatusDown,
+			expectedErr:    health.ErrCheckTimeout.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			registry := health.NewRegistry()
+			registry.Register("dependency", tc.timeout, tc.check)
+
+			report := registry.Check(context.Background())
+
+			suite.Equal(tc.expectedStatus, report.Status)
+			suite.Equal(tc.expectedStatus, report.Checks["dependency"].Status)
+			suite.Equal(tc.expectedErr, report.Checks["dependency"].Error)
+		})
+	}
+}
+
+func (suite *registryUnit) TestSetReady() {
+	testCases := []struct {
+		description    string
+		ready          bool
+		expectedStatus string
+		expectedErr    string
+	}{
+		{
+			description:    "ShouldReturnUp_WhenReady",
+			ready:          true,
+			expectedStatus: health.StatusUp,
+		},
+		{
+			description:    "ShouldReturnDown_WhenShuttingDown",
+			ready:          false,
+			expectedStatus: health.StatusDown,
+			expectedErr:    health.ErrShuttingDown.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			registry := health.NewRegistry()
+			registry.Register("dependency", 0, func(ctx context.Context) error {
+				return nil
+			})
+
+			registry.SetReady(tc.ready)
+			report := registry.Check(context.Background())
+
+			suite.Equal(tc.expectedStatus, report.Status)
+			suite.Equal(tc.expectedErr, report.Error)
+			suite.Equal(health.StatusUp, report.Checks["dependency"].Status)
+		})
+	}
+}
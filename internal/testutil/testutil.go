@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/db/migration"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +28,21 @@ func ActualResponse(t *testing.T, router *gin.Engine,
 	return httpRecorder.Result()
 }
 
+// ActualResponseWithHeader return recorded response of request with header
+func ActualResponseWithHeader(t *testing.T, router *gin.Engine,
+	method, url string, body io.Reader, header http.Header) *http.Response {
+	httpRecorder := httptest.NewRecorder()
+
+	req, err := http.NewRequest(method, url, body)
+	require.NoError(t, err)
+
+	req.Header = header
+
+	router.ServeHTTP(httpRecorder, req)
+
+	return httpRecorder.Result()
+}
+
 // ReqBodyFromInterface return request body that contain json payload.
 func ReqBodyFromInterface(t *testing.T, body interface{}) *bytes.Buffer {
 	jsonBytes, err := json.Marshal(body)
@@ -49,3 +66,9 @@ func JSONStringFromResBody(t *testing.T, body io.Reader) string {
 
 	return string(bytes)
 }
+
+// MigrateUp apply every schema migration to test database.
+func MigrateUp(t *testing.T, dbConn *db.Conn) {
+	_, err := migration.NewMigrator(dbConn, migration.Migrations).Up()
+	require.NoError(t, err)
+}
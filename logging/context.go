@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type contextKey int
+
+const (
+	requestIDContextKey contextKey = iota
+	loggerContextKey
+)
+
+// WithRequestID return copy of ctx that carries request id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// RequestID return request id carried by ctx or empty string.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+	return requestID
+}
+
+// WithLogger return copy of ctx that carries logger.
+func WithLogger(ctx context.Context, logger logrus.FieldLogger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, logger)
+}
+
+// FromContext return logger carried by ctx.
+// Standard logger is returned when ctx carries no logger.
+func FromContext(ctx context.Context) logrus.FieldLogger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey).(logrus.FieldLogger); ok {
+			return logger
+		}
+	}
+
+	return logrus.StandardLogger()
+}
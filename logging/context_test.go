@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type contextUnit struct {
+	suite.Suite
+}
+
+func TestContextUnit(t *testing.T) {
+	suite.Run(t, new(contextUnit))
+}
+
+func (suite *contextUnit) TestRequestID() {
+	testCases := []struct {
+		description       string
+		ctx               context.Context
+		expectedRequestID string
+	}{
+		{
+			description:       "ShouldReturnRequestID",
+			ctx:               WithRequestID(context.Background(), "request-id"),
+			expectedRequestID: "request-id",
+		},
+		{
+			description:       "ShouldReturnEmpty_WhenNotCarried",
+			ctx:               context.Background(),
+			expectedRequestID: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			suite.Equal(tc.expectedRequestID, RequestID(tc.ctx))
+		})
+	}
+}
+
+func (suite *contextUnit) TestFromContext() {
+	logger, _ := test.NewNullLogger()
+	entry := logger.WithField("request_id", "request-id")
+
+	suite.Equal(entry, FromContext(WithLogger(context.Background(), entry)))
+	suite.Equal(logrus.StandardLogger(), FromContext(context.Background()))
+}
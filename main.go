@@ -1,18 +1,34 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/defval/inject"
 	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/api/apikey"
 	"github.com/gghcode/go-gin-starterkit/api/auth"
 	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/api/oauth"
 	"github.com/gghcode/go-gin-starterkit/api/todo"
 	"github.com/gghcode/go-gin-starterkit/api/user"
+	"github.com/gghcode/go-gin-starterkit/api/wellknown"
 	"github.com/gghcode/go-gin-starterkit/config"
 	"github.com/gghcode/go-gin-starterkit/db"
 	_ "github.com/gghcode/go-gin-starterkit/docs"
+	"github.com/gghcode/go-gin-starterkit/health"
+	"github.com/gghcode/go-gin-starterkit/metrics"
 	"github.com/gghcode/go-gin-starterkit/middleware"
 	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gghcode/go-gin-starterkit/tracing"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/sirupsen/logrus"
 
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
@@ -35,33 +51,120 @@ const (
 // @license.name MIT
 // @license.url https://github.com/gghcode/go-gin-starterkit/blob/master/LICENSE
 func main() {
+	conf, err := config.NewBuilder().
+		AddConfigFile("config.yaml", true).
+		BindEnvs(envPrefix).
+		Build()
+
+	if err != nil {
+		panic(err)
+	}
+
 	container, err := inject.New(
-		inject.Provide(config.NewBuilder().
-			AddConfigFile("config.yaml", true).
-			BindEnvs(envPrefix).
-			Build),
+		inject.Provide(func() config.Configuration { return conf }),
+		storage(conf),
 
-		inject.Provide(db.NewConn),
-		inject.Provide(db.NewRedisConn),
+		inject.Provide(service.NewLogger),
+		inject.Provide(tracing.NewTracer),
+		inject.Provide(health.NewRegistry),
 		inject.Provide(service.NewPassport),
+		inject.Provide(service.NewTokenDenylist),
+		inject.Provide(service.NewKeyProvider),
+		inject.Provide(service.NewRateLimiter),
+		inject.Provide(service.NewNotifier),
 
 		inject.Provide(common.NewController, inject.As(api.IController)),
-		inject.Provide(user.NewRepository),
 		inject.Provide(user.NewController, inject.As(api.IController)),
-
-		inject.Provide(todo.NewRepository),
 		inject.Provide(todo.NewController, inject.As(api.IController)),
 
 		inject.Provide(auth.NewService),
 		inject.Provide(auth.NewController, inject.As(api.IController)),
+
+		inject.Provide(apikey.NewService),
+		inject.Provide(apikey.NewController, inject.As(api.IController)),
+
+		inject.Provide(oauth.NewService),
+		inject.Provide(oauth.NewController, inject.As(api.IController)),
+
+		inject.Provide(wellknown.NewController),
 	)
 
 	if err != nil {
 		panic(err)
 	}
 
-	var conf config.Configuration
-	if err := container.Extract(&conf); err != nil {
+	var dbConn *db.Conn
+	if conf.Storage != config.StorageMemory {
+		if err := container.Extract(&dbConn); err != nil {
+			panic(err)
+		}
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if dbConn == nil {
+			fmt.Fprintln(os.Stderr, "migrate requires postgres storage")
+			os.Exit(1)
+		}
+
+		err := runMigrate(os.Stdout, dbConn, os.Args[2:])
+		dbConn.Close()
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	var logger logrus.FieldLogger
+	if err := container.Extract(&logger); err != nil {
+		panic(err)
+	}
+
+	var tracer tracing.Tracer
+	if err := container.Extract(&tracer); err != nil {
+		panic(err)
+	}
+
+	var redisConn db.RedisConn
+	if err := container.Extract(&redisConn); err != nil {
+		panic(err)
+	}
+
+	var healthRegistry health.Registry
+	if err := container.Extract(&healthRegistry); err != nil {
+		panic(err)
+	}
+
+	checkTimeout := time.Duration(conf.Health.CheckTimeoutMs) * time.Millisecond
+	if dbConn != nil {
+		dbConn.RegisterHealthCheck(healthRegistry, checkTimeout)
+	}
+	redisConn.RegisterHealthCheck(healthRegistry, checkTimeout)
+
+	var denylist service.TokenDenylist
+	if err := container.Extract(&denylist); err != nil {
+		panic(err)
+	}
+
+	var keys service.KeyProvider
+	if err := container.Extract(&keys); err != nil {
+		panic(err)
+	}
+
+	var apiKeyService apikey.Service
+	if err := container.Extract(&apiKeyService); err != nil {
+		panic(err)
+	}
+
+	var rateLimiter service.RateLimiter
+	if err := container.Extract(&rateLimiter); err != nil {
+		panic(err)
+	}
+
+	var wellKnownController *wellknown.Controller
+	if err := container.Extract(&wellKnownController); err != nil {
 		panic(err)
 	}
 
@@ -70,16 +173,149 @@ func main() {
 		panic(err)
 	}
 
+	metricsRegistry := metrics.NewRegistry()
+	if dbConn != nil {
+		if err := dbConn.RegisterMetrics(metricsRegistry); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := redisConn.RegisterMetrics(metricsRegistry); err != nil {
+		panic(err)
+	}
+
+	metricsHandler, err := middleware.Metrics(metricsRegistry)
+	if err != nil {
+		panic(err)
+	}
+
+	clientIPHandler, err := middleware.ClientIP(conf.Server.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
+
+	binding.Validator = common.NewStructValidator()
+
 	router := gin.New()
-	router.Use(middleware.AddAuthHandler(conf.Jwt))
-	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.ForwardedByClientIP = false
+	router.Use(clientIPHandler)
+	router.Use(middleware.RequestID(logger))
+	router.Use(middleware.Tracing(tracer))
+	router.Use(middleware.AccessLogger(logger))
+	router.Use(metricsHandler)
+	router.Use(middleware.Recovery(logger))
+	router.Use(middleware.AddAuthHandler(keys, denylist, apiKeyService))
+	router.Use(middleware.AddRateLimiter(rateLimiter))
+	router.NoRoute(middleware.NoRoute())
 
-	apiRouter := router.Group("api/")
+	rootRouter := api.NewRouter(&router.RouterGroup)
+	rootRouter.Handle("GET", "/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	wellKnownController.RegisterRoutes(rootRouter)
+
+	apiRouter := rootRouter.Group(api.BasePath)
 	for _, controller := range controllers {
 		controller.RegisterRoutes(apiRouter)
 	}
 
-	if err := router.Run(conf.Addr); err != nil {
+	var adminServer *http.Server
+	if conf.Metrics.Addr == "" {
+		rootRouter.Handle("GET", conf.Metrics.Path, gin.WrapH(metrics.Handler(metricsRegistry)))
+	} else {
+		adminMux := http.NewServeMux()
+		adminMux.Handle(conf.Metrics.Path, metrics.Handler(metricsRegistry))
+		adminServer = &http.Server{Addr: conf.Metrics.Addr, Handler: adminMux}
+	}
+
+	server := newServer(conf, router)
+
+	serverErr := make(chan error, 2)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	if adminServer != nil {
+		go func() {
+			serverErr <- adminServer.ListenAndServe()
+		}()
+
+		logger.WithField("addr", conf.Metrics.Addr).Info("admin server started")
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	logger.WithField("addr", conf.Addr).Info("server started")
+
+	select {
+	case err := <-serverErr:
 		panic(err)
+	case <-quit:
+	}
+
+	logger.Info("server shutting down")
+
+	healthRegistry.SetReady(false)
+	time.Sleep(time.Duration(conf.Server.ShutdownDelaySec) * time.Second)
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Duration(conf.Server.ShutdownGraceSec)*time.Second,
+	)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		logger.WithError(err).Error("server shutdown failed")
+	}
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.WithError(err).Error("admin server shutdown failed")
+		}
+	}
+
+	if err := tracer.Shutdown(ctx); err != nil {
+		logger.WithError(err).Error("tracer shutdown failed")
+	}
+
+	if dbConn != nil {
+		if err := dbConn.Close(); err != nil {
+			logger.WithError(err).Error("db close failed")
+		}
+	}
+
+	if err := redisConn.Close(); err != nil {
+		logger.WithError(err).Error("redis close failed")
+	}
+}
+
+// storage return providers of repositories and key value store by configured storage.
+func storage(conf config.Configuration) inject.Option {
+	if conf.Storage == config.StorageMemory {
+		return inject.Bundle(
+			inject.Provide(db.NewMemoryRedisConn),
+			inject.Provide(user.NewMemoryRepository),
+			inject.Provide(todo.NewMemoryRepository),
+			inject.Provide(apikey.NewMemoryRepository),
+			inject.Provide(oauth.NewMemoryRepository),
+		)
+	}
+
+	return inject.Bundle(
+		inject.Provide(db.NewConn),
+		inject.Provide(db.NewRedisConn),
+		inject.Provide(user.NewRepository),
+		inject.Provide(todo.NewRepository),
+		inject.Provide(apikey.NewRepository),
+		inject.Provide(oauth.NewRepository),
+	)
+}
+
+func newServer(conf config.Configuration, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         conf.Addr,
+		Handler:      handler,
+		ReadTimeout:  time.Duration(conf.Server.ReadTimeoutSec) * time.Second,
+		WriteTimeout: time.Duration(conf.Server.WriteTimeoutSec) * time.Second,
+		IdleTimeout:  time.Duration(conf.Server.IdleTimeoutSec) * time.Second,
 	}
 }
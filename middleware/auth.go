@@ -2,18 +2,48 @@ package middleware
 
 import (
 	"errors"
-	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gghcode/go-gin-starterkit/api/common"
-	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/service"
 	"github.com/gin-gonic/gin"
 )
 
-// VerifyHandlerKey is key that identify inner handler.
-const VerifyHandlerKey = "INNER_FUNC_AUTH_REQUIRED"
+const (
+	// VerifyHandlerKey is key that identify inner handler.
+	VerifyHandlerKey = "INNER_FUNC_AUTH_REQUIRED"
+
+	// UserIDKey is key that identify authenticated user id.
+	UserIDKey = "user_id"
+
+	// TokenIDKey is key that identify id of authenticated access token.
+	TokenIDKey = "token_id"
+
+	// SessionIDKey is key that identify session id of authenticated access token.
+	SessionIDKey = "session_id"
+
+	// TokenExpiresAtKey is key that identify expiry of authenticated access token.
+	TokenExpiresAtKey = "token_expires_at"
+
+	// RoleKey is key that identify role of authenticated user.
+	RoleKey = "role"
+
+	// APIKeyIDKey is key that identify id of authenticated api key.
+	APIKeyIDKey = "api_key_id"
+
+	// ClientIDKey is key that identify oauth client that access token was issued to.
+	ClientIDKey = "client_id"
+
+	// ScopesKey is key that identify scopes of authenticated credential.
+	// It is set only when credential is restricted to scopes.
+	ScopesKey = "scopes"
+
+	// APIKeyScheme is authorization scheme of personal api keys.
+	APIKeyScheme = "ApiKey"
+)
 
 var (
 	// ErrTokenExpired is occurred when token expired
@@ -21,26 +51,66 @@ var (
 
 	// ErrUnauthorizedToken is occurred when token is invalid
 	ErrUnauthorizedToken = errors.New("Token is unauthorized")
+
+	// ErrTokenRevoked is occurred when token was revoked
+	ErrTokenRevoked = errors.New("Token was revoked")
 )
 
 // AddAuthHandler is
-func AddAuthHandler(conf config.JwtConfig) gin.HandlerFunc {
+// Bearer access tokens are verified by keys and api keys of ApiKey scheme by apiKeys.
+// Access tokens issued to oauth clients are restricted to their scope claim.
+func AddAuthHandler(keys service.KeyProvider, denylist service.TokenDenylist, apiKeys service.APIKeyAuthenticator) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var innerHandler gin.HandlerFunc = func(ctx *gin.Context) {
 			token := ctx.GetHeader("Authorization")
 
-			claims, err := verifyAccessToken(conf.SecretKey, token)
+			if strings.HasPrefix(token, APIKeyScheme+" ") {
+				authenticateAPIKey(ctx, apiKeys, strings.TrimPrefix(token, APIKeyScheme+" "))
+				return
+			}
+
+			claims, err := verifyAccessToken(keys, token)
+			if err != nil {
+				common.AbortWithErrResp(ctx, ErrorCodes, err)
+				return
+			}
+
+			tokenID, _ := claims["jti"].(string)
+			sessionID, _ := claims["sid"].(string)
+
+			revoked, err := denylist.IsRevoked(ctx.Request.Context(), tokenID, sessionID)
 			if err != nil {
-				ctx.AbortWithStatusJSON(
-					http.StatusUnauthorized,
-					common.NewErrResp(err),
-				)
+				common.AbortWithErrResp(ctx, ErrorCodes, err)
+				return
+			} else if revoked {
+				common.AbortWithErrResp(ctx, ErrorCodes, ErrTokenRevoked)
+				return
+			}
+
+			subject, _ := claims["sub"].(string)
+			userID, err := strconv.ParseInt(subject, 10, 64)
+			if err != nil {
+				common.AbortWithErrResp(ctx, ErrorCodes, ErrUnauthorizedToken)
 				return
 			}
 
-			userID, _ := strconv.ParseInt(claims["sub"].(string), 10, 64)
+			expiresAt, _ := claims["exp"].(float64)
+			role, _ := claims["role"].(string)
+
+			ctx.Set(UserIDKey, userID)
+			ctx.Set(TokenIDKey, tokenID)
+			ctx.Set(SessionIDKey, sessionID)
+			ctx.Set(TokenExpiresAtKey, time.Unix(int64(expiresAt), 0))
+			ctx.Set(RoleKey, role)
+
+			// tokens of oauth clients are restricted to scopes granted by user.
+			if clientID, _ := claims["client_id"].(string); clientID != "" {
+				scope, _ := claims["scope"].(string)
+
+				ctx.Set(ClientIDKey, clientID)
+				ctx.Set(ScopesKey, strings.Fields(scope))
+			}
 
-			ctx.Set("user_id", userID)
 			ctx.Next()
 		}
 
@@ -59,7 +129,26 @@ func AuthRequired() gin.HandlerFunc {
 	}
 }
 
-func verifyAccessToken(secret string, accessToken string) (jwt.MapClaims, error) {
+func authenticateAPIKey(ctx *gin.Context, apiKeys service.APIKeyAuthenticator, key string) {
+	if apiKeys == nil {
+		common.AbortWithErrResp(ctx, ErrorCodes, service.ErrInvalidAPIKey)
+		return
+	}
+
+	identity, err := apiKeys.AuthenticateAPIKey(ctx.Request.Context(), key)
+	if err != nil {
+		common.AbortWithErrResp(ctx, ErrorCodes, err)
+		return
+	}
+
+	ctx.Set(UserIDKey, identity.UserID)
+	ctx.Set(APIKeyIDKey, identity.KeyID)
+	ctx.Set(RoleKey, identity.Role)
+	ctx.Set(ScopesKey, identity.Scopes)
+	ctx.Next()
+}
+
+func verifyAccessToken(keys service.KeyProvider, accessToken string) (jwt.MapClaims, error) {
 	tokenInfo := strings.Split(accessToken, " ")
 	if len(tokenInfo) != 2 || tokenInfo[0] != "Bearer" {
 		return nil, ErrUnauthorizedToken
@@ -69,9 +158,7 @@ func verifyAccessToken(secret string, accessToken string) (jwt.MapClaims, error)
 	_, err := jwt.ParseWithClaims(
 		tokenInfo[1],
 		&claims,
-		func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
-		},
+		keys.Keyfunc,
 	)
 
 	if err != nil {
@@ -83,5 +170,10 @@ func verifyAccessToken(secret string, accessToken string) (jwt.MapClaims, error)
 		return nil, ErrUnauthorizedToken
 	}
 
+	// refresh tokens and id tokens are signed by same keys, but do not access api.
+	if tokenType, _ := claims["typ"].(string); tokenType != service.AccessTokenType {
+		return nil, ErrUnauthorizedToken
+	}
+
 	return claims, nil
 }
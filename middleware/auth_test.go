@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,15 +11,57 @@ import (
 	"github.com/gghcode/go-gin-starterkit/api/common"
 	"github.com/gghcode/go-gin-starterkit/config"
 	"github.com/gghcode/go-gin-starterkit/internal/testutil"
+	"github.com/gghcode/go-gin-starterkit/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 )
 
+type fakeDenylist struct {
+	revokedIDs map[string]bool
+}
+
+func (d *fakeDenylist) Revoke(ctx context.Context, id string, ttl time.Duration) error {
+	d.revokedIDs[id] = true
+	return nil
+}
+
+func (d *fakeDenylist) IsRevoked(ctx context.Context, ids ...string) (bool, error) {
+	for _, id := range ids {
+		if d.revokedIDs[id] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type fakeAPIKeyAuthenticator struct {
+	identities map[string]service.APIKeyIdentity
+}
+
+func (a *fakeAPIKeyAuthenticator) AuthenticateAPIKey(ctx context.Context, key string) (service.APIKeyIdentity, error) {
+	identity, ok := a.identities[key]
+	if !ok {
+		return service.APIKeyIdentity{}, service.ErrInvalidAPIKey
+	}
+
+	return identity, nil
+}
+
+// accessTokenClaims is claims of access tokens issued by auth service.
+type accessTokenClaims struct {
+	jwt.StandardClaims
+
+	Type string `json:"typ"`
+}
+
 type authUnit struct {
 	suite.Suite
 
-	conf config.JwtConfig
+	conf     config.JwtConfig
+	keys     service.KeyProvider
+	denylist *fakeDenylist
 }
 
 func TestAuthMiddlewareUnit(t *testing.T) {
@@ -31,6 +74,12 @@ func (suite *authUnit) SetupTest() {
 		AccessExpiresInSec:  300,
 		RefreshExpiresInSec: 3000,
 	}
+	suite.denylist = &fakeDenylist{revokedIDs: map[string]bool{}}
+
+	keys, err := service.NewKeyProvider(config.Configuration{Jwt: suite.conf})
+	suite.Require().NoError(err)
+
+	suite.keys = keys
 
 	gin.SetMode(gin.TestMode)
 }
@@ -45,10 +94,13 @@ func (suite *authUnit) TestVerifyAccessToken() {
 		{
 			description: "ShouldBeSuccess",
 			accessTokenFn: func() string {
-				claims := &jwt.StandardClaims{
-					ExpiresAt: time.Now().Add(3000 * time.Second).Unix(),
-					IssuedAt:  time.Now().Unix(),
-					Subject:   "10",
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(3000 * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+						Subject:   "10",
+					},
+					Type: service.AccessTokenType,
 				}
 
 				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -61,9 +113,48 @@ func (suite *authUnit) TestVerifyAccessToken() {
 		},
 		{
 			description: "ShouldReturnTokenExpiredErr",
+			accessTokenFn: func() string {
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(-300 * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+						Subject:   "10",
+					},
+					Type: service.AccessTokenType,
+				}
+
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				tokenString, _ := token.SignedString([]byte(suite.conf.SecretKey))
+
+				return "Bearer " + tokenString
+			},
+			expectedErr: ErrTokenExpired,
+		},
+		{
+			description: "ShouldReturnUnauthorizedTokenErr_WhenUnexpectedAlgorithm",
+			accessTokenFn: func() string {
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(3000 * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+						Subject:   "10",
+					},
+					Type: service.AccessTokenType,
+				}
+
+				token := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
+				tokenString, _ := token.SignedString([]byte(suite.conf.SecretKey))
+
+				return "Bearer " + tokenString
+			},
+			expectedErr: ErrUnauthorizedToken,
+		},
+		{
+			description: "ShouldReturnUnauthorizedTokenErr_WhenIDToken",
 			accessTokenFn: func() string {
 				claims := &jwt.StandardClaims{
-					ExpiresAt: time.Now().Add(-300 * time.Second).Unix(),
+					Audience:  "client",
+					ExpiresAt: time.Now().Add(3000 * time.Second).Unix(),
 					IssuedAt:  time.Now().Unix(),
 					Subject:   "10",
 				}
@@ -73,7 +164,7 @@ func (suite *authUnit) TestVerifyAccessToken() {
 
 				return "Bearer " + tokenString
 			},
-			expectedErr: ErrTokenExpired,
+			expectedErr: ErrUnauthorizedToken,
 		},
 		{
 			description:   "ShouldReturnUnauthorizedTokenErr_WhenEmptyToken",
@@ -100,7 +191,7 @@ func (suite *authUnit) TestVerifyAccessToken() {
 	for _, tc := range testCases {
 		suite.Run(tc.description, func() {
 			claims, actualErr := verifyAccessToken(
-				suite.conf.SecretKey,
+				suite.keys,
 				tc.accessTokenFn(),
 			)
 
@@ -125,10 +216,13 @@ func (suite *authUnit) TestAuthMiddleware() {
 			accessTokenFn: func() string {
 				expiresIn := time.Duration(suite.conf.AccessExpiresInSec)
 
-				claims := &jwt.StandardClaims{
-					ExpiresAt: time.Now().Add(expiresIn * time.Second).Unix(),
-					IssuedAt:  time.Now().Unix(),
-					Subject:   "10",
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(expiresIn * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+						Subject:   "10",
+					},
+					Type: service.AccessTokenType,
 				}
 
 				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -141,10 +235,78 @@ func (suite *authUnit) TestAuthMiddleware() {
 		{
 			description: "ShouldReturnTokenExpiredErr",
 			accessTokenFn: func() string {
-				claims := &jwt.StandardClaims{
-					ExpiresAt: time.Now().Add(-300 * time.Second).Unix(),
-					IssuedAt:  time.Now().Unix(),
-					Subject:   "10",
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(-300 * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+						Subject:   "10",
+					},
+					Type: service.AccessTokenType,
+				}
+
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				tokenString, _ := token.SignedString([]byte(suite.conf.SecretKey))
+
+				return "Bearer " + tokenString
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(ErrorCodes, ErrTokenExpired)),
+		},
+		{
+			description: "ShouldReturnTokenRevokedErr",
+			accessTokenFn: func() string {
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(300 * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+						Id:        "revoked_token_id",
+						Subject:   "10",
+					},
+					Type: service.AccessTokenType,
+				}
+				suite.denylist.Revoke(context.Background(), claims.Id, 300*time.Second)
+
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				tokenString, _ := token.SignedString([]byte(suite.conf.SecretKey))
+
+				return "Bearer " + tokenString
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(ErrorCodes, ErrTokenRevoked)),
+		},
+		{
+			description: "ShouldReturnUnauthorizedTokenErr_WhenNoSubject",
+			accessTokenFn: func() string {
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(300 * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+					},
+					Type: service.AccessTokenType,
+				}
+
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				tokenString, _ := token.SignedString([]byte(suite.conf.SecretKey))
+
+				return "Bearer " + tokenString
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
+				common.NewErrResp(ErrorCodes, ErrUnauthorizedToken)),
+		},
+		{
+			description: "ShouldReturnUnauthorizedTokenErr_WhenRefreshToken",
+			accessTokenFn: func() string {
+				claims := &accessTokenClaims{
+					StandardClaims: jwt.StandardClaims{
+						ExpiresAt: time.Now().Add(3000 * time.Second).Unix(),
+						IssuedAt:  time.Now().Unix(),
+						Id:        "refresh_token_id",
+						Subject:   "10",
+					},
+					Type: "refresh",
 				}
 
 				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -154,28 +316,28 @@ func (suite *authUnit) TestAuthMiddleware() {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(ErrTokenExpired)),
+				common.NewErrResp(ErrorCodes, ErrUnauthorizedToken)),
 		},
 		{
 			description:    "ShouldReturnUnauthorizedTokenErr_WhenShortTokenInfo",
 			accessTokenFn:  func() string { return "Bearfasdf" },
 			expectedStatus: http.StatusUnauthorized,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(ErrUnauthorizedToken)),
+				common.NewErrResp(ErrorCodes, ErrUnauthorizedToken)),
 		},
 		{
 			description:    "ShouldReturnUnauthorizedTokenErr_WhenEmptyToken",
 			accessTokenFn:  func() string { return "" },
 			expectedStatus: http.StatusUnauthorized,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(ErrUnauthorizedToken)),
+				common.NewErrResp(ErrorCodes, ErrUnauthorizedToken)),
 		},
 		{
 			description:    "ShouldReturnUnauthorizedTokenErr_WhenInvalidAccessToken",
 			accessTokenFn:  func() string { return "Bearer InvalidToken" },
 			expectedStatus: http.StatusUnauthorized,
 			expectedJSON: testutil.JSONStringFromInterface(suite.T(),
-				common.NewErrResp(ErrUnauthorizedToken)),
+				common.NewErrResp(ErrorCodes, ErrUnauthorizedToken)),
 		},
 	}
 
@@ -187,9 +349,9 @@ func (suite *authUnit) TestAuthMiddleware() {
 
 			_, engine := gin.CreateTestContext(recorder)
 
-			engine.Use(AddAuthHandler(suite.conf))
+			engine.Use(AddAuthHandler(suite.keys, suite.denylist, nil))
 			engine.Use(AuthRequired())
-			engine.GET("/", func(ctx *gin.Context) { ctx.MustGet("user_id") })
+			engine.GET("/", func(ctx *gin.Context) { ctx.MustGet(UserIDKey) })
 			engine.ServeHTTP(recorder, req)
 
 			suite.Equal(tc.expectedStatus, recorder.Code)
@@ -200,3 +362,141 @@ func (suite *authUnit) TestAuthMiddleware() {
 		})
 	}
 }
+
+func (suite *authUnit) TestAuthMiddleware_APIKey() {
+	apiKeys := &fakeAPIKeyAuthenticator{
+		identities: map[string]service.APIKeyIdentity{
+			"valid_key": {
+				KeyID:  3,
+				UserID: 10,
+				Role:   service.RoleUser,
+				Scopes: []string{service.ScopeTodosRead},
+			},
+		},
+	}
+
+	testCases := []struct {
+		description    string
+		authorization  string
+		apiKeys        service.APIKeyAuthenticator
+		expectedStatus int
+		expectedScopes []string
+	}{
+		{
+			description:    "ShouldBeSuccess",
+			authorization:  APIKeyScheme + " valid_key",
+			apiKeys:        apiKeys,
+			expectedStatus: http.StatusOK,
+			expectedScopes: []string{service.ScopeTodosRead},
+		},
+		{
+			description:    "ShouldReturnUnauthorized_WhenInvalidKey",
+			authorization:  APIKeyScheme + " invalid_key",
+			apiKeys:        apiKeys,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			description:    "ShouldReturnUnauthorized_WhenAPIKeysDisabled",
+			authorization:  APIKeyScheme + " valid_key",
+			apiKeys:        nil,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			req.Header.Add("Authorization", tc.authorization)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			var actualScopes []string
+
+			engine.Use(AddAuthHandler(suite.keys, suite.denylist, tc.apiKeys))
+			engine.Use(AuthRequired())
+			engine.GET("/", func(ctx *gin.Context) {
+				suite.Equal(int64(10), ctx.GetInt64(UserIDKey))
+				suite.Equal(int64(3), ctx.GetInt64(APIKeyIDKey))
+				actualScopes = ctx.GetStringSlice(ScopesKey)
+			})
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal(tc.expectedStatus, recorder.Code)
+			suite.Equal(tc.expectedScopes, actualScopes)
+		})
+	}
+}
+
+func (suite *authUnit) TestAuthMiddleware_ClientToken() {
+	testCases := []struct {
+		description      string
+		claims           jwt.MapClaims
+		expectedClientID string
+		expectedScopes   []string
+		expectedExists   bool
+	}{
+		{
+			description: "ShouldSetScopes_WhenClientToken",
+			claims: jwt.MapClaims{
+				"sub":       "10",
+				"client_id": "client",
+				"scope":     service.ScopeTodosRead + " " + service.ScopeTodosWrite,
+			},
+			expectedClientID: "client",
+			expectedScopes:   []string{service.ScopeTodosRead, service.ScopeTodosWrite},
+			expectedExists:   true,
+		},
+		{
+			description: "ShouldSetEmptyScopes_WhenClientTokenWithoutScope",
+			claims: jwt.MapClaims{
+				"sub":       "10",
+				"client_id": "client",
+			},
+			expectedClientID: "client",
+			expectedScopes:   []string{},
+			expectedExists:   true,
+		},
+		{
+			description: "ShouldNotSetScopes_WhenSessionToken",
+			claims: jwt.MapClaims{
+				"sub": "10",
+			},
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			tc.claims["exp"] = time.Now().Add(300 * time.Second).Unix()
+			tc.claims["typ"] = service.AccessTokenType
+
+			tokenString, err := suite.keys.Sign(tc.claims)
+			suite.Require().NoError(err)
+
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			req.Header.Add("Authorization", "Bearer "+tokenString)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			var actualScopes interface{}
+			var actualExists bool
+
+			engine.Use(AddAuthHandler(suite.keys, suite.denylist, nil))
+			engine.Use(AuthRequired())
+			engine.GET("/", func(ctx *gin.Context) {
+				suite.Equal(tc.expectedClientID, ctx.GetString(ClientIDKey))
+				actualScopes, actualExists = ctx.Get(ScopesKey)
+			})
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal(http.StatusOK, recorder.Code)
+			suite.Equal(tc.expectedExists, actualExists)
+
+			if tc.expectedExists {
+				suite.Equal(tc.expectedScopes, actualScopes)
+			}
+		})
+	}
+}
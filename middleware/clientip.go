@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIPKey is key that identify ip of client resolved by ClientIP.
+const ClientIPKey = "client_ip"
+
+// ClientIP resolve ip of client that rate limits, lockouts and logs use.
+// Forwarded headers are read only when peer is one of trustedProxies,
+// a comma separated list of ips or cidrs, so clients can not forge their ip.
+func ClientIP(trustedProxies string) (gin.HandlerFunc, error) {
+	proxies, err := parseTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Set(ClientIPKey, resolveClientIP(ctx, proxies))
+		ctx.Next()
+	}, nil
+}
+
+// GetClientIP return ip of client resolved by ClientIP,
+// or peer address when ClientIP is not used.
+func GetClientIP(ctx *gin.Context) string {
+	if clientIP := ctx.GetString(ClientIPKey); clientIP != "" {
+		return clientIP
+	}
+
+	return remoteIP(ctx)
+}
+
+// resolveClientIP walk X-Forwarded-For from the nearest hop
+// and return first address that is not trusted proxy.
+func resolveClientIP(ctx *gin.Context, proxies []*net.IPNet) string {
+	peer := remoteIP(ctx)
+	if !isTrustedProxy(peer, proxies) {
+		return peer
+	}
+
+	hops := strings.Split(ctx.GetHeader("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+
+		if !isTrustedProxy(hop, proxies) {
+			return hop
+		}
+	}
+
+	if realIP := strings.TrimSpace(ctx.GetHeader("X-Real-Ip")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+
+	return peer
+}
+
+func remoteIP(ctx *gin.Context) string {
+	ip, _, err := net.SplitHostPort(strings.TrimSpace(ctx.Request.RemoteAddr))
+	if err != nil {
+		return ""
+	}
+
+	return ip
+}
+
+func isTrustedProxy(ip string, proxies []*net.IPNet) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+
+	for _, proxy := range proxies {
+		if proxy.Contains(parsedIP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseTrustedProxies(trustedProxies string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+
+	for _, proxy := range strings.Split(trustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		_, proxyNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+
+		proxies = append(proxies, proxyNet)
+	}
+
+	return proxies, nil
+}
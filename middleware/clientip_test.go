@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type clientIPUnit struct {
+	suite.Suite
+}
+
+func TestClientIPMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(clientIPUnit))
+}
+
+func (suite *clientIPUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *clientIPUnit) TestClientIP() {
+	testCases := []struct {
+		description    string
+		trustedProxies string
+		remoteAddr     string
+		forwardedFor   string
+		realIP         string
+		expected       string
+	}{
+		{
+			description:    "ShouldReturnPeer_WhenNoHeaders",
+			trustedProxies: "",
+			remoteAddr:     "10.0.0.1:1234",
+			expected:       "10.0.0.1",
+		},
+		{
+			description:    "ShouldIgnoreHeaders_WhenPeerIsNotTrusted",
+			trustedProxies: "192.168.0.0/16",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   "1.2.3.4",
+			realIP:         "5.6.7.8",
+			expected:       "10.0.0.1",
+		},
+		{
+			description:    "ShouldReturnNearestUntrustedHop_WhenPeerIsTrusted",
+			trustedProxies: "192.168.0.0/16, 172.16.0.1",
+			remoteAddr:     "192.168.0.10:1234",
+			forwardedFor:   "1.2.3.4, 5.6.7.8, 172.16.0.1",
+			expected:       "5.6.7.8",
+		},
+		{
+			description:    "ShouldReturnRealIP_WhenPeerIsTrustedWithoutForwardedFor",
+			trustedProxies: "192.168.0.10",
+			remoteAddr:     "192.168.0.10:1234",
+			realIP:         "5.6.7.8",
+			expected:       "5.6.7.8",
+		},
+		{
+			description:    "ShouldReturnPeer_WhenForwardedForIsInvalid",
+			trustedProxies: "192.168.0.10",
+			remoteAddr:     "192.168.0.10:1234",
+			forwardedFor:   "forged",
+			expected:       "192.168.0.10",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			handler, err := ClientIP(tc.trustedProxies)
+			suite.Require().NoError(err)
+
+			var actual string
+
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+			req.Header.Set("X-Real-Ip", tc.realIP)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(handler)
+			engine.GET("/", func(ctx *gin.Context) { actual = GetClientIP(ctx) })
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func (suite *clientIPUnit) TestClientIP_ShouldReturnErr_WhenInvalidTrustedProxies() {
+	_, err := ClientIP("10.0.0.0/99")
+
+	suite.Error(err)
+}
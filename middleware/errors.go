@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/service"
+)
+
+// ErrorCodes map errors of middlewares into status and code of response.
+var ErrorCodes = common.ErrorCodes{
+	ErrTokenExpired:          {Status: http.StatusUnauthorized, Code: "auth.token_expired"},
+	ErrUnauthorizedToken:     {Status: http.StatusUnauthorized, Code: "auth.unauthorized"},
+	ErrTokenRevoked:          {Status: http.StatusUnauthorized, Code: "auth.token_revoked"},
+	ErrPermissionDenied:      {Status: http.StatusForbidden, Code: "auth.permission_denied"},
+	ErrTooManyRequests:       {Status: http.StatusTooManyRequests, Code: "rate_limit.exceeded"},
+	ErrInsufficientScope:     {Status: http.StatusForbidden, Code: "auth.insufficient_scope"},
+	service.ErrInvalidAPIKey: {Status: http.StatusUnauthorized, Code: "auth.invalid_api_key"},
+	service.ErrAPIKeyExpired: {Status: http.StatusUnauthorized, Code: "auth.api_key_expired"},
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gghcode/go-gin-starterkit/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// UnmatchedRoute is route template of request that matched no route.
+const UnmatchedRoute = "unmatched"
+
+// AccessLogger write an access log entry per request after handlers finished.
+func AccessLogger(logger logrus.FieldLogger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		entry := logger.WithFields(logrus.Fields{
+			"method":     ctx.Request.Method,
+			"route":      RouteTemplate(ctx),
+			"path":       ctx.Request.URL.Path,
+			"status":     ctx.Writer.Status(),
+			"latency_ms": float64(time.Since(start)) / float64(time.Millisecond),
+			"user_id":    ctx.GetInt64(UserIDKey),
+			"request_id": logging.RequestID(ctx.Request.Context()),
+			"client_ip":  GetClientIP(ctx),
+		})
+
+		if span := tracing.SpanFromContext(ctx.Request.Context()); span != nil {
+			entry = entry.WithField("trace_id", span.Context().TraceID.String())
+		}
+
+		if len(ctx.Errors) > 0 {
+			entry = entry.WithField("error", ctx.Errors.String())
+		}
+
+		switch status := ctx.Writer.Status(); {
+		case status >= 500:
+			entry.Error("request completed")
+		case status >= 400:
+			entry.Warn("request completed")
+		default:
+			entry.Info("request completed")
+		}
+	}
+}
+
+// RouteTemplate return registered path of route such as /users/:id recorded by api.Router.
+// Requests of routes without it are reported as unmatched,
+// so raw paths never grow cardinality of metrics.
+func RouteTemplate(ctx *gin.Context) string {
+	if template := ctx.GetString(api.RouteKey); template != "" {
+		return template
+	}
+
+	return UnmatchedRoute
+}
+
+// NoRoute respond not found error of unknown routes.
+func NoRoute() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		common.AbortWithErrResp(ctx, nil, common.ErrRouteNotFound)
+	}
+}
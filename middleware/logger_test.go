@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type loggerUnit struct {
+	suite.Suite
+}
+
+func TestLoggerMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(loggerUnit))
+}
+
+func (suite *loggerUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *loggerUnit) TestAccessLogger() {
+	testCases := []struct {
+		description    string
+		url            string
+		handler        gin.HandlerFunc
+		expectedLevel  logrus.Level
+		expectedStatus int
+		expectedRoute  string
+	}{
+		{
+			description: "ShouldLogInfo_WhenSuccess",
+			url:         "/users/10",
+			handler: func(ctx *gin.Context) {
+				ctx.Set(UserIDKey, int64(10))
+				ctx.Status(http.StatusOK)
+			},
+			expectedLevel:  logrus.InfoLevel,
+			expectedStatus: http.StatusOK,
+			expectedRoute:  "/users/:id",
+		},
+		{
+			description:    "ShouldLogWarn_WhenClientErr",
+			url:            "/users/20",
+			handler:        func(ctx *gin.Context) { ctx.Status(http.StatusNotFound) },
+			expectedLevel:  logrus.WarnLevel,
+			expectedStatus: http.StatusNotFound,
+			expectedRoute:  "/users/:id",
+		},
+		{
+			description:    "ShouldLogError_WhenServerErr",
+			url:            "/users/30",
+			handler:        func(ctx *gin.Context) { ctx.Status(http.StatusInternalServerError) },
+			expectedLevel:  logrus.ErrorLevel,
+			expectedStatus: http.StatusInternalServerError,
+			expectedRoute:  "/users/:id",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			logger, hook := test.NewNullLogger()
+
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tc.url, nil)
+			req.Header.Set(RequestIDHeader, "request-id")
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(RequestID(logger), AccessLogger(logger))
+			api.NewRouter(&engine.RouterGroup).Handle("GET", "/users/:id", tc.handler)
+			engine.ServeHTTP(recorder, req)
+
+			entry := hook.LastEntry()
+			suite.Require().NotNil(entry)
+			suite.Equal(tc.expectedLevel, entry.Level)
+			suite.Equal("GET", entry.Data["method"])
+			suite.Equal(tc.expectedRoute, entry.Data["route"])
+			suite.Equal(tc.expectedStatus, entry.Data["status"])
+			suite.Equal("request-id", entry.Data["request_id"])
+			suite.Contains(entry.Data, "latency_ms")
+			suite.Contains(entry.Data, "user_id")
+		})
+	}
+}
+
+func (suite *loggerUnit) TestRouteTemplate() {
+	testCases := []struct {
+		description   string
+		route         string
+		url           string
+		expectedRoute string
+	}{
+		{
+			description:   "ShouldReturnPath_WhenNoParams",
+			route:         "/users",
+			url:           "/users",
+			expectedRoute: "/users",
+		},
+		{
+			description:   "ShouldReturnRegisteredPath_WhenParams",
+			route:         "/users/:id/role",
+			url:           "/users/10/role",
+			expectedRoute: "/users/:id/role",
+		},
+		{
+			description:   "ShouldReturnRegisteredPath_WhenParamsHaveSameValue",
+			route:         "/todos/:owner/:id",
+			url:           "/todos/1/1",
+			expectedRoute: "/todos/:owner/:id",
+		},
+		{
+			description:   "ShouldReturnRegisteredPath_WhenParamEqualsLiteralSegment",
+			route:         "/users/:id",
+			url:           "/users/users",
+			expectedRoute: "/users/:id",
+		},
+		{
+			description:   "ShouldReturnRegisteredPath_WhenCatchAllParam",
+			route:         "/docs/*any",
+			url:           "/docs/swagger/index.html",
+			expectedRoute: "/docs/*any",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			var actualRoute string
+
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api"+tc.url, nil)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			router := api.NewRouter(&engine.RouterGroup).Group("/api")
+			router.Handle("GET", tc.route, func(ctx *gin.Context) { actualRoute = RouteTemplate(ctx) })
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal("/api"+tc.expectedRoute, actualRoute)
+		})
+	}
+}
+
+func (suite *loggerUnit) TestRouteTemplate_ShouldReturnUnmatched_WhenRouteNotRecorded() {
+	var actualRoute string
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/1", nil)
+
+	_, engine := gin.CreateTestContext(recorder)
+
+	engine.GET("/users/:id", func(ctx *gin.Context) { actualRoute = RouteTemplate(ctx) })
+	engine.ServeHTTP(recorder, req)
+
+	suite.Equal(UnmatchedRoute, actualRoute)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics observe duration of requests by route template and status
+// and number of requests in flight.
+func Metrics(registerer prometheus.Registerer) (gin.HandlerFunc, error) {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of http requests by route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	inFlight := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Number of http requests being served.",
+	})
+
+	if err := registerer.Register(duration); err != nil {
+		return nil, err
+	}
+
+	if err := registerer.Register(inFlight); err != nil {
+		return nil, err
+	}
+
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		ctx.Next()
+
+		duration.WithLabelValues(
+			ctx.Request.Method,
+			RouteTemplate(ctx),
+			strconv.Itoa(ctx.Writer.Status()),
+		).Observe(time.Since(start).Seconds())
+	}, nil
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/suite"
+)
+
+type metricsUnit struct {
+	suite.Suite
+}
+
+func TestMetricsMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(metricsUnit))
+}
+
+func (suite *metricsUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *metricsUnit) TestMetrics() {
+	registry := prometheus.NewRegistry()
+
+	metricsHandler, err := Metrics(registry)
+	suite.Require().NoError(err)
+
+	_, engine := gin.CreateTestContext(httptest.NewRecorder())
+	engine.Use(metricsHandler)
+	engine.NoRoute(NoRoute())
+	api.NewRouter(&engine.RouterGroup).Handle("GET", "/users/:id", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	for _, url := range []string{"/users/1", "/users/2", "/unknown/path"} {
+		req, _ := http.NewRequest("GET", url, nil)
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	families, err := registry.Gather()
+	suite.Require().NoError(err)
+
+	histograms := map[string]uint64{}
+	var inFlight float64
+
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			switch family.GetName() {
+			case "http_request_duration_seconds":
+				histograms[labelsKey(metric)] = metric.GetHistogram().GetSampleCount()
+			case "http_requests_in_flight":
+				inFlight = metric.GetGauge().GetValue()
+			}
+		}
+	}
+
+	suite.Equal(map[string]uint64{
+		"GET /users/:id 200": 2,
+		"GET unmatched 404":  1,
+	}, histograms)
+	suite.Equal(float64(0), inFlight)
+}
+
+func (suite *metricsUnit) TestMetrics_ShouldReturnErr_WhenAlreadyRegistered() {
+	registry := prometheus.NewRegistry()
+
+	_, err := Metrics(registry)
+	suite.NoError(err)
+
+	_, err = Metrics(registry)
+	suite.Error(err)
+}
+
+func labelsKey(metric *dto.Metric) string {
+	labels := map[string]string{}
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+
+	return labels["method"] + " " + labels["route"] + " " + labels["status"]
+}
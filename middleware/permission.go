@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+)
+
+// MeParam is path param that refers to user of token.
+const MeParam = "me"
+
+// ErrPermissionDenied is occurred when role of token lacks permission.
+var ErrPermissionDenied = errors.New("Permission denied")
+
+// RequirePermission abort request unless role of token has permission.
+// It must be used after AuthRequired.
+func RequirePermission(permission service.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !service.HasPermission(ctx.GetString(RoleKey), permission) {
+			common.AbortWithErrResp(ctx, ErrorCodes, ErrPermissionDenied)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireSelfOrPermission abort request unless path param is user id of token
+// or role of token has permission.
+// It must be used after AuthRequired.
+func RequireSelfOrPermission(param string, permission service.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, err := strconv.ParseInt(ctx.Param(param), 10, 64)
+		if err == nil && userID == ctx.GetInt64(UserIDKey) {
+			ctx.Next()
+			return
+		}
+
+		RequirePermission(permission)(ctx)
+	}
+}
+
+// RequireSelf abort request unless path param is me or user id of token.
+// It must be used after AuthRequired.
+func RequireSelf(param string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID := strconv.FormatInt(ctx.GetInt64(UserIDKey), 10)
+		if value := ctx.Param(param); value != MeParam && value != userID {
+			common.AbortWithErrResp(ctx, ErrorCodes, ErrPermissionDenied)
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type permissionUnit struct {
+	suite.Suite
+}
+
+func TestPermissionMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(permissionUnit))
+}
+
+func (suite *permissionUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *permissionUnit) TestRequirePermission() {
+	testCases := []struct {
+		description    string
+		role           string
+		expectedStatus int
+	}{
+		{
+			description:    "ShouldBeSuccess_WhenAdmin",
+			role:           service.RoleAdmin,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldReturnForbidden_WhenUser",
+			role:           service.RoleUser,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "ShouldReturnForbidden_WhenNoRole",
+			role:           "",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(func(ctx *gin.Context) { ctx.Set(RoleKey, tc.role) })
+			engine.GET("/",
+				RequirePermission(service.PermissionManageUsers),
+				func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+			)
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal(tc.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func (suite *permissionUnit) TestRequireSelfOrPermission() {
+	testCases := []struct {
+		description    string
+		userID         int64
+		role           string
+		url            string
+		expectedStatus int
+	}{
+		{
+			description:    "ShouldBeSuccess_WhenSelf",
+			userID:         10,
+			role:           service.RoleUser,
+			url:            "/users/10",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldBeSuccess_WhenAdmin",
+			userID:         10,
+			role:           service.RoleAdmin,
+			url:            "/users/20",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldReturnForbidden_WhenOtherUser",
+			userID:         10,
+			role:           service.RoleUser,
+			url:            "/users/20",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "ShouldReturnForbidden_WhenInvalidID",
+			userID:         10,
+			role:           service.RoleUser,
+			url:            "/users/abc",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tc.url, nil)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(func(ctx *gin.Context) {
+				ctx.Set(UserIDKey, tc.userID)
+				ctx.Set(RoleKey, tc.role)
+			})
+			engine.GET("/users/:id",
+				RequireSelfOrPermission("id", service.PermissionManageUsers),
+				func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+			)
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal(tc.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func (suite *permissionUnit) TestRequireSelf() {
+	testCases := []struct {
+		description    string
+		role           string
+		url            string
+		expectedStatus int
+	}{
+		{
+			description:    "ShouldBeSuccess_WhenMe",
+			role:           service.RoleUser,
+			url:            "/users/me",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldBeSuccess_WhenSelf",
+			role:           service.RoleUser,
+			url:            "/users/10",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldReturnForbidden_WhenAdminOfOtherUser",
+			role:           service.RoleAdmin,
+			url:            "/users/20",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", tc.url, nil)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(func(ctx *gin.Context) {
+				ctx.Set(UserIDKey, int64(10))
+				ctx.Set(RoleKey, tc.role)
+			})
+			engine.PUT("/users/:id",
+				RequireSelf("id"),
+				func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+			)
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal(tc.expectedStatus, recorder.Code)
+		})
+	}
+}
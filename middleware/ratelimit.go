@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RateLimiterKey is key that identify rate limiter of request.
+	RateLimiterKey = "INNER_RATE_LIMITER"
+
+	// RateLimitLimitHeader is header of requests allowed within window.
+	RateLimitLimitHeader = "RateLimit-Limit"
+
+	// RateLimitRemainingHeader is header of requests remaining within window.
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+
+	// RateLimitResetHeader is header of seconds until window frees request.
+	RateLimitResetHeader = "RateLimit-Reset"
+
+	// RetryAfterHeader is header of seconds that limited client should wait for.
+	RetryAfterHeader = "Retry-After"
+)
+
+// ErrTooManyRequests is occurred when client exceeds rate limit.
+var ErrTooManyRequests = errors.New("Too many requests")
+
+// AddRateLimiter add limiter that RateLimit counts requests by.
+func AddRateLimiter(limiter service.RateLimiter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(RateLimiterKey, limiter)
+		ctx.Next()
+	}
+}
+
+// RateLimit abort request when client exceeds policy.
+// Client is identified by user id after AuthRequired and by ip otherwise.
+// Request is allowed when limiter fails, so outage of redis does not lock clients out.
+func RateLimit(policy string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, ok := ctx.Get(RateLimiterKey)
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		limiter := value.(service.RateLimiter)
+
+		result, err := limiter.Allow(ctx.Request.Context(), policy, clientKey(ctx))
+		if err != nil {
+			logging.FromContext(ctx.Request.Context()).
+				WithError(err).
+				WithField("policy", policy).
+				Warn("rate limit skipped")
+
+			ctx.Next()
+			return
+		}
+
+		if result.Limit > 0 {
+			reset := strconv.FormatInt(int64(math.Ceil(result.ResetAfter.Seconds())), 10)
+
+			ctx.Header(RateLimitLimitHeader, strconv.FormatInt(result.Limit, 10))
+			ctx.Header(RateLimitRemainingHeader, strconv.FormatInt(result.Remaining, 10))
+			ctx.Header(RateLimitResetHeader, reset)
+
+			if !result.Allowed {
+				ctx.Header(RetryAfterHeader, reset)
+			}
+		}
+
+		if !result.Allowed {
+			common.AbortWithErrResp(ctx, ErrorCodes, ErrTooManyRequests)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func clientKey(ctx *gin.Context) string {
+	if userID, ok := ctx.Get(UserIDKey); ok {
+		return "user_" + strconv.FormatInt(userID.(int64), 10)
+	}
+
+	return "ip_" + GetClientIP(ctx)
+}
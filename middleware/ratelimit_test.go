@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type failingRateLimiter struct{}
+
+func (failingRateLimiter) Allow(ctx context.Context, policy, key string) (db.RateLimit, error) {
+	return db.RateLimit{}, errors.New("connection refused")
+}
+
+type rateLimitUnit struct {
+	suite.Suite
+
+	limiter service.RateLimiter
+}
+
+func TestRateLimitMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(rateLimitUnit))
+}
+
+func (suite *rateLimitUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	var conf config.Configuration
+	conf.RateLimit.Storage = config.StorageMemory
+	conf.RateLimit.Token = config.RateLimitPolicy{Limit: 2, WindowSec: 60}
+	conf.RateLimit.Refresh = config.RateLimitPolicy{Limit: 1, WindowSec: 60}
+	conf.RateLimit.User = config.RateLimitPolicy{Limit: 1, WindowSec: 60}
+
+	suite.limiter = service.NewRateLimiter(conf, nil)
+}
+
+func (suite *rateLimitUnit) serve(limiter service.RateLimiter, policy string, userID int64) *httptest.ResponseRecorder {
+	return suite.serveFrom(limiter, policy, userID, "")
+}
+
+func (suite *rateLimitUnit) serveFrom(limiter service.RateLimiter, policy string, userID int64, forwardedFor string) *httptest.ResponseRecorder {
+	clientIPHandler, err := ClientIP("")
+	suite.Require().NoError(err)
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+
+	_, engine := gin.CreateTestContext(recorder)
+
+	engine.Use(clientIPHandler)
+	engine.Use(AddRateLimiter(limiter))
+	engine.Use(func(ctx *gin.Context) {
+		if userID != 0 {
+			ctx.Set(UserIDKey, userID)
+		}
+	})
+	engine.POST("/", RateLimit(policy), func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	engine.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func (suite *rateLimitUnit) TestRateLimit_ShouldLimitByIP() {
+	testCases := []struct {
+		description       string
+		expectedStatus    int
+		expectedRemaining string
+	}{
+		{
+			description:       "ShouldBeSuccess_WhenFirstRequest",
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: "1",
+		},
+		{
+			description:       "ShouldBeSuccess_WhenLastAllowedRequest",
+			expectedStatus:    http.StatusOK,
+			expectedRemaining: "0",
+		},
+		{
+			description:       "ShouldReturnTooManyRequests_WhenLimitExceeded",
+			expectedStatus:    http.StatusTooManyRequests,
+			expectedRemaining: "0",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			recorder := suite.serve(suite.limiter, service.RateLimitToken, 0)
+
+			suite.Equal(tc.expectedStatus, recorder.Code)
+			suite.Equal("2", recorder.Header().Get(RateLimitLimitHeader))
+			suite.Equal(tc.expectedRemaining, recorder.Header().Get(RateLimitRemainingHeader))
+			suite.Equal("60", recorder.Header().Get(RateLimitResetHeader))
+
+			if tc.expectedStatus == http.StatusTooManyRequests {
+				suite.Equal("60", recorder.Header().Get(RetryAfterHeader))
+			} else {
+				suite.Empty(recorder.Header().Get(RetryAfterHeader))
+			}
+		})
+	}
+}
+
+func (suite *rateLimitUnit) TestRateLimit_ShouldShareBucket_WhenForwardedForIsForged() {
+	suite.Equal(http.StatusOK, suite.serveFrom(suite.limiter, service.RateLimitToken, 0, "1.1.1.1").Code)
+	suite.Equal(http.StatusOK, suite.serveFrom(suite.limiter, service.RateLimitToken, 0, "2.2.2.2").Code)
+	suite.Equal(http.StatusTooManyRequests, suite.serveFrom(suite.limiter, service.RateLimitToken, 0, "3.3.3.3").Code)
+}
+
+func (suite *rateLimitUnit) TestRateLimit_ShouldLimitEachPolicy() {
+	suite.Equal(http.StatusOK, suite.serve(suite.limiter, service.RateLimitRefresh, 0).Code)
+	suite.Equal(http.StatusTooManyRequests, suite.serve(suite.limiter, service.RateLimitRefresh, 0).Code)
+	suite.Equal(http.StatusOK, suite.serve(suite.limiter, service.RateLimitToken, 0).Code)
+}
+
+func (suite *rateLimitUnit) TestRateLimit_ShouldLimitEachUser() {
+	suite.Equal(http.StatusOK, suite.serve(suite.limiter, service.RateLimitUser, 1).Code)
+	suite.Equal(http.StatusTooManyRequests, suite.serve(suite.limiter, service.RateLimitUser, 1).Code)
+	suite.Equal(http.StatusOK, suite.serve(suite.limiter, service.RateLimitUser, 2).Code)
+}
+
+func (suite *rateLimitUnit) TestRateLimit_ShouldSkipHeaders_WhenPolicyDisabled() {
+	recorder := suite.serve(suite.limiter, service.RateLimitSignup, 0)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get(RateLimitLimitHeader))
+}
+
+func (suite *rateLimitUnit) TestRateLimit_ShouldAllow_WhenLimiterFailed() {
+	recorder := suite.serve(failingRateLimiter{}, service.RateLimitToken, 0)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get(RateLimitLimitHeader))
+}
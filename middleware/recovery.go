@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// Recovery recover panic of handlers, log it with stack trace
+// and respond internal server error.
+func Recovery(logger logrus.FieldLogger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.WithFields(logrus.Fields{
+					"method":     ctx.Request.Method,
+					"path":       ctx.Request.URL.Path,
+					"request_id": logging.RequestID(ctx.Request.Context()),
+					"panic":      fmt.Sprint(recovered),
+					"stack":      string(debug.Stack()),
+				}).Error("recovered from panic")
+
+				common.AbortWithErrResp(ctx, nil, common.ErrInternalServer)
+			}
+		}()
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/internal/testutil"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type recoveryUnit struct {
+	suite.Suite
+}
+
+func TestRecoveryMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(recoveryUnit))
+}
+
+func (suite *recoveryUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *recoveryUnit) TestRecovery() {
+	logger, hook := test.NewNullLogger()
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+
+	_, engine := gin.CreateTestContext(recorder)
+
+	engine.Use(Recovery(logger))
+	engine.GET("/", func(ctx *gin.Context) { panic("unexpected") })
+	engine.ServeHTTP(recorder, req)
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	suite.JSONEq(
+		testutil.JSONStringFromInterface(suite.T(), common.NewErrResp(nil, common.ErrInternalServer)),
+		testutil.JSONStringFromResBody(suite.T(), recorder.Body),
+	)
+
+	entry := hook.LastEntry()
+	suite.Require().NotNil(entry)
+	suite.Equal(logrus.ErrorLevel, entry.Level)
+	suite.Equal("unexpected", entry.Data["panic"])
+	suite.NotEmpty(entry.Data["stack"])
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RequestIDHeader is header that identify request.
+	RequestIDHeader = "X-Request-ID"
+
+	// RequestIDKey is key that identify request id.
+	RequestIDKey = "request_id"
+
+	maxRequestIDLen = 128
+)
+
+// RequestID accept request id of header or generate new one when absent.
+// The id is echoed in response header and carried by request context
+// with logger that logs it, so repositories can log errors with same id.
+func RequestID(logger logrus.FieldLogger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewV4().String()
+		}
+
+		reqCtx := logging.WithRequestID(ctx.Request.Context(), requestID)
+		reqCtx = logging.WithLogger(reqCtx, logger.WithField("request_id", requestID))
+
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Set(RequestIDKey, requestID)
+		ctx.Header(RequestIDHeader, requestID)
+		ctx.Next()
+	}
+}
+
+// isValidRequestID reject ids that could forge log lines or flood logs.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLen {
+		return false
+	}
+
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type requestIDUnit struct {
+	suite.Suite
+}
+
+func TestRequestIDMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(requestIDUnit))
+}
+
+func (suite *requestIDUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *requestIDUnit) TestRequestID() {
+	testCases := []struct {
+		description       string
+		requestID         string
+		expectedGenerated bool
+	}{
+		{
+			description:       "ShouldEchoRequestID",
+			requestID:         "7a1d7c56-request.id:1",
+			expectedGenerated: false,
+		},
+		{
+			description:       "ShouldGenerateRequestID_WhenEmpty",
+			requestID:         "",
+			expectedGenerated: true,
+		},
+		{
+			description:       "ShouldGenerateRequestID_WhenInvalidChars",
+			requestID:         "forged\nline",
+			expectedGenerated: true,
+		},
+		{
+			description:       "ShouldGenerateRequestID_WhenTooLong",
+			requestID:         strings.Repeat("a", maxRequestIDLen+1),
+			expectedGenerated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			logger, hook := test.NewNullLogger()
+
+			var errResp common.ErrorResponse
+
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			req.Header.Set(RequestIDHeader, tc.requestID)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(RequestID(logger))
+			engine.GET("/", func(ctx *gin.Context) {
+				logging.FromContext(ctx.Request.Context()).Error("failed")
+				common.WriteErrResp(ctx, nil, common.ErrInternalServer)
+			})
+			engine.ServeHTTP(recorder, req)
+
+			actualRequestID := recorder.Header().Get(RequestIDHeader)
+			suite.NotEmpty(actualRequestID)
+
+			if tc.expectedGenerated {
+				suite.NotEqual(tc.requestID, actualRequestID)
+			} else {
+				suite.Equal(tc.requestID, actualRequestID)
+			}
+
+			suite.NoError(json.NewDecoder(recorder.Body).Decode(&errResp))
+			suite.Equal(actualRequestID, errResp.RequestID)
+
+			entry := hook.LastEntry()
+			suite.Require().NotNil(entry)
+			suite.Equal(actualRequestID, entry.Data["request_id"])
+		})
+	}
+}
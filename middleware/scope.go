@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gghcode/go-gin-starterkit/api/common"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrInsufficientScope is occurred when credential is not granted scope of route.
+var ErrInsufficientScope = errors.New("Insufficient scope")
+
+// RequireScope abort request authenticated by credential restricted to scopes
+// unless it is granted scope. Access tokens of login are not restricted.
+// It must be used after AuthRequired.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if scopes, restricted := ctx.Get(ScopesKey); restricted {
+			if granted, _ := scopes.([]string); !service.HasScope(granted, scope) {
+				common.AbortWithErrResp(ctx, ErrorCodes, ErrInsufficientScope)
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireFullAccess abort request authenticated by credential restricted to scopes
+// such as api key, so such credentials can not manage account security.
+// It must be used after AuthRequired.
+func RequireFullAccess() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, restricted := ctx.Get(ScopesKey); restricted {
+			common.AbortWithErrResp(ctx, ErrorCodes, ErrInsufficientScope)
+			return
+		}
+
+		ctx.Next()
+	}
+}
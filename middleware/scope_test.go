@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type scopeUnit struct {
+	suite.Suite
+}
+
+func TestScopeMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(scopeUnit))
+}
+
+func (suite *scopeUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *scopeUnit) TestRequireScope() {
+	testCases := []struct {
+		description    string
+		scopes         []string
+		restricted     bool
+		handler        gin.HandlerFunc
+		expectedStatus int
+	}{
+		{
+			description:    "ShouldBeSuccess_WhenNotRestricted",
+			restricted:     false,
+			handler:        RequireScope(service.ScopeTodosWrite),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldBeSuccess_WhenScopeGranted",
+			scopes:         []string{service.ScopeTodosRead, service.ScopeTodosWrite},
+			restricted:     true,
+			handler:        RequireScope(service.ScopeTodosWrite),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldReturnForbidden_WhenScopeNotGranted",
+			scopes:         []string{service.ScopeTodosRead},
+			restricted:     true,
+			handler:        RequireScope(service.ScopeTodosWrite),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description:    "ShouldBeSuccess_WhenFullAccess",
+			restricted:     false,
+			handler:        RequireFullAccess(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "ShouldReturnForbidden_WhenFullAccessRequiredButRestricted",
+			scopes:         service.Scopes,
+			restricted:     true,
+			handler:        RequireFullAccess(),
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(func(ctx *gin.Context) {
+				if tc.restricted {
+					ctx.Set(ScopesKey, tc.scopes)
+				}
+			})
+			engine.GET("/",
+				tc.handler,
+				func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+			)
+			engine.ServeHTTP(recorder, req)
+
+			suite.Equal(tc.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/gghcode/go-gin-starterkit/tracing"
+	"github.com/gin-gonic/gin"
+)
+
+// Tracing start server span per request that continues trace of
+// traceparent header when present. The span is carried by request context
+// so repositories and redis commands record child spans.
+func Tracing(tracer tracing.Tracer) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// malformed header starts new trace as W3C trace context requires.
+		parent, _ := tracing.ParseTraceparent(ctx.GetHeader(tracing.TraceparentHeader))
+
+		reqCtx, span := tracer.Start(ctx.Request.Context(), ctx.Request.Method, tracing.SpanKindServer, parent)
+		defer span.End()
+
+		traceID := span.Context().TraceID.String()
+		reqCtx = logging.WithLogger(reqCtx, logging.FromContext(reqCtx).WithField("trace_id", traceID))
+
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Header(tracing.TraceparentHeader, span.Context().Traceparent())
+		ctx.Next()
+
+		route := RouteTemplate(ctx)
+		status := ctx.Writer.Status()
+
+		span.SetName(ctx.Request.Method + " " + route)
+		span.SetAttribute("http.method", ctx.Request.Method)
+		span.SetAttribute("http.route", route)
+		span.SetAttribute("http.status_code", status)
+		span.SetAttribute("request_id", logging.RequestID(reqCtx))
+
+		if status >= http.StatusInternalServerError {
+			span.SetError(errors.New(http.StatusText(status)))
+			if err := ctx.Errors.Last(); err != nil {
+				span.SetError(err.Err)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/api"
+	"github.com/gghcode/go-gin-starterkit/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type tracingUnit struct {
+	suite.Suite
+}
+
+func TestTracingMiddlewareUnit(t *testing.T) {
+	suite.Run(t, new(tracingUnit))
+}
+
+func (suite *tracingUnit) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *tracingUnit) TestTracing() {
+	testCases := []struct {
+		description         string
+		traceparent         string
+		status              int
+		expectedTraceID     string
+		expectedParentID    string
+		expectedServerError bool
+	}{
+		{
+			description:      "ShouldContinueTrace_WhenTraceparentIsValid",
+			traceparent:      "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			status:           http.StatusOK,
+			expectedTraceID:  "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedParentID: "00f067aa0ba902b7",
+		},
+		{
+			description: "ShouldStartTrace_WhenTraceparentIsInvalid",
+			traceparent: "00-invalid-01",
+			status:      http.StatusOK,
+		},
+		{
+			description:         "ShouldMarkError_WhenServerError",
+			status:              http.StatusInternalServerError,
+			expectedServerError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			logger, _ := test.NewNullLogger()
+			exporter := &recordExporter{}
+			tracer := tracing.NewTracerWithExporter(exporter, logger)
+
+			recorder := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/users/1", nil)
+			req.Header.Set(tracing.TraceparentHeader, tc.traceparent)
+
+			_, engine := gin.CreateTestContext(recorder)
+
+			engine.Use(RequestID(logger))
+			engine.Use(Tracing(tracer))
+			api.NewRouter(&engine.RouterGroup).Handle("GET", "/users/:id", func(ctx *gin.Context) {
+				_, span := tracing.StartSpan(ctx.Request.Context(), "repository", tracing.SpanKindInternal)
+				span.End()
+
+				ctx.Status(tc.status)
+			})
+			engine.ServeHTTP(recorder, req)
+
+			suite.Require().NoError(tracer.Shutdown(context.Background()))
+			suite.Require().Len(exporter.spans, 2)
+
+			child, server := exporter.spans[0], exporter.spans[1]
+
+			suite.Equal("GET /users/:id", server.Name)
+			suite.Equal(tracing.SpanKindServer, server.Kind)
+			suite.Equal("/users/:id", server.Attributes["http.route"])
+			suite.Equal(tc.status, server.Attributes["http.status_code"])
+			suite.Equal(recorder.Header().Get(RequestIDHeader), server.Attributes["request_id"])
+			suite.Equal(tc.expectedParentID, server.ParentSpanID)
+			suite.Equal(tc.expectedServerError, server.Error != "")
+
+			if tc.expectedTraceID != "" {
+				suite.Equal(tc.expectedTraceID, server.TraceID)
+			}
+
+			suite.Equal(server.TraceID, child.TraceID)
+			suite.Equal(server.SpanID, child.ParentSpanID)
+
+			expectedHeader := "00-" + server.TraceID + "-" + server.SpanID + "-01"
+			suite.Equal(expectedHeader, recorder.Header().Get(tracing.TraceparentHeader))
+		})
+	}
+}
+
+type recordExporter struct {
+	mutex sync.Mutex
+	spans []tracing.SpanData
+}
+
+func (exporter *recordExporter) Export(ctx context.Context, spans []tracing.SpanData) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	exporter.spans = append(exporter.spans, spans...)
+	return nil
+}
+
+func (exporter *recordExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/db"
+	"github.com/gghcode/go-gin-starterkit/db/migration"
+	"github.com/pkg/errors"
+)
+
+const migrateUsage = "usage: migrate up | migrate down N | migrate status"
+
+// runMigrate run migrate subcommand by args.
+func runMigrate(out io.Writer, dbConn *db.Conn, args []string) error {
+	migrator := migration.NewMigrator(dbConn, migration.Migrations)
+
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Fprintf(out, "applied %d_%s\n", m.Version, m.Name)
+		}
+
+		return err
+	case "down":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return errors.Errorf("invalid number of migrations: %s", args[1])
+		}
+
+		reverted, err := migrator.Down(n)
+		for _, m := range reverted {
+			fmt.Fprintf(out, "reverted %d_%s\n", m.Version, m.Name)
+		}
+
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return w.Flush()
+	}
+
+	return errors.New(migrateUsage)
+}
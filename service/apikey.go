@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrInvalidAPIKey is occurred when api key is malformed, unknown or revoked.
+	ErrInvalidAPIKey = errors.New("Invalid api key")
+
+	// ErrAPIKeyExpired is occurred when api key is expired.
+	ErrAPIKeyExpired = errors.New("Api key expired")
+)
+
+// APIKeyIdentity is user and grants of authenticated api key.
+type APIKeyIdentity struct {
+	KeyID  int64
+	UserID int64
+	Role   string
+	Scopes []string
+}
+
+// APIKeyAuthenticator authenticate personal api keys,
+// so auth middleware accepts them next to access tokens.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (APIKeyIdentity, error)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/db"
+)
+
+const prefixTokenDenylist = "access_token_denylist"
+
+// TokenDenylist keeps ids of revoked tokens until they expire.
+type TokenDenylist interface {
+	Revoke(ctx context.Context, id string, ttl time.Duration) error
+	IsRevoked(ctx context.Context, ids ...string) (bool, error)
+}
+
+type tokenDenylist struct {
+	redis db.RedisConn
+}
+
+// NewTokenDenylist return new token denylist.
+func NewTokenDenylist(redisConn db.RedisConn) TokenDenylist {
+	return &tokenDenylist{
+		redis: redisConn,
+	}
+}
+
+func (denylist *tokenDenylist) Revoke(ctx context.Context, id string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return denylist.redis.Set(ctx, TokenDenylistRedisStorageKey(id), "1", ttl)
+}
+
+func (denylist *tokenDenylist) IsRevoked(ctx context.Context, ids ...string) (bool, error) {
+	var keys []string
+	for _, id := range ids {
+		if id != "" {
+			keys = append(keys, TokenDenylistRedisStorageKey(id))
+		}
+	}
+
+	if len(keys) == 0 {
+		return false, nil
+	}
+
+	count, err := denylist.redis.Exists(ctx, keys...)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// TokenDenylistRedisStorageKey return key of revoked token id.
+func TokenDenylistRedisStorageKey(id string) string {
+	return fmt.Sprintf("%s_%s", prefixTokenDenylist, id)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+var (
+	// SigningMethodEdDSA is Ed25519 signing method which jwt-go doesn't provide.
+	SigningMethodEdDSA = &signingMethodEdDSA{}
+
+	// ErrInvalidEdDSAKey is occurred when key is not ed25519 key
+	ErrInvalidEdDSAKey = errors.New("Key is not valid ed25519 key")
+
+	// ErrEdDSAVerification is occurred when signature is invalid
+	ErrEdDSAVerification = errors.New("ed25519: verification error")
+)
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+type signingMethodEdDSA struct{}
+
+func (method *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+func (method *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok || len(publicKey) != ed25519.PublicKeySize {
+		return ErrInvalidEdDSAKey
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return ErrEdDSAVerification
+	}
+
+	return nil
+}
+
+func (method *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok || len(privateKey) != ed25519.PrivateKeySize {
+		return "", ErrInvalidEdDSAKey
+	}
+
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrUnsupportedAlgorithm is occurred when configured algorithm is not supported.
+	ErrUnsupportedAlgorithm = errors.New("Unsupported jwt algorithm")
+
+	// ErrUnexpectedSigningMethod is occurred when token was signed by other algorithm.
+	ErrUnexpectedSigningMethod = errors.New("Unexpected signing method")
+
+	// ErrUnknownKeyID is occurred when token was signed by unknown key.
+	ErrUnknownKeyID = errors.New("Unknown key id")
+
+	// ErrRetiredKey is occurred when token was signed by retired key.
+	ErrRetiredKey = errors.New("Key was retired")
+
+	// ErrInvalidSigningKey is occurred when signing key is missing or can't sign.
+	ErrInvalidSigningKey = errors.New("Invalid signing key")
+)
+
+// AccessTokenType is typ claim of access tokens,
+// so other tokens signed by same keys are not accepted as access tokens.
+const AccessTokenType = "access"
+
+// KeyProvider signs and verifies jwt by configured algorithm and keys.
+type KeyProvider interface {
+	Sign(claims jwt.Claims) (string, error)
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	PublicKeys() JSONWebKeySet
+	Algorithm() string
+}
+
+// JSONWebKeySet is public key set described in RFC 7517.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey is public key described in RFC 7517.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jwtKey struct {
+	id         string
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+	retiresAt  time.Time
+}
+
+func (key jwtKey) isRetired(now time.Time) bool {
+	return !key.retiresAt.IsZero() && !now.Before(key.retiresAt)
+}
+
+type keyProvider struct {
+	method       jwt.SigningMethod
+	secretKey    []byte
+	signingKeyID string
+	keys         map[string]jwtKey
+	keyIDs       []string
+}
+
+// NewKeyProvider return new key provider.
+// HS256 signs by secret key, otherwise keys are loaded from PEM files.
+func NewKeyProvider(conf config.Configuration) (KeyProvider, error) {
+	algorithm := conf.Jwt.Algorithm
+	if algorithm == "" {
+		algorithm = jwt.SigningMethodHS256.Alg()
+	}
+
+	provider := keyProvider{
+		method:       jwt.GetSigningMethod(algorithm),
+		signingKeyID: conf.Jwt.SigningKeyID,
+		keys:         map[string]jwtKey{},
+	}
+
+	switch provider.method {
+	case jwt.SigningMethodHS256:
+		provider.secretKey = []byte(conf.Jwt.SecretKey)
+		return &provider, nil
+	case jwt.SigningMethodRS256, jwt.SigningMethodES256, SigningMethodEdDSA:
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	for _, keyConf := range conf.Jwt.Keys {
+		key, err := loadJwtKey(keyConf)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isKeyOfMethod(key.publicKey, provider.method) {
+			return nil, errors.Errorf("key %s is not %s key", key.id, algorithm)
+		}
+
+		provider.keys[key.id] = key
+		provider.keyIDs = append(provider.keyIDs, key.id)
+	}
+
+	signingKey, ok := provider.keys[provider.signingKeyID]
+	if !ok || signingKey.privateKey == nil || signingKey.isRetired(time.Now()) {
+		return nil, ErrInvalidSigningKey
+	}
+
+	return &provider, nil
+}
+
+func (provider *keyProvider) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(provider.method, claims)
+
+	if provider.signingKeyID != "" {
+		token.Header["kid"] = provider.signingKeyID
+	}
+
+	if provider.secretKey != nil {
+		return token.SignedString(provider.secretKey)
+	}
+
+	return token.SignedString(provider.keys[provider.signingKeyID].privateKey)
+}
+
+func (provider *keyProvider) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != provider.method.Alg() {
+		return nil, ErrUnexpectedSigningMethod
+	}
+
+	if provider.secretKey != nil {
+		return provider.secretKey, nil
+	}
+
+	keyID, _ := token.Header["kid"].(string)
+
+	key, ok := provider.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	if key.isRetired(time.Now()) {
+		return nil, ErrRetiredKey
+	}
+
+	return key.publicKey, nil
+}
+
+func (provider *keyProvider) PublicKeys() JSONWebKeySet {
+	keySet := JSONWebKeySet{
+		Keys: []JSONWebKey{},
+	}
+
+	now := time.Now()
+	for _, keyID := range provider.keyIDs {
+		key := provider.keys[keyID]
+		if key.isRetired(now) {
+			continue
+		}
+
+		keySet.Keys = append(keySet.Keys, newJSONWebKey(key, provider.method.Alg()))
+	}
+
+	return keySet
+}
+
+// Algorithm return name of algorithm that tokens are signed by.
+func (provider *keyProvider) Algorithm() string {
+	return provider.method.Alg()
+}
+
+func loadJwtKey(keyConf config.JwtKeyConfig) (jwtKey, error) {
+	key := jwtKey{
+		id: keyConf.ID,
+	}
+
+	if keyConf.RetiresAt != "" {
+		retiresAt, err := time.Parse(time.RFC3339, keyConf.RetiresAt)
+		if err != nil {
+			return jwtKey{}, errors.Wrapf(err, "key %s has invalid retires_at", key.id)
+		}
+
+		key.retiresAt = retiresAt
+	}
+
+	pemBytes, err := ioutil.ReadFile(keyConf.File)
+	if err != nil {
+		return jwtKey{}, errors.Wrapf(err, "key %s can't be read", key.id)
+	}
+
+	parsedKey, err := parsePEMKey(pemBytes)
+	if err != nil {
+		return jwtKey{}, errors.Wrapf(err, "key %s can't be parsed", key.id)
+	}
+
+	if signer, ok := parsedKey.(crypto.Signer); ok {
+		key.privateKey = signer
+		key.publicKey = signer.Public()
+	} else {
+		key.publicKey = parsedKey
+	}
+
+	return key, nil
+}
+
+func parsePEMKey(pemBytes []byte) (interface{}, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+
+	return nil, errors.Errorf("unsupported PEM block type %s", block.Type)
+}
+
+func isKeyOfMethod(publicKey crypto.PublicKey, method jwt.SigningMethod) bool {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return method == jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		return method == jwt.SigningMethodES256 && key.Curve == elliptic.P256()
+	case ed25519.PublicKey:
+		return method == SigningMethodEdDSA
+	}
+
+	return false
+}
+
+func newJSONWebKey(key jwtKey, alg string) JSONWebKey {
+	jwk := JSONWebKey{
+		Kid: key.id,
+		Use: "sig",
+		Alg: alg,
+	}
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeBase64URL(publicKey.N.Bytes())
+		jwk.E = encodeBase64URL(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+
+		jwk.Kty = "EC"
+		jwk.Crv = publicKey.Curve.Params().Name
+		jwk.X = encodeBase64URL(padBytes(publicKey.X.Bytes(), size))
+		jwk.Y = encodeBase64URL(padBytes(publicKey.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeBase64URL(publicKey)
+	}
+
+	return jwk
+}
+
+func padBytes(bytes []byte, size int) []byte {
+	if len(bytes) >= size {
+		return bytes
+	}
+
+	return append(make([]byte, size-len(bytes)), bytes...)
+}
+
+func encodeBase64URL(bytes []byte) string {
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type keyProviderUnit struct {
+	suite.Suite
+
+	keyDir string
+}
+
+func TestKeyProviderUnit(t *testing.T) {
+	suite.Run(t, new(keyProviderUnit))
+}
+
+func (suite *keyProviderUnit) SetupTest() {
+	keyDir, err := ioutil.TempDir("", "keys")
+	suite.Require().NoError(err)
+
+	suite.keyDir = keyDir
+}
+
+func (suite *keyProviderUnit) TearDownTest() {
+	os.RemoveAll(suite.keyDir)
+}
+
+func (suite *keyProviderUnit) TestSignAndVerify() {
+	testCases := []struct {
+		description string
+		algorithm   string
+		keyFn       func() interface{}
+	}{
+		{
+			description: "ShouldVerify_WhenRS256",
+			algorithm:   "RS256",
+			keyFn: func() interface{} {
+				key, _ := rsa.GenerateKey(rand.Reader, 2048)
+				return key
+			},
+		},
+		{
+			description: "ShouldVerify_WhenES256",
+			algorithm:   "ES256",
+			keyFn: func() interface{} {
+				key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				return key
+			},
+		},
+		{
+			description: "ShouldVerify_WhenEdDSA",
+			algorithm:   "EdDSA",
+			keyFn: func() interface{} {
+				_, key, _ := ed25519.GenerateKey(rand.Reader)
+				return key
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			keys, err := service.NewKeyProvider(suite.configuration(tc.algorithm, "current",
+				suite.keyConfig("current", tc.keyFn(), ""),
+			))
+			suite.Require().NoError(err)
+
+			tokenString, err := keys.Sign(jwt.StandardClaims{Subject: "10"})
+			suite.Require().NoError(err)
+
+			token, err := jwt.Parse(tokenString, keys.Keyfunc)
+			suite.NoError(err)
+			suite.True(token.Valid)
+			suite.Equal(tc.algorithm, token.Header["alg"])
+			suite.Equal(tc.algorithm, keys.Algorithm())
+			suite.Equal("current", token.Header["kid"])
+
+			jwks := keys.PublicKeys()
+			suite.Len(jwks.Keys, 1)
+			suite.Equal("current", jwks.Keys[0].Kid)
+			suite.Equal(tc.algorithm, jwks.Keys[0].Alg)
+		})
+	}
+}
+
+func (suite *keyProviderUnit) TestKeyRotation() {
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	oldKeys, err := service.NewKeyProvider(suite.configuration("RS256", "old",
+		suite.keyConfig("old", oldKey, ""),
+	))
+	suite.Require().NoError(err)
+
+	oldTokenString, err := oldKeys.Sign(jwt.StandardClaims{Subject: "10"})
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		description  string
+		retiresAt    time.Time
+		expectedErr  error
+		expectedJWKS int
+	}{
+		{
+			description:  "ShouldVerify_WhenOldKeyNotRetired",
+			retiresAt:    time.Now().Add(time.Hour),
+			expectedErr:  nil,
+			expectedJWKS: 2,
+		},
+		{
+			description:  "ShouldReturnRetiredErr_WhenOldKeyRetired",
+			retiresAt:    time.Now().Add(-time.Hour),
+			expectedErr:  service.ErrRetiredKey,
+			expectedJWKS: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			keys, err := service.NewKeyProvider(suite.configuration("RS256", "new",
+				suite.keyConfig("old", oldKey, tc.retiresAt.Format(time.RFC3339)),
+				suite.keyConfig("new", newKey, ""),
+			))
+			suite.Require().NoError(err)
+
+			_, actualErr := jwt.Parse(oldTokenString, keys.Keyfunc)
+			if tc.expectedErr == nil {
+				suite.NoError(actualErr)
+			} else {
+				suite.Equal(tc.expectedErr, actualErr.(*jwt.ValidationError).Inner)
+			}
+
+			suite.Len(keys.PublicKeys().Keys, tc.expectedJWKS)
+		})
+	}
+}
+
+func (suite *keyProviderUnit) TestKeyfunc() {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	keys, err := service.NewKeyProvider(suite.configuration("RS256", "current",
+		suite.keyConfig("current", rsaKey, ""),
+	))
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		description   string
+		tokenStringFn func() string
+		expectedErr   error
+	}{
+		{
+			description: "ShouldReturnUnexpectedSigningMethodErr_WhenHS256ByPublicKey",
+			tokenStringFn: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{})
+				token.Header["kid"] = "current"
+
+				publicKeyBytes := x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)
+				tokenString, _ := token.SignedString(publicKeyBytes)
+
+				return tokenString
+			},
+			expectedErr: service.ErrUnexpectedSigningMethod,
+		},
+		{
+			description: "ShouldReturnUnknownKeyIDErr",
+			tokenStringFn: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{})
+				token.Header["kid"] = "unknown"
+
+				tokenString, _ := token.SignedString(rsaKey)
+
+				return tokenString
+			},
+			expectedErr: service.ErrUnknownKeyID,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			_, actualErr := jwt.Parse(tc.tokenStringFn(), keys.Keyfunc)
+
+			suite.Equal(tc.expectedErr, actualErr.(*jwt.ValidationError).Inner)
+		})
+	}
+}
+
+func (suite *keyProviderUnit) TestNewKeyProvider() {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	testCases := []struct {
+		description string
+		conf        config.Configuration
+		expectedErr error
+	}{
+		{
+			description: "ShouldReturnUnsupportedErr",
+			conf:        suite.configuration("none", ""),
+			expectedErr: service.ErrUnsupportedAlgorithm,
+		},
+		{
+			description: "ShouldReturnInvalidSigningKeyErr_WhenSigningKeyNotExists",
+			conf: suite.configuration("RS256", "unknown",
+				suite.keyConfig("current", rsaKey, ""),
+			),
+			expectedErr: service.ErrInvalidSigningKey,
+		},
+		{
+			description: "ShouldReturnInvalidSigningKeyErr_WhenSigningKeyRetired",
+			conf: suite.configuration("RS256", "current",
+				suite.keyConfig("current", rsaKey, time.Now().Add(-time.Hour).Format(time.RFC3339)),
+			),
+			expectedErr: service.ErrInvalidSigningKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			_, actualErr := service.NewKeyProvider(tc.conf)
+
+			suite.Equal(tc.expectedErr, actualErr)
+		})
+	}
+}
+
+func (suite *keyProviderUnit) configuration(
+	algorithm, signingKeyID string, keys ...config.JwtKeyConfig) config.Configuration {
+
+	return config.Configuration{
+		Jwt: config.JwtConfig{
+			Algorithm:    algorithm,
+			SigningKeyID: signingKeyID,
+			Keys:         keys,
+		},
+	}
+}
+
+func (suite *keyProviderUnit) keyConfig(
+	id string, privateKey interface{}, retiresAt string) config.JwtKeyConfig {
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	suite.Require().NoError(err)
+
+	filePath := filepath.Join(suite.keyDir, id+".pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+
+	err = ioutil.WriteFile(filePath, pemBytes, 0600)
+	suite.Require().NoError(err)
+
+	return config.JwtKeyConfig{
+		ID:        id,
+		File:      filePath,
+		RetiresAt: retiresAt,
+	}
+}
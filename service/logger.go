@@ -0,0 +1,43 @@
+package service
+
+import (
+	"os"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LogFormatJSON is log format that write an entry as json object per line.
+	LogFormatJSON = "json"
+
+	// LogFormatText is log format that write an entry as key=value pairs.
+	LogFormatText = "text"
+)
+
+// ErrInvalidLogFormat is occurred when configured log format is not supported.
+var ErrInvalidLogFormat = errors.New("Log format is invalid")
+
+// NewLogger return new logger configured by log level and format.
+func NewLogger(conf config.Configuration) (logrus.FieldLogger, error) {
+	level, err := logrus.ParseLevel(conf.Log.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stdout)
+	logger.SetLevel(level)
+
+	switch conf.Log.Format {
+	case LogFormatJSON:
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	case LogFormatText:
+		logger.SetFormatter(&logrus.TextFormatter{})
+	default:
+		return nil, ErrInvalidLogFormat
+	}
+
+	return logger, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/logging"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// NotifierLog write notifications to logger, so it is meant for development.
+	NotifierLog = "log"
+
+	// NotifierFile append notifications to file as json lines.
+	NotifierFile = "file"
+)
+
+// ErrInvalidNotifier is occurred when configured notifier is not supported.
+var ErrInvalidNotifier = errors.New("Notifier is invalid")
+
+// ErrNotifierNotConfigured is occurred when no notifier is configured.
+var ErrNotifierNotConfigured = errors.New("Notifier is not configured")
+
+// Notification is message delivered to user.
+type Notification struct {
+	UserID   int64     `json:"user_id"`
+	UserName string    `json:"username"`
+	Subject  string    `json:"subject"`
+	Body     string    `json:"body"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// Notifier deliver notifications to users
+// such as password reset tokens.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// NewNotifier return notifier selected by conf.Notifier.Type.
+// There is no default, so log notifier is never used unless chosen explicitly.
+func NewNotifier(conf config.Configuration, logger logrus.FieldLogger) (Notifier, error) {
+	switch conf.Notifier.Type {
+	case "":
+		return nil, ErrNotifierNotConfigured
+	case NotifierLog:
+		logger.Warn("log notifier writes password reset tokens to logs, do not use it in production")
+
+		return &logNotifier{logger: logger}, nil
+	case NotifierFile:
+		file, err := os.OpenFile(conf.Notifier.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewWriterNotifier(file), nil
+	}
+
+	return nil, ErrInvalidNotifier
+}
+
+type logNotifier struct {
+	logger logrus.FieldLogger
+}
+
+func (notifier *logNotifier) Notify(ctx context.Context, notification Notification) error {
+	notifier.logger.WithFields(logrus.Fields{
+		"request_id": logging.RequestID(ctx),
+		"user_id":    notification.UserID,
+		"username":   notification.UserName,
+		"subject":    notification.Subject,
+		"body":       notification.Body,
+	}).Info("notification sent")
+
+	return nil
+}
+
+type writerNotifier struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+// NewWriterNotifier return notifier that writes notifications to writer as json lines.
+func NewWriterNotifier(writer io.Writer) Notifier {
+	return &writerNotifier{writer: writer}
+}
+
+func (notifier *writerNotifier) Notify(ctx context.Context, notification Notification) error {
+	if notification.SentAt.IsZero() {
+		notification.SentAt = time.Now()
+	}
+
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	_, err = notifier.writer.Write(append(line, '\n'))
+	return err
+}
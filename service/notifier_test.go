@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotifier(t *testing.T) {
+	testCases := []struct {
+		description string
+		notifier    string
+		expectedErr error
+	}{
+		{
+			description: "ShouldReturnLogNotifier",
+			notifier:    service.NotifierLog,
+			expectedErr: nil,
+		},
+		{
+			description: "ShouldReturnNotConfiguredErr_WhenEmptyNotifier",
+			notifier:    "",
+			expectedErr: service.ErrNotifierNotConfigured,
+		},
+		{
+			description: "ShouldReturnInvalidNotifierErr_WhenUnknownNotifier",
+			notifier:    "smtp",
+			expectedErr: service.ErrInvalidNotifier,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var conf config.Configuration
+			conf.Notifier.Type = tc.notifier
+
+			logger, _ := test.NewNullLogger()
+
+			_, err := service.NewNotifier(conf, logger)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestLogNotifier(t *testing.T) {
+	var conf config.Configuration
+	conf.Notifier.Type = service.NotifierLog
+
+	logger, hook := test.NewNullLogger()
+
+	notifier, err := service.NewNotifier(conf, logger)
+	assert.NoError(t, err)
+
+	warning := hook.LastEntry()
+	assert.NotNil(t, warning)
+	assert.Equal(t, logrus.WarnLevel, warning.Level)
+
+	assert.NoError(t, notifier.Notify(context.Background(), service.Notification{
+		UserID:  10,
+		Subject: "Password reset",
+		Body:    "token",
+	}))
+
+	entry := hook.LastEntry()
+	assert.NotNil(t, entry)
+	assert.Equal(t, int64(10), entry.Data["user_id"])
+	assert.Equal(t, "token", entry.Data["body"])
+}
+
+func TestWriterNotifier(t *testing.T) {
+	var buf bytes.Buffer
+
+	notifier := service.NewWriterNotifier(&buf)
+	assert.NoError(t, notifier.Notify(context.Background(), service.Notification{
+		UserID:   10,
+		UserName: "username",
+		Subject:  "Password reset",
+		Body:     "token",
+	}))
+
+	var actual service.Notification
+	assert.NoError(t, json.NewDecoder(&buf).Decode(&actual))
+	assert.Equal(t, "username", actual.UserName)
+	assert.Equal(t, "token", actual.Body)
+	assert.False(t, actual.SentAt.IsZero())
+}
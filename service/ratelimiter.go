@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/gghcode/go-gin-starterkit/db"
+)
+
+const prefixRateLimit = "rate_limit"
+
+const (
+	// RateLimitToken is policy of issuing token that limits clients by ip.
+	RateLimitToken = "token"
+
+	// RateLimitRefresh is policy of refreshing token that limits clients by ip.
+	RateLimitRefresh = "refresh"
+
+	// RateLimitMFA is policy of verifying mfa code that limits clients by ip.
+	RateLimitMFA = "mfa"
+
+	// RateLimitSignup is policy of signing up that limits clients by ip.
+	RateLimitSignup = "signup"
+
+	// RateLimitPasswordReset is policy of resetting password that limits clients by ip.
+	RateLimitPasswordReset = "password_reset"
+
+	// RateLimitUser is policy of authenticated routes that limits users by id.
+	RateLimitUser = "user"
+)
+
+// RateLimiter count hits of clients against sliding window of policy.
+type RateLimiter interface {
+	// Allow record hit of key against policy.
+	// Result is always allowed with zero limit when policy is disabled.
+	Allow(ctx context.Context, policy, key string) (db.RateLimit, error)
+}
+
+type rateLimiter struct {
+	redis    db.RedisConn
+	policies map[string]config.RateLimitPolicy
+}
+
+// NewRateLimiter return new rate limiter of configured policies.
+// Hits are kept in process instead of redis when storage is memory.
+func NewRateLimiter(conf config.Configuration, redisConn db.RedisConn) RateLimiter {
+	if conf.RateLimit.Storage == config.StorageMemory {
+		redisConn = db.NewMemoryRedisConn()
+	}
+
+	return &rateLimiter{
+		redis: redisConn,
+		policies: map[string]config.RateLimitPolicy{
+			RateLimitToken:         conf.RateLimit.Token,
+			RateLimitRefresh:       conf.RateLimit.Refresh,
+			RateLimitMFA:           conf.RateLimit.MFA,
+			RateLimitSignup:        conf.RateLimit.Signup,
+			RateLimitUser:          conf.RateLimit.User,
+			RateLimitPasswordReset: conf.RateLimit.PasswordReset,
+		},
+	}
+}
+
+func (limiter *rateLimiter) Allow(ctx context.Context, policy, key string) (db.RateLimit, error) {
+	p := limiter.policies[policy]
+	if p.Limit <= 0 || p.WindowSec <= 0 {
+		return db.RateLimit{Allowed: true}, nil
+	}
+
+	return limiter.redis.SlidingWindow(
+		ctx,
+		RateLimitRedisStorageKey(policy, key),
+		p.Limit,
+		time.Duration(p.WindowSec)*time.Second,
+	)
+}
+
+// RateLimitRedisStorageKey return key of hits of client against policy.
+func RateLimitRedisStorageKey(policy, key string) string {
+	return fmt.Sprintf("%s_%s_%s", prefixRateLimit, policy, key)
+}
@@ -0,0 +1,45 @@
+package service
+
+const (
+	// RoleUser is default role of signed up user.
+	RoleUser = "user"
+
+	// RoleAdmin is role that can manage every user.
+	RoleAdmin = "admin"
+)
+
+// Permission is action that role is allowed to perform.
+type Permission string
+
+const (
+	// PermissionManageUsers allows changing and removing other users.
+	PermissionManageUsers Permission = "users:manage"
+
+	// PermissionManageRoles allows changing role of users.
+	PermissionManageRoles Permission = "roles:manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleUser: {},
+	RoleAdmin: {
+		PermissionManageUsers,
+		PermissionManageRoles,
+	},
+}
+
+// IsValidRole return true when role is known.
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission return true when role is allowed to perform permission.
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
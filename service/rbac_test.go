@@ -0,0 +1,42 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPermission(t *testing.T) {
+	testCases := []struct {
+		description string
+		role        string
+		permission  service.Permission
+		expected    bool
+	}{
+		{
+			description: "ShouldAllow_WhenAdmin",
+			role:        service.RoleAdmin,
+			permission:  service.PermissionManageUsers,
+			expected:    true,
+		},
+		{
+			description: "ShouldDeny_WhenUser",
+			role:        service.RoleUser,
+			permission:  service.PermissionManageUsers,
+			expected:    false,
+		},
+		{
+			description: "ShouldDeny_WhenUnknownRole",
+			role:        "unknown",
+			permission:  service.PermissionManageUsers,
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, service.HasPermission(tc.role, tc.permission))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+const (
+	// ScopeTodosRead allows reading todos of user.
+	ScopeTodosRead = "todos:read"
+
+	// ScopeTodosWrite allows creating, changing and removing todos of user.
+	ScopeTodosWrite = "todos:write"
+
+	// ScopeUsersRead allows reading users.
+	ScopeUsersRead = "users:read"
+
+	// ScopeUsersWrite allows changing and removing users that role of user is allowed to.
+	ScopeUsersWrite = "users:write"
+
+	// ScopeOpenID allows oauth clients to authenticate user by OpenID Connect.
+	ScopeOpenID = "openid"
+
+	// ScopeProfile allows oauth clients to read profile claims of user.
+	ScopeProfile = "profile"
+)
+
+// Scopes is every scope that restricted credentials can be granted.
+var Scopes = []string{
+	ScopeTodosRead,
+	ScopeTodosWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeOpenID,
+	ScopeProfile,
+}
+
+// IsValidScope return true when scope is known.
+func IsValidScope(scope string) bool {
+	return HasScope(Scopes, scope)
+}
+
+// HasScope return true when scopes contain scope.
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is shared secret of test vectors of RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).
+	EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	testCases := []struct {
+		description  string
+		unix         int64
+		expectedCode string
+	}{
+		{
+			description:  "ShouldMatchRFC6238_At59",
+			unix:         59,
+			expectedCode: "287082",
+		},
+		{
+			description:  "ShouldMatchRFC6238_At1111111109",
+			unix:         1111111109,
+			expectedCode: "081804",
+		},
+		{
+			description:  "ShouldMatchRFC6238_At1234567890",
+			unix:         1234567890,
+			expectedCode: "005924",
+		},
+		{
+			description:  "ShouldMatchRFC6238_At20000000000",
+			unix:         20000000000,
+			expectedCode: "353130",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			code, err := service.TOTPCode(rfc6238Secret, time.Unix(tc.unix, 0))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCode, code)
+		})
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	testCases := []struct {
+		description string
+		codeAt      time.Time
+		code        string
+		expected    bool
+	}{
+		{
+			description: "ShouldBeValid_WhenCurrentCode",
+			codeAt:      now,
+			expected:    true,
+		},
+		{
+			description: "ShouldBeValid_WhenPreviousCode",
+			codeAt:      now.Add(-service.TOTPPeriod),
+			expected:    true,
+		},
+		{
+			description: "ShouldBeInvalid_WhenCodeIsTooOld",
+			codeAt:      now.Add(-3 * service.TOTPPeriod),
+			expected:    false,
+		},
+		{
+			description: "ShouldBeInvalid_WhenCodeIsMalformed",
+			code:        "12345",
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			code := tc.code
+			if code == "" {
+				code, _ = service.TOTPCode(rfc6238Secret, tc.codeAt)
+			}
+
+			_, valid := service.ValidateTOTP(rfc6238Secret, code, now)
+			assert.Equal(t, tc.expected, valid)
+		})
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	secret, err := service.NewTOTPSecret()
+	assert.NoError(t, err)
+
+	uri, err := url.Parse(service.TOTPURI("starterkit", "username", secret))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/starterkit:username", uri.Path)
+	assert.Equal(t, secret, uri.Query().Get("secret"))
+	assert.Equal(t, "starterkit", uri.Query().Get("issuer"))
+}
@@ -0,0 +1,290 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ExporterNone discard every span.
+	ExporterNone = "none"
+
+	// ExporterStdout write spans to stdout as json lines.
+	ExporterStdout = "stdout"
+
+	// ExporterFile append spans to file as json lines.
+	ExporterFile = "file"
+
+	// ExporterOTLP post spans to otlp/http collector as json.
+	ExporterOTLP = "otlp"
+)
+
+// ErrInvalidExporter is occurred when tracing exporter is not supported.
+var ErrInvalidExporter = errors.New("Tracing exporter is invalid")
+
+// Exporter send finished spans to backend.
+type Exporter interface {
+	Export(ctx context.Context, spans []SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// NewExporter return exporter selected by conf.Exporter.
+func NewExporter(conf config.TracingConfig) (Exporter, error) {
+	switch strings.ToLower(conf.Exporter) {
+	case "", ExporterNone:
+		return noneExporter{}, nil
+	case ExporterStdout:
+		return NewWriterExporter(os.Stdout, conf.ServiceName), nil
+	case ExporterFile:
+		file, err := os.OpenFile(conf.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewWriterExporter(file, conf.ServiceName), nil
+	case ExporterOTLP:
+		return NewOTLPExporter(conf.Endpoint, conf.ServiceName, http.DefaultClient), nil
+	}
+
+	return nil, ErrInvalidExporter
+}
+
+type noneExporter struct{}
+
+func (noneExporter) Export(ctx context.Context, spans []SpanData) error { return nil }
+
+func (noneExporter) Shutdown(ctx context.Context) error { return nil }
+
+type writerExporter struct {
+	mutex       sync.Mutex
+	writer      io.Writer
+	serviceName string
+}
+
+type writerSpan struct {
+	ServiceName string `json:"service_name"`
+	SpanData
+}
+
+// NewWriterExporter return new exporter that writes one json line per span.
+// writer is closed on shutdown when it is io.Closer other than stdout.
+func NewWriterExporter(writer io.Writer, serviceName string) Exporter {
+	return &writerExporter{
+		writer:      writer,
+		serviceName: serviceName,
+	}
+}
+
+func (exporter *writerExporter) Export(ctx context.Context, spans []SpanData) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	encoder := json.NewEncoder(exporter.writer)
+	for _, span := range spans {
+		if err := encoder.Encode(writerSpan{exporter.serviceName, span}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (exporter *writerExporter) Shutdown(ctx context.Context) error {
+	closer, ok := exporter.writer.(io.Closer)
+	if !ok || exporter.writer == os.Stdout {
+		return nil
+	}
+
+	return closer.Close()
+}
+
+type otlpExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+}
+
+// NewOTLPExporter return new exporter that posts spans
+// to endpoint/v1/traces by otlp/http json encoding.
+func NewOTLPExporter(endpoint string, serviceName string, client *http.Client) Exporter {
+	return &otlpExporter{
+		url:         strings.TrimRight(endpoint, "/") + "/v1/traces",
+		serviceName: serviceName,
+		client:      client,
+	}
+}
+
+func (exporter *otlpExporter) Export(ctx context.Context, spans []SpanData) error {
+	body, err := json.Marshal(exporter.request(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, exporter.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := exporter.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("otlp collector responded %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (exporter *otlpExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+const otlpStatusError = 2
+
+var otlpKinds = map[SpanKind]int{
+	SpanKindInternal: 1,
+	SpanKindServer:   2,
+	SpanKindClient:   3,
+}
+
+func (exporter *otlpExporter) request(spans []SpanData) otlpRequest {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, otlpSpan{
+			TraceID:           span.TraceID,
+			SpanID:            span.SpanID,
+			ParentSpanID:      span.ParentSpanID,
+			Name:              span.Name,
+			Kind:              otlpKinds[span.Kind],
+			StartTimeUnixNano: unixNano(span.StartTime),
+			EndTimeUnixNano:   unixNano(span.EndTime),
+			Attributes:        otlpAttributes(span.Attributes),
+			Status:            otlpSpanStatus(span.Error),
+		})
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: otlpAttributes(map[string]interface{}{
+						"service.name": exporter.serviceName,
+					}),
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "github.com/gghcode/go-gin-starterkit/tracing"},
+						Spans: otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func otlpSpanStatus(errMessage string) otlpStatus {
+	if errMessage == "" {
+		return otlpStatus{}
+	}
+
+	return otlpStatus{Code: otlpStatusError, Message: errMessage}
+}
+
+func otlpAttributes(attributes map[string]interface{}) []otlpKeyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var result []otlpKeyValue
+	for _, key := range keys {
+		result = append(result, otlpKeyValue{Key: key, Value: otlpValue(attributes[key])})
+	}
+
+	return result
+}
+
+func otlpValue(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case bool:
+		return map[string]interface{}{"boolValue": v}
+	case int:
+		return map[string]interface{}{"intValue": strconv.Itoa(v)}
+	case int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case float64:
+		return map[string]interface{}{"doubleValue": v}
+	case string:
+		return map[string]interface{}{"stringValue": v}
+	}
+
+	return map[string]interface{}{"stringValue": fmt.Sprint(value)}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
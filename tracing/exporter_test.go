@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type exporterUnit struct {
+	suite.Suite
+}
+
+func TestExporterUnit(t *testing.T) {
+	suite.Run(t, new(exporterUnit))
+}
+
+var fakeSpan = SpanData{
+	TraceID:      "4bf92f3577b34da6a3ce929d0e0e4736",
+	SpanID:       "00f067aa0ba902b7",
+	ParentSpanID: "b7ad6b7169203331",
+	Name:         "GET /users/:id",
+	Kind:         SpanKindServer,
+	StartTime:    time.Unix(1, 0),
+	EndTime:      time.Unix(2, 0),
+	Attributes: map[string]interface{}{
+		"http.status_code": 500,
+		"http.route":       "/users/:id",
+	},
+	Error: "Internal Server Error",
+}
+
+func (suite *exporterUnit) TestNewExporter_ShouldReturnErr_WhenInvalidExporter() {
+	_, err := NewExporter(config.TracingConfig{Exporter: "zipkin"})
+
+	suite.Equal(ErrInvalidExporter, err)
+}
+
+func (suite *exporterUnit) TestWriterExporter() {
+	var buf bytes.Buffer
+	exporter := NewWriterExporter(&buf, "starterkit")
+
+	suite.Require().NoError(exporter.Export(context.Background(), []SpanData{fakeSpan, fakeSpan}))
+
+	decoder := json.NewDecoder(&buf)
+	for i := 0; i < 2; i++ {
+		var actual map[string]interface{}
+		suite.Require().NoError(decoder.Decode(&actual))
+
+		suite.Equal("starterkit", actual["service_name"])
+		suite.Equal(fakeSpan.TraceID, actual["trace_id"])
+		suite.Equal(fakeSpan.ParentSpanID, actual["parent_span_id"])
+		suite.Equal(fakeSpan.Name, actual["name"])
+		suite.Equal("server", actual["kind"])
+		suite.Equal(fakeSpan.Error, actual["error"])
+	}
+
+	suite.False(decoder.More())
+}
+
+func (suite *exporterUnit) TestOTLPExporter() {
+	var actualPath, actualContentType string
+	var actualBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		actualContentType = r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&actualBody)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL+"/", "starterkit", server.Client())
+	suite.Require().NoError(exporter.Export(context.Background(), []SpanData{fakeSpan}))
+
+	suite.Equal("/v1/traces", actualPath)
+	suite.Equal("application/json", actualContentType)
+
+	expected := `{"resourceSpans":[{
+		"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"starterkit"}}]},
+		"scopeSpans":[{
+			"scope":{"name":"github.com/gghcode/go-gin-starterkit/tracing"},
+			"spans":[{
+				"traceId":"4bf92f3577b34da6a3ce929d0e0e4736",
+				"spanId":"00f067aa0ba902b7",
+				"parentSpanId":"b7ad6b7169203331",
+				"name":"GET /users/:id",
+				"kind":2,
+				"startTimeUnixNano":"1000000000",
+				"endTimeUnixNano":"2000000000",
+				"attributes":[
+					{"key":"http.route","value":{"stringValue":"/users/:id"}},
+					{"key":"http.status_code","value":{"intValue":"500"}}
+				],
+				"status":{"code":2,"message":"Internal Server Error"}
+			}]
+		}]
+	}]}`
+
+	actual, err := json.Marshal(actualBody)
+	suite.Require().NoError(err)
+	suite.JSONEq(expected, string(actual))
+}
+
+func (suite *exporterUnit) TestOTLPExporter_ShouldReturnErr_WhenCollectorFailed() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL, "starterkit", server.Client())
+
+	suite.Error(exporter.Export(context.Background(), []SpanData{fakeSpan}))
+}
+
+type recordExporter struct {
+	mutex    sync.Mutex
+	spans    []SpanData
+	shutdown bool
+}
+
+func (exporter *recordExporter) Export(ctx context.Context, spans []SpanData) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	exporter.spans = append(exporter.spans, spans...)
+	return nil
+}
+
+func (exporter *recordExporter) Shutdown(ctx context.Context) error {
+	exporter.shutdown = true
+	return nil
+}
+
+func nopLogger() logrus.FieldLogger {
+	logger, _ := test.NewNullLogger()
+	return logger
+}
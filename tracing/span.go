@@ -0,0 +1,264 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SpanKind is role of span in trace.
+type SpanKind string
+
+const (
+	// SpanKindServer is kind of span that handles remote request.
+	SpanKindServer SpanKind = "server"
+
+	// SpanKindClient is kind of span that calls remote service such as redis.
+	SpanKindClient SpanKind = "client"
+
+	// SpanKindInternal is kind of span that runs in process.
+	SpanKindInternal SpanKind = "internal"
+)
+
+// TraceparentHeader is W3C trace context header.
+const TraceparentHeader = "traceparent"
+
+// ErrInvalidTraceparent is occurred when traceparent header is malformed.
+var ErrInvalidTraceparent = errors.New("Traceparent is invalid")
+
+// TraceID identify trace.
+type TraceID [16]byte
+
+// SpanID identify span in trace.
+type SpanID [8]byte
+
+// String return hex encoded trace id.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid report whether id is not all zero.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// String return hex encoded span id.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid report whether id is not all zero.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// SpanContext is part of span that propagates to children and remote services.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid report whether both trace id and span id are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent return span context formatted as W3C traceparent header.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent parse W3C traceparent header.
+func ParseTraceparent(header string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	// version 00 has exactly four fields, later versions may append more.
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	var sc SpanContext
+	if !decodeHex(parts[1], sc.TraceID[:]) || !decodeHex(parts[2], sc.SpanID[:]) {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	var flags [1]byte
+	if !decodeHex(parts[3], flags[:]) || !sc.IsValid() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	sc.Sampled = flags[0]&0x01 == 0x01
+
+	return sc, nil
+}
+
+func decodeHex(src string, dst []byte) bool {
+	if len(src) != hex.EncodedLen(len(dst)) || strings.ToLower(src) != src {
+		return false
+	}
+
+	_, err := hex.Decode(dst, []byte(src))
+	return err == nil
+}
+
+// SpanData is finished span that is passed to exporter.
+type SpanData struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Name         string                 `json:"name"`
+	Kind         SpanKind               `json:"kind"`
+	StartTime    time.Time              `json:"start_time"`
+	EndTime      time.Time              `json:"end_time"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// Span is timed operation of trace.
+// Every method is safe to call on nil span, so callers need no tracing checks.
+type Span struct {
+	tracer *tracer
+	sc     SpanContext
+
+	mutex sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+// Context return span context of span.
+func (span *Span) Context() SpanContext {
+	if span == nil {
+		return SpanContext{}
+	}
+
+	return span.sc
+}
+
+// SetName rename span such as when route is known after handling.
+func (span *Span) SetName(name string) {
+	if span == nil {
+		return
+	}
+
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	span.data.Name = name
+}
+
+// SetAttribute set attribute of span.
+func (span *Span) SetAttribute(key string, value interface{}) {
+	if span == nil {
+		return
+	}
+
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	if span.data.Attributes == nil {
+		span.data.Attributes = map[string]interface{}{}
+	}
+
+	span.data.Attributes[key] = value
+}
+
+// SetError mark span failed by err. nil err is ignored.
+func (span *Span) SetError(err error) {
+	if span == nil || err == nil {
+		return
+	}
+
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	span.data.Error = err.Error()
+}
+
+// End finish span and pass it to exporter when sampled.
+func (span *Span) End() {
+	if span == nil {
+		return
+	}
+
+	span.mutex.Lock()
+	if span.ended {
+		span.mutex.Unlock()
+		return
+	}
+
+	span.ended = true
+	span.data.EndTime = time.Now()
+	data := span.data
+	span.mutex.Unlock()
+
+	if span.sc.Sampled {
+		span.tracer.enqueue(data)
+	}
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan return copy of ctx that carries span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext return span carried by ctx or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// StartSpan start child span of span carried by ctx.
+// nil span is returned when ctx carries no span.
+func StartSpan(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+
+	span := parent.tracer.newSpan(name, kind, parent.sc)
+	return ContextWithSpan(ctx, span), span
+}
+
+// Trace run fn within internal span of name started by StartSpan
+// and mark span failed by error that fn returned.
+func Trace(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, span := StartSpan(ctx, name, SpanKindInternal)
+	defer span.End()
+
+	err := fn(ctx)
+	span.SetError(err)
+
+	return err
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	rand.Read(id[:])
+	return id
+}
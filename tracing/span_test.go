@@ -0,0 +1,183 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type spanUnit struct {
+	suite.Suite
+}
+
+func TestSpanUnit(t *testing.T) {
+	suite.Run(t, new(spanUnit))
+}
+
+func (suite *spanUnit) TestParseTraceparent() {
+	testCases := []struct {
+		description     string
+		header          string
+		expectedTraceID string
+		expectedSpanID  string
+		expectedSampled bool
+		expectedErr     error
+	}{
+		{
+			description:     "ShouldParseSampled",
+			header:          "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedSpanID:  "00f067aa0ba902b7",
+			expectedSampled: true,
+		},
+		{
+			description:     "ShouldParseNotSampled",
+			header:          "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedSpanID:  "00f067aa0ba902b7",
+			expectedSampled: false,
+		},
+		{
+			description:     "ShouldParse_WhenFutureVersionHasMoreFields",
+			header:          "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedSpanID:  "00f067aa0ba902b7",
+			expectedSampled: true,
+		},
+		{
+			description: "ShouldReturnErr_WhenEmpty",
+			header:      "",
+			expectedErr: ErrInvalidTraceparent,
+		},
+		{
+			description: "ShouldReturnErr_WhenVersionFF",
+			header:      "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedErr: ErrInvalidTraceparent,
+		},
+		{
+			description: "ShouldReturnErr_WhenVersion00HasMoreFields",
+			header:      "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			expectedErr: ErrInvalidTraceparent,
+		},
+		{
+			description: "ShouldReturnErr_WhenTraceIDIsZero",
+			header:      "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			expectedErr: ErrInvalidTraceparent,
+		},
+		{
+			description: "ShouldReturnErr_WhenSpanIDIsZero",
+			header:      "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			expectedErr: ErrInvalidTraceparent,
+		},
+		{
+			description: "ShouldReturnErr_WhenUpperCase",
+			header:      "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			expectedErr: ErrInvalidTraceparent,
+		},
+		{
+			description: "ShouldReturnErr_WhenTraceIDIsShort",
+			header:      "00-4bf92f3577b34da6-00f067aa0ba902b7-01",
+			expectedErr: ErrInvalidTraceparent,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.description, func() {
+			actual, err := ParseTraceparent(tc.header)
+
+			suite.Equal(tc.expectedErr, err)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			suite.Equal(tc.expectedTraceID, actual.TraceID.String())
+			suite.Equal(tc.expectedSpanID, actual.SpanID.String())
+			suite.Equal(tc.expectedSampled, actual.Sampled)
+		})
+	}
+}
+
+func (suite *spanUnit) TestTraceparent_ShouldRoundTrip() {
+	header := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	sc, err := ParseTraceparent(header)
+	suite.Require().NoError(err)
+
+	suite.Equal(header, sc.Traceparent())
+}
+
+func (suite *spanUnit) TestStartSpan_ShouldReturnNil_WhenContextHasNoSpan() {
+	ctx, span := StartSpan(context.Background(), "child", SpanKindInternal)
+
+	suite.Nil(span)
+	suite.Nil(SpanFromContext(ctx))
+
+	// nil span is safe to use.
+	span.SetAttribute("key", "value")
+	span.SetError(ErrInvalidTraceparent)
+	span.End()
+}
+
+func (suite *spanUnit) TestStartSpan_ShouldExportChildOfRemoteParent() {
+	exporter := &recordExporter{}
+	tracer := NewTracerWithExporter(exporter, nopLogger())
+
+	parent, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	suite.Require().NoError(err)
+
+	ctx, root := tracer.Start(context.Background(), "root", SpanKindServer, parent)
+	_, child := StartSpan(ctx, "child", SpanKindInternal)
+	child.SetError(ErrInvalidTraceparent)
+	child.End()
+	root.End()
+
+	suite.Require().NoError(tracer.Shutdown(context.Background()))
+	suite.Require().Len(exporter.spans, 2)
+
+	actualChild, actualRoot := exporter.spans[0], exporter.spans[1]
+
+	suite.Equal("4bf92f3577b34da6a3ce929d0e0e4736", actualRoot.TraceID)
+	suite.Equal("00f067aa0ba902b7", actualRoot.ParentSpanID)
+	suite.Equal(actualRoot.TraceID, actualChild.TraceID)
+	suite.Equal(actualRoot.SpanID, actualChild.ParentSpanID)
+	suite.Equal(ErrInvalidTraceparent.Error(), actualChild.Error)
+	suite.True(exporter.shutdown)
+}
+
+func (suite *spanUnit) TestTrace_ShouldExportFailedChild() {
+	exporter := &recordExporter{}
+	tracer := NewTracerWithExporter(exporter, nopLogger())
+
+	ctx, root := tracer.Start(context.Background(), "root", SpanKindServer, SpanContext{})
+	err := Trace(ctx, "child", func(ctx context.Context) error {
+		suite.NotEqual(root, SpanFromContext(ctx))
+		return ErrInvalidTraceparent
+	})
+	root.End()
+
+	suite.Equal(ErrInvalidTraceparent, err)
+	suite.Require().NoError(tracer.Shutdown(context.Background()))
+	suite.Require().Len(exporter.spans, 2)
+
+	actualChild, actualRoot := exporter.spans[0], exporter.spans[1]
+
+	suite.Equal("child", actualChild.Name)
+	suite.Equal(SpanKindInternal, actualChild.Kind)
+	suite.Equal(actualRoot.SpanID, actualChild.ParentSpanID)
+	suite.Equal(ErrInvalidTraceparent.Error(), actualChild.Error)
+}
+
+func (suite *spanUnit) TestStart_ShouldNotExport_WhenParentNotSampled() {
+	exporter := &recordExporter{}
+	tracer := NewTracerWithExporter(exporter, nopLogger())
+
+	parent, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	suite.Require().NoError(err)
+
+	_, span := tracer.Start(context.Background(), "root", SpanKindServer, parent)
+	span.End()
+
+	suite.Require().NoError(tracer.Shutdown(context.Background()))
+	suite.Empty(exporter.spans)
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gghcode/go-gin-starterkit/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	queueSize      = 2048
+	batchSize      = 256
+	exportInterval = 5 * time.Second
+)
+
+// Tracer start root spans and export finished spans in background.
+type Tracer interface {
+	// Start start span as child of remote parent.
+	// New trace is started when parent is invalid.
+	Start(ctx context.Context, name string, kind SpanKind, parent SpanContext) (context.Context, *Span)
+
+	// Shutdown export remaining spans and stop background export.
+	Shutdown(ctx context.Context) error
+}
+
+type tracer struct {
+	exporter Exporter
+	logger   logrus.FieldLogger
+
+	queue    chan SpanData
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewTracer return new tracer that exports spans by configured exporter.
+func NewTracer(conf config.Configuration, logger logrus.FieldLogger) (Tracer, error) {
+	exporter, err := NewExporter(conf.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTracerWithExporter(exporter, logger), nil
+}
+
+// NewTracerWithExporter return new tracer that exports spans by exporter.
+func NewTracerWithExporter(exporter Exporter, logger logrus.FieldLogger) Tracer {
+	return newTracer(exporter, logger, exportInterval)
+}
+
+func newTracer(exporter Exporter, logger logrus.FieldLogger, interval time.Duration) *tracer {
+	t := &tracer{
+		exporter: exporter,
+		logger:   logger,
+		queue:    make(chan SpanData, queueSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go t.run(interval)
+
+	return t
+}
+
+func (t *tracer) Start(ctx context.Context, name string, kind SpanKind, parent SpanContext) (context.Context, *Span) {
+	if !parent.IsValid() {
+		parent = SpanContext{TraceID: newTraceID(), Sampled: true}
+	}
+
+	span := t.newSpan(name, kind, parent)
+	return ContextWithSpan(ctx, span), span
+}
+
+func (t *tracer) newSpan(name string, kind SpanKind, parent SpanContext) *Span {
+	sc := SpanContext{
+		TraceID: parent.TraceID,
+		SpanID:  newSpanID(),
+		Sampled: parent.Sampled,
+	}
+
+	data := SpanData{
+		TraceID:   sc.TraceID.String(),
+		SpanID:    sc.SpanID.String(),
+		Name:      name,
+		Kind:      kind,
+		StartTime: time.Now(),
+	}
+
+	if parent.SpanID.IsValid() {
+		data.ParentSpanID = parent.SpanID.String()
+	}
+
+	return &Span{tracer: t, sc: sc, data: data}
+}
+
+func (t *tracer) enqueue(data SpanData) {
+	select {
+	case t.queue <- data:
+	default:
+		// drop span rather than block request when exporter falls behind.
+	}
+}
+
+func (t *tracer) run(interval time.Duration) {
+	defer close(t.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]SpanData, 0, batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := t.exporter.Export(context.Background(), batch); err != nil {
+			t.logger.WithError(err).Warn("export spans failed")
+		}
+
+		batch = make([]SpanData, 0, batchSize)
+	}
+
+	for {
+		select {
+		case data := <-t.queue:
+			batch = append(batch, data)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-t.stop:
+			for {
+				select {
+				case data := <-t.queue:
+					batch = append(batch, data)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (t *tracer) Shutdown(ctx context.Context) error {
+	t.stopOnce.Do(func() { close(t.stop) })
+
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return t.exporter.Shutdown(ctx)
+}